@@ -1,28 +1,55 @@
 package cmd
 
 import (
+	"bufio"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/dmoose/checkpoint/internal/file"
 	"github.com/dmoose/checkpoint/internal/git"
 	"github.com/dmoose/checkpoint/internal/schema"
+	"github.com/dmoose/checkpoint/internal/userconfig"
 	"github.com/dmoose/checkpoint/pkg/config"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
+var checkOpts struct {
+	format      string
+	amendInput  bool
+	interactive bool
+}
+
 func init() {
 	rootCmd.AddCommand(checkCmd)
+	checkCmd.Flags().StringVar(&checkOpts.format, "format", "", "Input file format: yaml or json")
+	checkCmd.Flags().BoolVar(&checkOpts.amendInput, "amend-last-input", false, "Restore the most recent input backup instead of regenerating from a fresh diff")
+	checkCmd.Flags().BoolVarP(&checkOpts.interactive, "interactive", "i", false, "Fill changes through terminal prompts instead of editing the input file")
 }
 
 var checkCmd = &cobra.Command{
 	Use:   "check [path]",
 	Short: "Generate input file for LLM",
 	Long: `Creates .checkpoint-input and .checkpoint-diff files.
-Guards against concurrent checkpoints with lock files.`,
+Guards against concurrent checkpoints with lock files.
+
+Use --format json to generate .checkpoint-input.json instead; commit and
+lint accept either file.
+
+Inputs are backed up to .checkpoint/.input-backups/ on clean and commit.
+Use --amend-last-input to restore the most recent one after an accidental clean.
+
+Use --interactive to walk the changed files and answer prompts for each
+change (summary, details, type, scope) instead of editing the input file.
+The default change type comes from 'checkpoint config set --global
+default_change_type <type>' when the project allows it.`,
 	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		projectPath := "."
@@ -34,12 +61,34 @@ Guards against concurrent checkpoints with lock files.`,
 			fmt.Fprintf(os.Stderr, "error: cannot resolve path: %v\n", err)
 			os.Exit(1)
 		}
-		Check(absPath)
+		CheckWithOptions(absPath, CheckOptions{Format: checkOpts.format, AmendLastInput: checkOpts.amendInput, Interactive: checkOpts.interactive})
 	},
 }
 
+// CheckOptions holds flags for the check command
+type CheckOptions struct {
+	Format         string // "yaml" or "json"; empty selects yaml
+	AmendLastInput bool   // Restore the latest input backup instead of generating
+	Interactive    bool   // Prompt for changes on the terminal
+}
+
+// maxInputBackups caps how many input backups are retained
+const maxInputBackups = 5
+
 // Check implements Phase 2: generate .checkpoint-input and .checkpoint-diff
 func Check(projectPath string) {
+	CheckWithOptions(projectPath, CheckOptions{})
+}
+
+// CheckWithOptions generates the input and diff files using the given options
+func CheckWithOptions(projectPath string, opts CheckOptions) {
+	format := strings.ToLower(opts.Format)
+	if format != "" && format != "yaml" && format != "json" {
+		fmt.Fprintf(os.Stderr, "error: unknown input format '%s'\n", opts.Format)
+		fmt.Fprintf(os.Stderr, "hint: use --format yaml or --format json\n")
+		os.Exit(1)
+	}
+
 	// Validate git repository (robust to worktrees)
 	if ok, err := git.IsGitRepository(projectPath); !ok {
 		if err != nil {
@@ -59,13 +108,33 @@ func Check(projectPath string) {
 	}
 
 	// Prevent overwriting an in-progress checkpoint
-	inputPath := filepath.Join(projectPath, config.InputFileName)
-	if file.Exists(inputPath) {
-		fmt.Fprintf(os.Stderr, "error: input file already exists at %s\n", inputPath)
+	if existing, ok := findInputFile(projectPath); ok {
+		fmt.Fprintf(os.Stderr, "error: input file already exists at %s\n", existing)
 		fmt.Fprintf(os.Stderr, "another checkpoint may be in progress; run 'checkpoint commit %s' or 'checkpoint clean %s' to resolve\n", projectPath, projectPath)
 		os.Exit(1)
 	}
 
+	// Restore the previous input instead of re-diffing
+	if opts.AmendLastInput && opts.Interactive {
+		fmt.Fprintf(os.Stderr, "error: --amend-last-input cannot be combined with --interactive\n")
+		os.Exit(1)
+	}
+	if opts.AmendLastInput {
+		restored, err := restoreLatestInputBackup(projectPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			fmt.Fprintf(os.Stderr, "hint: inputs are backed up on 'checkpoint clean' and 'checkpoint commit'; run 'checkpoint check' to start fresh\n")
+			os.Exit(1)
+		}
+		if err := file.WriteFile(lockPath, fmt.Sprintf("pid=%d\ntimestamp=%s\n", os.Getpid(), time.Now().Format(time.RFC3339))); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: failed to create lock file: %v\n", err)
+		}
+		fmt.Printf("✓ Restored previous input\n")
+		fmt.Printf("Input: %s\n", restored)
+		fmt.Printf("Next: continue editing, then run: checkpoint commit %s\n", projectPath)
+		return
+	}
+
 	// Create lock file
 	if err := file.WriteFile(lockPath, fmt.Sprintf("pid=%d\ntimestamp=%s\n", os.Getpid(), time.Now().Format(time.RFC3339))); err != nil {
 		fmt.Fprintf(os.Stderr, "error: failed to create lock file: %v\n", err)
@@ -111,10 +180,55 @@ func Check(projectPath string) {
 		}
 	}
 
+	rules, err := schema.LoadRules(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "hint: fix .checkpoint/%s or remove it to use the defaults\n", config.SchemaYaml)
+		_ = os.Remove(diffPath)
+		_ = os.Remove(lockPath)
+		os.Exit(1)
+	}
+	if user, err := userconfig.Load(); err == nil {
+		rules.DefaultType = user.DefaultChangeType
+	}
+
 	// Generate input file content (multi-change schema)
 	// Note: Project context and recent context removed to reduce file size
 	// LLM can read .checkpoint-project.yml and .checkpoint-context.yml directly if needed
+	inputPath := filepath.Join(projectPath, config.InputFileName)
 	inputContent := schema.GenerateInputTemplateWithMetadata(status, config.DiffFileName, prevNextSteps, filesChanged, nil, "", "")
+	if format == "json" {
+		inputPath = filepath.Join(projectPath, config.InputJSONFileName)
+		inputContent, err = schema.GenerateInputTemplateJSON(status, config.DiffFileName, prevNextSteps, filesChanged)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: failed to generate JSON input: %v\n", err)
+			_ = os.Remove(diffPath)
+			_ = os.Remove(lockPath)
+			os.Exit(1)
+		}
+	}
+	inputContent = rules.ApplyToTemplate(inputContent)
+	if opts.Interactive {
+		entry := &schema.CheckpointEntry{
+			SchemaVersion: schema.SchemaVersion,
+			Timestamp:     time.Now().Format(time.RFC3339),
+			GitStatus:     status,
+			DiffFile:      config.DiffFileName,
+			FilesChanged:  filesChanged,
+			NextSteps:     prevNextSteps,
+		}
+		paths := changedPaths(status, filesChanged)
+		err := promptEntry(os.Stdin, os.Stdout, entry, paths, rules)
+		if err == nil {
+			inputContent, err = renderInputEntry(entry, format)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			_ = os.Remove(diffPath)
+			_ = os.Remove(lockPath)
+			os.Exit(1)
+		}
+	}
 	if err := file.WriteFile(inputPath, inputContent); err != nil {
 		fmt.Fprintf(os.Stderr, "error: failed to write input file: %v\n", err)
 		_ = os.Remove(diffPath)
@@ -122,8 +236,301 @@ func Check(projectPath string) {
 		os.Exit(1)
 	}
 
+	if opts.Interactive {
+		fmt.Printf("\n✓ Checkpoint input written\n")
+		fmt.Printf("Input: %s\n", inputPath)
+		fmt.Printf("Next: review it (add context if useful), then run: checkpoint commit %s\n", projectPath)
+		return
+	}
+
 	fmt.Printf("✓ Checkpoint input generated\n")
 	fmt.Printf("Input: %s\n", inputPath)
 	fmt.Printf("Diff:  %s\n", diffPath)
 	fmt.Printf("Next: open the input, fill changes[], then run: checkpoint commit %s\n", projectPath)
 }
+
+// changedPaths returns the unique paths from git status and numstat, in order
+func changedPaths(status string, filesChanged []schema.FileChange) []string {
+	seen := make(map[string]bool)
+	var paths []string
+	add := func(p string) {
+		// Skip checkpoint's own artifacts (e.g. the lock file created above)
+		if strings.HasPrefix(filepath.Base(p), ".checkpoint-") {
+			return
+		}
+		if p != "" && !seen[p] {
+			seen[p] = true
+			paths = append(paths, p)
+		}
+	}
+	for _, fc := range filesChanged {
+		add(fc.Path)
+	}
+	for _, fs := range schema.ParseGitStatus(status) {
+		add(fs.Path)
+	}
+	return paths
+}
+
+// suggestScope derives a scope name from a file path: the first directory
+// below common source roots (cmd/, internal/, pkg/, src/), else the top-level
+// directory, else the file name without extension
+func suggestScope(path string) string {
+	parts := strings.Split(strings.TrimSuffix(filepath.ToSlash(path), "/"), "/")
+	if len(parts) > 2 {
+		switch parts[0] {
+		case "cmd", "internal", "pkg", "src", "lib", "app":
+			return parts[1]
+		}
+	}
+	if len(parts) > 1 {
+		if parts[0] == "cmd" {
+			return strings.TrimSuffix(parts[1], filepath.Ext(parts[1]))
+		}
+		return parts[0]
+	}
+	return strings.TrimSuffix(parts[0], filepath.Ext(parts[0]))
+}
+
+// groupPathsByScope groups paths by suggested scope, keeping first-seen order
+func groupPathsByScope(paths []string) ([]string, map[string][]string) {
+	groups := make(map[string][]string)
+	var order []string
+	for _, p := range paths {
+		scope := suggestScope(p)
+		if _, ok := groups[scope]; !ok {
+			order = append(order, scope)
+		}
+		groups[scope] = append(groups[scope], p)
+	}
+	return order, groups
+}
+
+// promptEntry walks the changed files grouped by scope, asking for one change
+// per group, then offers extra changes and an optional problem statement
+func promptEntry(in io.Reader, out io.Writer, entry *schema.CheckpointEntry, paths []string, rules schema.Rules) error {
+	r := bufio.NewReader(in)
+	order, groups := groupPathsByScope(paths)
+
+	if len(paths) == 0 {
+		_, _ = fmt.Fprintln(out, "No changed files detected.")
+	}
+	for _, scope := range order {
+		_, _ = fmt.Fprintf(out, "\nFiles in %s:\n", scope)
+		for _, p := range groups[scope] {
+			_, _ = fmt.Fprintf(out, "  %s\n", p)
+		}
+		change, ok, err := promptChange(r, out, scope, rules)
+		if err != nil {
+			return err
+		}
+		if ok {
+			entry.Changes = append(entry.Changes, change)
+		}
+	}
+
+	_, _ = fmt.Fprintln(out, "\nAdditional changes (leave summary blank to finish):")
+	for {
+		change, ok, err := promptChange(r, out, "", rules)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		entry.Changes = append(entry.Changes, change)
+	}
+	if len(entry.Changes) == 0 {
+		return fmt.Errorf("no changes entered; nothing to checkpoint")
+	}
+
+	problem, err := promptLine(r, out, "\nProblem statement (optional): ")
+	if err != nil {
+		return err
+	}
+	entry.Context.ProblemStatement = problem
+	return nil
+}
+
+// promptChange asks for one change; ok is false when the summary is left blank
+func promptChange(r *bufio.Reader, out io.Writer, defaultScope string, rules schema.Rules) (schema.Change, bool, error) {
+	var c schema.Change
+	for {
+		summary, err := promptLine(r, out, "Summary (blank to skip): ")
+		if err != nil {
+			return c, false, err
+		}
+		if summary == "" {
+			return c, false, nil
+		}
+		if n := len([]rune(summary)); n > rules.MaxSummaryLength {
+			_, _ = fmt.Fprintf(out, "  summary too long (%d > %d chars), try again\n", n, rules.MaxSummaryLength)
+			continue
+		}
+		c.Summary = summary
+		break
+	}
+
+	details, err := promptLine(r, out, "Details (optional): ")
+	if err != nil {
+		return c, false, err
+	}
+	c.Details = details
+
+	var options []string
+	for i, t := range rules.ChangeTypes {
+		options = append(options, fmt.Sprintf("%d) %s", i+1, t))
+	}
+	for c.ChangeType == "" {
+		answer, err := promptLine(r, out, fmt.Sprintf("Change type [%s] (default %s): ", strings.Join(options, " "), rules.DefaultChangeType()))
+		if err != nil {
+			return c, false, err
+		}
+		c.ChangeType = parseChangeType(answer, rules.ChangeTypes, rules.DefaultChangeType())
+		if c.ChangeType == "" {
+			_, _ = fmt.Fprintf(out, "  unknown change type '%s' (valid: %s)\n", answer, rules.ChangeTypeList())
+		}
+	}
+
+	label := "Scope (optional): "
+	if defaultScope != "" {
+		label = fmt.Sprintf("Scope [%s]: ", defaultScope)
+	}
+	scope, err := promptLine(r, out, label)
+	if err != nil {
+		return c, false, err
+	}
+	if scope == "" {
+		scope = defaultScope
+	}
+	c.Scope = scope
+	return c, true, nil
+}
+
+// parseChangeType accepts a change type name or its 1-based menu number;
+// blank means defaultType
+func parseChangeType(answer string, types []string, defaultType string) string {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer == "" {
+		return defaultType
+	}
+	for i, t := range types {
+		if answer == t || answer == fmt.Sprint(i+1) {
+			return t
+		}
+	}
+	return ""
+}
+
+// promptLine prints label and reads one trimmed line; EOF ends input like a blank line
+func promptLine(r *bufio.Reader, out io.Writer, label string) (string, error) {
+	_, _ = fmt.Fprint(out, label)
+	line, err := r.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("read input: %w", err)
+	}
+	if err == io.EOF && line == "" {
+		_, _ = fmt.Fprintln(out)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// renderInputEntry serializes a filled entry as an input file in the given format
+func renderInputEntry(entry *schema.CheckpointEntry, format string) (string, error) {
+	if format == "json" {
+		data, err := json.MarshalIndent(entry, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("marshal json: %w", err)
+		}
+		return string(data) + "\n", nil
+	}
+	data, err := yaml.Marshal(entry)
+	if err != nil {
+		return "", fmt.Errorf("marshal yaml: %w", err)
+	}
+	return "# Filled with 'checkpoint check --interactive'; edit freely before committing.\n" + string(data), nil
+}
+
+// findInputFile returns the path of the in-progress input file, preferring
+// .checkpoint-input.json over the YAML .checkpoint-input when both exist
+func findInputFile(projectPath string) (string, bool) {
+	for _, name := range []string{config.InputJSONFileName, config.InputFileName} {
+		p := filepath.Join(projectPath, name)
+		if file.Exists(p) {
+			return p, true
+		}
+	}
+	return filepath.Join(projectPath, config.InputFileName), false
+}
+
+// backupInputFile copies an input file into .checkpoint/.input-backups so it
+// can be restored with 'check --amend-last-input', keeping the newest few
+func backupInputFile(projectPath, inputPath string) error {
+	data, err := os.ReadFile(inputPath)
+	if err != nil {
+		return err
+	}
+
+	backupDir := filepath.Join(projectPath, config.CheckpointDir, config.InputBackupsDir)
+	if err := os.MkdirAll(backupDir, 0755); err != nil {
+		return fmt.Errorf("create backup dir: %w", err)
+	}
+	// Backups are local scratch data; keep them out of 'git add -A'
+	ignorePath := filepath.Join(backupDir, ".gitignore")
+	if !file.Exists(ignorePath) {
+		_ = file.WriteFile(ignorePath, "*\n")
+	}
+
+	name := time.Now().UTC().Format("20060102T150405.000000000") + "_" + filepath.Base(inputPath)
+	if err := os.WriteFile(filepath.Join(backupDir, name), data, 0644); err != nil {
+		return fmt.Errorf("write backup: %w", err)
+	}
+
+	backups := listInputBackups(backupDir)
+	for len(backups) > maxInputBackups {
+		_ = os.Remove(filepath.Join(backupDir, backups[0]))
+		backups = backups[1:]
+	}
+	return nil
+}
+
+// listInputBackups returns backup file names, oldest first
+func listInputBackups(backupDir string) []string {
+	entries, err := os.ReadDir(backupDir)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.Contains(e.Name(), "_") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// restoreLatestInputBackup writes the newest backup back to its original input file name
+func restoreLatestInputBackup(projectPath string) (string, error) {
+	backupDir := filepath.Join(projectPath, config.CheckpointDir, config.InputBackupsDir)
+	backups := listInputBackups(backupDir)
+	if len(backups) == 0 {
+		return "", fmt.Errorf("no input backups found in %s", backupDir)
+	}
+
+	latest := backups[len(backups)-1]
+	data, err := os.ReadFile(filepath.Join(backupDir, latest))
+	if err != nil {
+		return "", fmt.Errorf("read backup: %w", err)
+	}
+
+	name := latest[strings.Index(latest, "_")+1:]
+	if name != config.InputFileName && name != config.InputJSONFileName {
+		name = config.InputFileName
+	}
+	inputPath := filepath.Join(projectPath, name)
+	if err := os.WriteFile(inputPath, data, 0644); err != nil {
+		return "", fmt.Errorf("restore input: %w", err)
+	}
+	return inputPath, nil
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dmoose/checkpoint/internal/schema"
+)
+
+func TestSuggestScope(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"internal/git/git.go", "git"},
+		{"cmd/check.go", "check"},
+		{"cmd/", "cmd"},
+		{"docs/guide.md", "docs"},
+		{"README.md", "README"},
+		{"pkg/config/config.go", "config"},
+	}
+	for _, tt := range tests {
+		if got := suggestScope(tt.path); got != tt.want {
+			t.Errorf("suggestScope(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestPromptEntry(t *testing.T) {
+	paths := changedPaths("?? .checkpoint-lock\n M internal/git/git.go\n?? docs/new.md\n", nil)
+	if len(paths) != 2 {
+		t.Fatalf("changedPaths = %v, want lock file skipped", paths)
+	}
+
+	input := strings.Join([]string{
+		// internal/git group: accept suggested scope
+		"Add author lookup", "", "1", "",
+		// docs group: invalid type, then retry by name, custom scope
+		"Document stats", "Covers --contributors", "bogus", "docs", "guides",
+		// additional change, then finish
+		strings.Repeat("x", schema.MaxSummaryLength+1), "Tidy imports", "", "refactor", "",
+		"",
+		"Stats lacked authors",
+	}, "\n") + "\n"
+
+	entry := &schema.CheckpointEntry{SchemaVersion: schema.SchemaVersion, Timestamp: "2025-01-01T00:00:00Z"}
+	if err := promptEntry(strings.NewReader(input), io.Discard, entry, paths, schema.DefaultRules()); err != nil {
+		t.Fatalf("promptEntry: %v", err)
+	}
+
+	want := []schema.Change{
+		{Summary: "Add author lookup", ChangeType: "feature", Scope: "git"},
+		{Summary: "Document stats", Details: "Covers --contributors", ChangeType: "docs", Scope: "guides"},
+		{Summary: "Tidy imports", ChangeType: "refactor"},
+	}
+	if len(entry.Changes) != len(want) {
+		t.Fatalf("got %d changes, want %d: %+v", len(entry.Changes), len(want), entry.Changes)
+	}
+	for i, c := range want {
+		if !reflect.DeepEqual(entry.Changes[i], c) {
+			t.Errorf("change[%d] = %+v, want %+v", i, entry.Changes[i], c)
+		}
+	}
+	if entry.Context.ProblemStatement != "Stats lacked authors" {
+		t.Errorf("problem statement = %q", entry.Context.ProblemStatement)
+	}
+	if err := schema.ValidateEntry(entry); err != nil {
+		t.Errorf("entry not valid: %v", err)
+	}
+
+	empty := &schema.CheckpointEntry{}
+	if err := promptEntry(strings.NewReader(""), io.Discard, empty, paths, schema.DefaultRules()); err == nil {
+		t.Error("expected error when no changes are entered")
+	}
+}
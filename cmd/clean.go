@@ -4,21 +4,41 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/dmoose/checkpoint/internal/file"
 	"github.com/dmoose/checkpoint/pkg/config"
 
 	"github.com/spf13/cobra"
 )
 
+var cleanOpts struct {
+	dryRun bool
+	only   string
+	keep   string
+}
+
 func init() {
 	rootCmd.AddCommand(cleanCmd)
+	cleanCmd.Flags().BoolVarP(&cleanOpts.dryRun, "dry-run", "n", false, "List files that would be removed without removing them")
+	cleanCmd.Flags().StringVar(&cleanOpts.only, "only", "", "Comma-separated targets to remove (input,input-json,diff,lock,status; globs allowed)")
+	cleanCmd.Flags().StringVar(&cleanOpts.keep, "keep", "", "Comma-separated targets to keep (globs allowed)")
 }
 
 var cleanCmd = &cobra.Command{
 	Use:   "clean [path]",
 	Short: "Remove temporary checkpoint files to abort and restart",
 	Long: `Deletes .checkpoint-input, .checkpoint-diff, and .checkpoint-lock files.
-Use when you need to start over or resolve conflicts.`,
+Use when you need to start over or resolve conflicts.
+
+Targets: input, input-json, diff, lock (default), status (only when selected with --only).
+Target names accept glob patterns, e.g. --only '*' selects every managed file.
+Removed input files are backed up; restore with 'checkpoint check --amend-last-input'.
+
+Examples:
+  checkpoint clean --dry-run        # Show what would be removed
+  checkpoint clean --only lock      # Remove a stale lock, keep the input
+  checkpoint clean --keep input     # Remove everything except the input`,
 	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		projectPath := "."
@@ -30,20 +50,68 @@ Use when you need to start over or resolve conflicts.`,
 			fmt.Fprintf(os.Stderr, "error: cannot resolve path: %v\n", err)
 			os.Exit(1)
 		}
-		Clean(absPath)
+		CleanWithOptions(absPath, CleanOptions{
+			DryRun: cleanOpts.dryRun,
+			Only:   splitList(cleanOpts.only),
+			Keep:   splitList(cleanOpts.keep),
+		})
 	},
 }
 
+// CleanOptions holds flags for the clean command
+type CleanOptions struct {
+	DryRun bool     // List without removing
+	Only   []string // Target names/globs to remove (empty = defaults)
+	Keep   []string // Target names/globs to preserve
+}
+
+// cleanTarget is a temporary file managed by checkpoint
+type cleanTarget struct {
+	Name     string // short name used by --only/--keep
+	FileName string
+	Default  bool // removed when no --only is given
+}
+
+// cleanTargets enumerates the temporary files checkpoint manages
+var cleanTargets = []cleanTarget{
+	{Name: "input", FileName: config.InputFileName, Default: true},
+	{Name: "input-json", FileName: config.InputJSONFileName, Default: true},
+	{Name: "diff", FileName: config.DiffFileName, Default: true},
+	{Name: "lock", FileName: config.LockFileName, Default: true},
+	{Name: "status", FileName: config.StatusFileName, Default: false},
+}
+
 // Clean removes artifacts created by the 'check' command so the user can abort and re-run
 func Clean(projectPath string) {
-	inputPath := filepath.Join(projectPath, config.InputFileName)
-	diffPath := filepath.Join(projectPath, config.DiffFileName)
-	lockPath := filepath.Join(projectPath, config.LockFileName)
+	CleanWithOptions(projectPath, CleanOptions{})
+}
 
-	filesToRemove := []string{inputPath, diffPath, lockPath}
-	removedAny := false
+// CleanWithOptions removes the selected checkpoint artifacts
+func CleanWithOptions(projectPath string, opts CleanOptions) {
+	targets, err := selectCleanTargets(opts.Only, opts.Keep)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "hint: valid targets are %s\n", strings.Join(cleanTargetNames(), ", "))
+		os.Exit(1)
+	}
 
-	for _, filePath := range filesToRemove {
+	removedAny := false
+	for _, t := range targets {
+		filePath := filepath.Join(projectPath, t.FileName)
+		if opts.DryRun {
+			if _, err := os.Stat(filePath); err == nil {
+				fmt.Printf("Would remove %s\n", filePath)
+				removedAny = true
+			}
+			continue
+		}
+		if t.FileName == config.InputFileName || t.FileName == config.InputJSONFileName {
+			if file.Exists(filePath) {
+				if err := backupInputFile(projectPath, filePath); err != nil {
+					fmt.Fprintf(os.Stderr, "warning: failed to back up %s: %v\n", filePath, err)
+				}
+			}
+		}
 		if err := os.Remove(filePath); err == nil {
 			fmt.Printf("Removed %s\n", filePath)
 			removedAny = true
@@ -52,9 +120,78 @@ func Clean(projectPath string) {
 		}
 	}
 
-	if !removedAny {
+	switch {
+	case !removedAny:
 		fmt.Println("Nothing to clean (no checkpoint artifacts found)")
-	} else {
+	case opts.DryRun:
+		fmt.Println("[dry-run] No files were removed")
+	default:
 		fmt.Println("✓ Checkpoint artifacts cleaned")
 	}
 }
+
+// selectCleanTargets resolves --only/--keep patterns against the managed target set
+func selectCleanTargets(only, keep []string) ([]cleanTarget, error) {
+	for _, pattern := range append(append([]string{}, only...), keep...) {
+		if !matchesAnyCleanTarget(pattern) {
+			return nil, fmt.Errorf("unknown clean target '%s'", pattern)
+		}
+	}
+
+	var selected []cleanTarget
+	for _, t := range cleanTargets {
+		if len(only) > 0 {
+			if !matchCleanTarget(t, only) {
+				continue
+			}
+		} else if !t.Default {
+			continue
+		}
+		if matchCleanTarget(t, keep) {
+			continue
+		}
+		selected = append(selected, t)
+	}
+	return selected, nil
+}
+
+// matchCleanTarget reports whether a target matches any pattern by short name or file name
+func matchCleanTarget(t cleanTarget, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := filepath.Match(p, t.Name); ok {
+			return true
+		}
+		if ok, _ := filepath.Match(p, t.FileName); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAnyCleanTarget(pattern string) bool {
+	for _, t := range cleanTargets {
+		if matchCleanTarget(t, []string{pattern}) {
+			return true
+		}
+	}
+	return false
+}
+
+func cleanTargetNames() []string {
+	names := make([]string, 0, len(cleanTargets))
+	for _, t := range cleanTargets {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// splitList splits a comma-separated flag value, dropping empty items
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
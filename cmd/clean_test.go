@@ -214,3 +214,114 @@ func TestCleanReadOnlyFiles(t *testing.T) {
 	// Clean up by making file writable again
 	_ = os.Chmod(inputPath, 0644)
 }
+
+func TestCleanWithOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    CleanOptions
+		removed []string
+		kept    []string
+	}{
+		{
+			name:    "dry run removes nothing",
+			opts:    CleanOptions{DryRun: true},
+			removed: nil,
+			kept:    []string{config.InputFileName, config.DiffFileName, config.LockFileName, config.StatusFileName},
+		},
+		{
+			name:    "only lock",
+			opts:    CleanOptions{Only: []string{"lock"}},
+			removed: []string{config.LockFileName},
+			kept:    []string{config.InputFileName, config.DiffFileName, config.StatusFileName},
+		},
+		{
+			name:    "keep input",
+			opts:    CleanOptions{Keep: []string{"input"}},
+			removed: []string{config.DiffFileName, config.LockFileName},
+			kept:    []string{config.InputFileName, config.StatusFileName},
+		},
+		{
+			name:    "glob selects status too",
+			opts:    CleanOptions{Only: []string{"*"}, Keep: []string{"status"}},
+			removed: []string{config.InputFileName, config.DiffFileName, config.LockFileName},
+			kept:    []string{config.StatusFileName},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir, err := os.MkdirTemp("", "checkpoint-clean-options")
+			if err != nil {
+				t.Fatalf("failed to create temp dir: %v", err)
+			}
+			defer func() { _ = os.RemoveAll(tmpDir) }()
+
+			for _, name := range []string{config.InputFileName, config.DiffFileName, config.LockFileName, config.StatusFileName} {
+				if err := file.WriteFile(filepath.Join(tmpDir, name), "test"); err != nil {
+					t.Fatalf("failed to create %s: %v", name, err)
+				}
+			}
+
+			CleanWithOptions(tmpDir, tt.opts)
+
+			for _, name := range tt.removed {
+				if file.Exists(filepath.Join(tmpDir, name)) {
+					t.Errorf("%s should be removed", name)
+				}
+			}
+			for _, name := range tt.kept {
+				if !file.Exists(filepath.Join(tmpDir, name)) {
+					t.Errorf("%s should be kept", name)
+				}
+			}
+		})
+	}
+}
+
+func TestSelectCleanTargetsUnknown(t *testing.T) {
+	if _, err := selectCleanTargets([]string{"bogus"}, nil); err == nil {
+		t.Errorf("expected error for unknown target")
+	}
+}
+
+func TestCleanBacksUpInputForAmend(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "checkpoint-clean-backup-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	inputPath := filepath.Join(tmpDir, config.InputFileName)
+	content := "schema_version: \"1\"\nchanges:\n  - summary: \"hand edited\"\n"
+	if err := os.WriteFile(inputPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write input: %v", err)
+	}
+
+	Clean(tmpDir)
+	if _, err := os.Stat(inputPath); !os.IsNotExist(err) {
+		t.Fatalf("expected input to be removed")
+	}
+
+	restored, err := restoreLatestInputBackup(tmpDir)
+	if err != nil {
+		t.Fatalf("restoreLatestInputBackup failed: %v", err)
+	}
+	if restored != inputPath {
+		t.Errorf("restored to %s, want %s", restored, inputPath)
+	}
+	data, err := os.ReadFile(inputPath)
+	if err != nil || string(data) != content {
+		t.Errorf("restored content mismatch: %q (err=%v)", string(data), err)
+	}
+
+	// Backups are capped
+	for i := 0; i < maxInputBackups+3; i++ {
+		if err := backupInputFile(tmpDir, inputPath); err != nil {
+			t.Fatalf("backupInputFile failed: %v", err)
+		}
+	}
+	backupDir := filepath.Join(tmpDir, config.CheckpointDir, config.InputBackupsDir)
+	if got := len(listInputBackups(backupDir)); got != maxInputBackups {
+		t.Errorf("expected %d backups retained, got %d", maxInputBackups, got)
+	}
+}
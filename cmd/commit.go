@@ -1,9 +1,14 @@
 package cmd
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,15 +18,31 @@ import (
 	"github.com/dmoose/checkpoint/internal/git"
 	"github.com/dmoose/checkpoint/internal/project"
 	"github.com/dmoose/checkpoint/internal/schema"
+	"github.com/dmoose/checkpoint/internal/userconfig"
 	"github.com/dmoose/checkpoint/pkg/config"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 var commitOpts struct {
 	dryRun        bool
 	changelogOnly bool
 	keepSession   bool
+	tag           string
+	tagMessage    string
+	message       string
+	editMessage   bool
+	contextFrom   []string
+	parents       int
+	allowRewrite  bool
+	coAuthors     []string
+	noContext     bool
+	amend         bool
+	retryOnLock   bool
+	lockTimeout   time.Duration
+	verbose       bool
+	conventional  bool
 }
 
 func init() {
@@ -29,13 +50,76 @@ func init() {
 	commitCmd.Flags().BoolVarP(&commitOpts.dryRun, "dry-run", "n", false, "Show commit message and staged files without committing")
 	commitCmd.Flags().BoolVar(&commitOpts.changelogOnly, "changelog-only", false, "Stage only changelog instead of all changes")
 	commitCmd.Flags().BoolVar(&commitOpts.keepSession, "keep-session", false, "Preserve session file after commit (default: cleared)")
+	commitCmd.Flags().StringVar(&commitOpts.tag, "tag", "", "Create a git tag on the new commit (e.g. v1.2.0)")
+	commitCmd.Flags().StringVar(&commitOpts.tagMessage, "tag-message", "", "Create an annotated tag with this message (requires --tag)")
+	commitCmd.Flags().StringVarP(&commitOpts.message, "message", "m", "", "Use this git commit message instead of the generated one")
+	commitCmd.Flags().StringArrayVar(&commitOpts.contextFrom, "context-from", nil, "Attach a file (design doc, transcript) as external context (repeatable)")
+	commitCmd.Flags().BoolVar(&commitOpts.editMessage, "edit-message", false, "Open the commit message in $EDITOR before committing")
+	commitCmd.Flags().IntVar(&commitOpts.parents, "parents", 1, "Verify the last N checkpoint commits are still reachable from HEAD (0 to skip)")
+	commitCmd.Flags().BoolVar(&commitOpts.allowRewrite, "allow-rewritten", false, "Commit even if earlier checkpoint commits were rewritten (e.g. by a rebase)")
+	commitCmd.Flags().StringArrayVar(&commitOpts.coAuthors, "co-author", nil, "Add a Co-authored-by trailer, as \"Name <email>\" (repeatable)")
+	commitCmd.Flags().BoolVar(&commitOpts.noContext, "no-context", false, "Skip context capture for this checkpoint (bypasses commit.require_context)")
+	commitCmd.Flags().BoolVar(&commitOpts.retryOnLock, "retry-on-lock", false, "Wait for a concurrent checkpoint commit to finish instead of failing")
+	commitCmd.Flags().DurationVar(&commitOpts.lockTimeout, "lock-timeout", 30*time.Second, "Give up waiting for the commit lock after this long (with --retry-on-lock)")
+	commitCmd.Flags().BoolVarP(&commitOpts.verbose, "verbose", "v", false, "Log each commit lock retry")
+	commitCmd.Flags().BoolVar(&commitOpts.conventional, "conventional", false, "Write a Conventional Commits message (feat(scope): summary) instead of the Checkpoint: subject (default: commit.conventional, then the user config)")
+	commitCmd.Flags().BoolVar(&commitOpts.amend, "amend", false, "Replace the last checkpoint and fold into its commit (git commit --amend)")
 }
 
 var commitCmd = &cobra.Command{
 	Use:   "commit [path]",
 	Short: "Parse input, append to changelog, stage changes, and git commit",
 	Long: `Validates input, creates YAML document, stages files, commits.
-Then backfills commit hash into the last changelog document.`,
+Then backfills commit hash into the last changelog document.
+
+Use --tag to tag the new commit (and record the tag in the changelog entry);
+add --tag-message for an annotated tag. The tag is checked before committing.
+
+The git commit message is generated from the changes. Use --message to
+supply it directly, or --edit-message to tweak it in $EDITOR (lines starting
+with '#' are dropped). The changelog entry is recorded either way.
+
+Use --conventional for a Conventional Commits message that release tooling
+can parse: the first change becomes the subject, as type(scope): summary
+(feature maps to feat, other to chore; '!' marks a breaking change), further
+changes are listed in the body, and each breaking change gets a BREAKING
+CHANGE footer. When the flag is not given, commit.conventional in
+.checkpoint/project.yaml decides, then conventional_commits in the user config
+('checkpoint config set --global conventional_commits true').
+--conventional=false forces the Checkpoint: subject.
+
+Use --context-from <file> (repeatable) to attach design docs or chat
+transcripts as external_context in .checkpoint-context.yaml, where
+'checkpoint search' can find them.
+
+Set commit.require_context: true in .checkpoint/project.yaml to reject
+entries without a context.problem_statement and at least one decision or
+key insight. For a trivial change, --no-context skips context capture for
+this one checkpoint: the changelog entry is written, but no context
+document or project recommendations, and the requirement is not checked.
+
+Before committing, the commit hashes of the last --parents checkpoints
+(default 1) must still be reachable from HEAD; after a rebase they may not
+be. commit.history_check in .checkpoint/project.yaml sets what happens
+then: confirm (default; prompt on a terminal, otherwise warn and continue),
+strict (fail), warn (continue), or off. --allow-rewritten proceeds anyway.
+
+Use --co-author "Name <email>" (repeatable) to credit a pair or assisting
+agent with a Co-authored-by trailer, which GitHub shows on the commit.
+Duplicate emails are dropped. Trailers are also added to a --message.
+
+Use --amend to fix up the last checkpoint: the input is re-parsed and
+replaces the last changelog entry (keeping its original timestamp) and its
+context document, and the result is folded into the previous commit with
+'git commit --amend'. HEAD must be that checkpoint's commit. Project
+recommendations are not regenerated, and --tag cannot be combined with it.
+
+While writing the changelog and committing, commit holds a lock in the git
+directory so concurrent checkpoint commits (CI jobs, parallel agents) can't
+interleave. A second commit fails at once when the lock is held; with
+--retry-on-lock it waits, backing off exponentially (100ms doubling to 2s),
+for up to --lock-timeout (default 30s). --verbose logs each retry. A lock
+older than 10 minutes is assumed abandoned and taken over.`,
 	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		projectPath := "."
@@ -47,10 +131,28 @@ Then backfills commit hash into the last changelog document.`,
 			fmt.Fprintf(os.Stderr, "error: cannot resolve path: %v\n", err)
 			os.Exit(1)
 		}
+		var conventional *bool
+		if cmd.Flags().Changed("conventional") {
+			conventional = &commitOpts.conventional
+		}
 		CommitWithOptions(absPath, CommitOptions{
 			DryRun:        commitOpts.dryRun,
 			ChangelogOnly: commitOpts.changelogOnly,
 			KeepSession:   commitOpts.keepSession,
+			Tag:           commitOpts.tag,
+			TagMessage:    commitOpts.tagMessage,
+			Message:       commitOpts.message,
+			EditMessage:   commitOpts.editMessage,
+			ContextFrom:   commitOpts.contextFrom,
+			Parents:       commitOpts.parents,
+			AllowRewrite:  commitOpts.allowRewrite,
+			CoAuthors:     commitOpts.coAuthors,
+			NoContext:     commitOpts.noContext,
+			Amend:         commitOpts.amend,
+			RetryOnLock:   commitOpts.retryOnLock,
+			LockTimeout:   commitOpts.lockTimeout,
+			Verbose:       commitOpts.verbose,
+			Conventional:  conventional,
 		}, Version)
 	},
 }
@@ -59,6 +161,20 @@ type CommitOptions struct {
 	DryRun        bool
 	ChangelogOnly bool
 	KeepSession   bool
+	Tag           string        // git tag to create on the new commit
+	TagMessage    string        // annotated tag message (lightweight tag when empty)
+	Message       string        // git commit message overriding the generated one
+	EditMessage   bool          // open the commit message in $EDITOR before committing
+	ContextFrom   []string      // files attached as external context
+	Parents       int           // previous checkpoint commits that must be reachable from HEAD
+	AllowRewrite  bool          // proceed even when previous checkpoint commits were rewritten
+	CoAuthors     []string      // "Name <email>" credited with Co-authored-by trailers
+	NoContext     bool          // write no context document for this checkpoint
+	Amend         bool          // replace the last checkpoint and amend its commit
+	RetryOnLock   bool          // wait for a held commit lock instead of failing
+	LockTimeout   time.Duration // how long --retry-on-lock waits
+	Verbose       bool          // log lock retries
+	Conventional  *bool         // --conventional; nil defers to project.yaml, then the user config
 }
 
 // Commit implements Phase 3: parse input, append to changelog, git commit, write status
@@ -80,8 +196,8 @@ func CommitWithOptions(projectPath string, opts CommitOptions, version string) {
 	}
 
 	// Check if input file exists
-	inputPath := filepath.Join(projectPath, config.InputFileName)
-	if !file.Exists(inputPath) {
+	inputPath, found := findInputFile(projectPath)
+	if !found {
 		fmt.Fprintf(os.Stderr, "error: input file not found at %s\n", inputPath)
 		fmt.Fprintf(os.Stderr, "hint: run 'checkpoint check %s' to generate the input file\n", projectPath)
 		fmt.Fprintf(os.Stderr, "or run 'checkpoint clean %s' to restart if needed\n", projectPath)
@@ -99,18 +215,139 @@ func CommitWithOptions(projectPath string, opts CommitOptions, version string) {
 	entry, err := schema.ParseInputFile(inputContent)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: failed to parse input file: %v\n", err)
-		fmt.Fprintf(os.Stderr, "hint: check YAML syntax in %s\n", inputPath)
+		fmt.Fprintf(os.Stderr, "hint: check YAML/JSON syntax in %s\n", inputPath)
 		fmt.Fprintf(os.Stderr, "or run 'checkpoint clean %s' to restart\n", projectPath)
 		os.Exit(1)
 	}
 
+	if opts.NoContext && len(opts.ContextFrom) > 0 {
+		fmt.Fprintf(os.Stderr, "error: --no-context and --context-from cannot be combined\n")
+		os.Exit(1)
+	}
+	if opts.NoContext {
+		// Drop whatever the input holds, template placeholders included
+		entry.Context = context.CheckpointContext{}
+	}
+
 	// Validate entry (comprehensive validation)
-	if err := schema.ValidateEntry(entry); err != nil {
+	rules, err := schema.LoadRules(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "hint: fix .checkpoint/%s or remove it to use the defaults\n", config.SchemaYaml)
+		os.Exit(1)
+	}
+	if err := schema.ValidateEntryWithRules(entry, rules); err != nil {
 		fmt.Fprintf(os.Stderr, "error: validation failed: %v\n", err)
 		fmt.Fprintf(os.Stderr, "hint: edit %s to fix the issues above\n", inputPath)
 		os.Exit(1)
 	}
 
+	settings := loadProjectSettings(projectPath)
+	if !opts.NoContext && settings.Commit.RequireContext {
+		if err := schema.ValidateContext(entry); err != nil {
+			fmt.Fprintf(os.Stderr, "error: validation failed: %v\n", err)
+			fmt.Fprintf(os.Stderr, "hint: fill in the context section of %s (required by commit.require_context in project.yaml)\n", inputPath)
+			os.Exit(1)
+		}
+	}
+
+	// Read external context files before anything is written
+	for _, path := range opts.ContextFrom {
+		ext, err := readExternalContext(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			fmt.Fprintf(os.Stderr, "hint: check the --context-from path\n")
+			os.Exit(1)
+		}
+		entry.Context.ExternalContext = append(entry.Context.ExternalContext, ext)
+	}
+
+	coAuthors, err := parseCoAuthors(opts.CoAuthors)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "hint: use --co-author \"Name <email@example.com>\"\n")
+		os.Exit(1)
+	}
+
+	// Amending replaces the last checkpoint, which must be the HEAD commit
+	var amended *schema.CheckpointEntry
+	if opts.Amend {
+		if opts.Tag != "" {
+			fmt.Fprintf(os.Stderr, "error: --amend and --tag cannot be combined\n")
+			fmt.Fprintf(os.Stderr, "hint: tag the amended commit afterwards with 'git tag'\n")
+			os.Exit(1)
+		}
+		amended, err = amendTarget(projectPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			fmt.Fprintf(os.Stderr, "hint: run without --amend to record a new checkpoint\n")
+			os.Exit(1)
+		}
+		entry.Timestamp = amended.Timestamp
+		if entry.Tag == "" {
+			entry.Tag = amended.Tag
+		}
+	}
+
+	// Validate the tag up front so a failure can't leave the commit untagged
+	if opts.TagMessage != "" && opts.Tag == "" {
+		fmt.Fprintf(os.Stderr, "error: --tag-message requires --tag\n")
+		os.Exit(1)
+	}
+	if opts.Tag != "" {
+		if err := git.ValidateTagName(projectPath, opts.Tag); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		exists, err := git.TagExists(projectPath, opts.Tag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: failed to check tag: %v\n", err)
+			os.Exit(1)
+		}
+		if exists {
+			fmt.Fprintf(os.Stderr, "error: tag '%s' already exists\n", opts.Tag)
+			fmt.Fprintf(os.Stderr, "hint: choose a new tag name or delete the existing one with 'git tag -d %s'\n", opts.Tag)
+			os.Exit(1)
+		}
+		entry.Tag = opts.Tag
+	}
+
+	// Refuse to chain onto checkpoints orphaned by rewritten history
+	mode, err := historyCheckMode(settings.Commit.HistoryCheck)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "hint: set commit.history_check in .checkpoint/project.yaml to confirm, strict, warn, or off\n")
+		os.Exit(1)
+	}
+	if mode != "off" && opts.Parents > 0 {
+		missing, err := unreachableCheckpoints(projectPath, opts.Parents)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: could not verify checkpoint history: %v\n", err)
+		} else if len(missing) > 0 {
+			fmt.Fprintf(os.Stderr, "warning: %d earlier checkpoint commit(s) are not reachable from HEAD (history rewritten?): %s\n",
+				len(missing), strings.Join(missing, ", "))
+			if !opts.AllowRewrite && !opts.DryRun {
+				switch historyCheckAction(mode, stdinIsTerminal()) {
+				case "fail":
+					fmt.Fprintf(os.Stderr, "error: refusing to chain a checkpoint onto rewritten history\n")
+					fmt.Fprintf(os.Stderr, "hint: rerun with --allow-rewritten if the rebase was intentional\n")
+					os.Exit(1)
+				case "prompt":
+					answer, err := promptLine(bufio.NewReader(os.Stdin), os.Stdout, "Commit anyway? [y/N]: ")
+					if err != nil || !strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes") {
+						fmt.Fprintf(os.Stderr, "error: commit aborted\n")
+						os.Exit(1)
+					}
+				default:
+					if mode == "confirm" {
+						fmt.Fprintf(os.Stderr, "warning: stdin is not a terminal, so history_check: confirm continues without asking\n")
+						fmt.Fprintf(os.Stderr, "hint: set commit.history_check: strict in .checkpoint/project.yaml to fail instead\n")
+					}
+				}
+			}
+		}
+	}
+
 	// Fill timestamp if missing
 	if entry.Timestamp == "" {
 		entry.Timestamp = time.Now().Format(time.RFC3339)
@@ -123,7 +360,35 @@ func CommitWithOptions(projectPath string, opts CommitOptions, version string) {
 		os.Exit(1)
 	}
 	// Generate commit message
-	commitMsg := generateCommitMessage(entry)
+	userConventional := false
+	if user, err := userconfig.Load(); err == nil {
+		userConventional = user.ConventionalCommits
+	}
+	commitMsg := generateCommitMessage(entry, coAuthors)
+	if resolveConventional(opts.Conventional, settings.Commit.Conventional, userConventional) {
+		commitMsg = generateConventionalMessage(entry, coAuthors)
+	}
+	if opts.Message != "" {
+		commitMsg = strings.TrimSpace(opts.Message)
+		if commitMsg == "" {
+			fmt.Fprintf(os.Stderr, "error: --message is empty\n")
+			os.Exit(1)
+		}
+		commitMsg += coAuthorTrailers(coAuthors)
+	}
+	if opts.EditMessage && !opts.DryRun {
+		edited, err := editCommitMessage(commitMsg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			fmt.Fprintf(os.Stderr, "hint: set $EDITOR, or pass the message with --message\n")
+			os.Exit(1)
+		}
+		if edited == "" {
+			fmt.Fprintf(os.Stderr, "error: aborting commit due to empty commit message\n")
+			os.Exit(1)
+		}
+		commitMsg = edited
+	}
 
 	// Handle dry-run before making any changes
 	if opts.DryRun {
@@ -134,34 +399,81 @@ func CommitWithOptions(projectPath string, opts CommitOptions, version string) {
 		} else {
 			fmt.Printf("  - All modified and untracked files (git add -A)\n")
 		}
+		if opts.Tag != "" {
+			fmt.Printf("\n[dry-run] Would tag commit: %s\n", opts.Tag)
+		}
+		if amended != nil {
+			fmt.Printf("\n[dry-run] Would amend the checkpoint from %s (commit %s)\n", amended.Timestamp, amended.CommitHash)
+		}
+		if len(entry.Context.ExternalContext) > 0 {
+			fmt.Printf("\n[dry-run] Would attach external context:\n")
+			for _, ext := range entry.Context.ExternalContext {
+				fmt.Printf("  - %s (%d bytes)\n", ext.Source, len(ext.Content))
+			}
+		}
+		if opts.NoContext {
+			fmt.Printf("\n[dry-run] Would skip context capture (--no-context)\n")
+		}
+		if opts.EditMessage {
+			fmt.Printf("\n[dry-run] Would open the message in %s for editing\n", commitEditor())
+		}
 		return
 	}
 
+	// Serialize concurrent checkpoint commits until the hash is backfilled
+	commitLock, err := acquireCommitLock(projectPath, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		if opts.RetryOnLock {
+			fmt.Fprintf(os.Stderr, "hint: raise --lock-timeout, or remove the lock if no checkpoint commit is running\n")
+		} else {
+			fmt.Fprintf(os.Stderr, "hint: rerun with --retry-on-lock to wait for the other commit\n")
+		}
+		os.Exit(1)
+	}
+	exitLocked := func() {
+		releaseCommitLock(commitLock)
+		os.Exit(1)
+	}
+
 	// Initialize changelog with meta document if it doesn't exist
 	changelogPath := filepath.Join(projectPath, config.ChangelogFileName)
 	if err := changelog.InitializeChangelog(changelogPath, version); err != nil {
 		fmt.Fprintf(os.Stderr, "error: failed to initialize changelog: %v\n", err)
-		os.Exit(1)
+		exitLocked()
 	}
 
-	// Append to changelog (append-only)
-	if err := changelog.AppendEntry(changelogPath, doc); err != nil {
+	// Append to changelog (append-only), or replace the last entry when amending
+	if amended != nil {
+		if _, err := changelog.ReplaceLastEntry(changelogPath, doc); err != nil {
+			fmt.Fprintf(os.Stderr, "error: failed to replace last changelog entry: %v\n", err)
+			fmt.Fprintf(os.Stderr, "hint: check write permissions for %s\n", changelogPath)
+			exitLocked()
+		}
+	} else if err := changelog.AppendEntry(changelogPath, doc); err != nil {
 		fmt.Fprintf(os.Stderr, "error: failed to append to changelog: %v\n", err)
 		fmt.Fprintf(os.Stderr, "hint: check write permissions for %s\n", changelogPath)
-		os.Exit(1)
+		exitLocked()
 	}
 
-	// Append context entry
+	// Append context entry, dropping the amended checkpoint's one first
 	contextPath := filepath.Join(projectPath, config.ContextFileName)
-	contextEntry := context.CreateContextEntry(entry.Timestamp, entry.Context)
-	if err := context.AppendContextEntry(contextPath, contextEntry); err != nil {
-		fmt.Fprintf(os.Stderr, "warning: failed to append context entry: %v\n", err)
+	if amended != nil {
+		if _, err := context.RemoveContextEntry(contextPath, amended.Timestamp); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: failed to remove amended context entry: %v\n", err)
+		}
+	}
+	if !opts.NoContext {
+		contextEntry := context.CreateContextEntry(entry.Timestamp, entry.Context)
+		if err := context.AppendContextEntryWithRetention(contextPath, contextEntry, settings.ContextRetention); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: failed to append context entry: %v\n", err)
+		}
 	}
 
 	// Generate project recommendations from context
 	projectFilePath := filepath.Join(projectPath, config.ProjectFileName)
 	recommendations := generateProjectRecommendations(entry.Context)
-	if recommendations != nil {
+	if recommendations != nil && amended == nil {
 		if err := project.AppendRecommendations(projectFilePath, entry.Timestamp,
 			recommendations.Additions, recommendations.Updates, recommendations.Deletions); err != nil {
 			fmt.Fprintf(os.Stderr, "warning: failed to append project recommendations: %v\n", err)
@@ -173,24 +485,28 @@ func CommitWithOptions(projectPath string, opts CommitOptions, version string) {
 		if err := git.StageFile(projectPath, config.ChangelogFileName); err != nil {
 			fmt.Fprintf(os.Stderr, "error: failed to stage changelog: %v\n", err)
 			fmt.Fprintf(os.Stderr, "hint: ensure git is working and the repository is not corrupted\n")
-			os.Exit(1)
+			exitLocked()
 		}
 	} else {
 		if err := git.StageAll(projectPath); err != nil {
 			fmt.Fprintf(os.Stderr, "error: failed to stage changes: %v\n", err)
 			fmt.Fprintf(os.Stderr, "hint: check for git issues or run 'git status' to see what's wrong\n")
-			os.Exit(1)
+			exitLocked()
 		}
 	}
 
 	// Commit
 	var commitHash string
-	commitHash, err = git.Commit(projectPath, commitMsg)
+	if amended != nil {
+		commitHash, err = git.CommitAmend(projectPath, commitMsg)
+	} else {
+		commitHash, err = git.Commit(projectPath, commitMsg)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: failed to commit: %v\n", err)
 		fmt.Fprintf(os.Stderr, "warning: changelog has been appended but not committed\n")
 		fmt.Fprintf(os.Stderr, "hint: fix git issues and run 'checkpoint commit %s' again\n", projectPath)
-		os.Exit(1)
+		exitLocked()
 	}
 
 	// Update/backfill commit hash in changelog
@@ -202,6 +518,15 @@ func CommitWithOptions(projectPath string, opts CommitOptions, version string) {
 		fmt.Fprintf(os.Stderr, "warning: failed to backfill commit_hash in changelog: %v\n", err)
 		fmt.Fprintf(os.Stderr, "hint: the commit succeeded, but you may need to manually add the commit hash\n")
 	}
+	releaseCommitLock(commitLock)
+
+	// Tag the new commit
+	if opts.Tag != "" {
+		if err := git.CreateTag(projectPath, opts.Tag, commitHash, opts.TagMessage); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: commit succeeded but tagging failed: %v\n", err)
+			fmt.Fprintf(os.Stderr, "hint: tag manually with 'git tag %s %s'\n", opts.Tag, commitHash)
+		}
+	}
 
 	// Read meta document for project metadata
 	var projectID, pathHash string
@@ -221,6 +546,9 @@ func CommitWithOptions(projectPath string, opts CommitOptions, version string) {
 	}
 
 	// Clean up input, diff, and lock files
+	if err := backupInputFile(projectPath, inputPath); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to back up input file: %v\n", err)
+	}
 	if err := os.Remove(inputPath); err != nil {
 		fmt.Fprintf(os.Stderr, "warning: failed to remove input file: %v\n", err)
 	}
@@ -237,10 +565,17 @@ func CommitWithOptions(projectPath string, opts CommitOptions, version string) {
 		}
 	}
 
-	// Clear session file unless --keep-session is set
+	// Clear session file unless --keep-session is set; a session linked with
+	// 'session save --link-checkpoint' records the hash and is kept
 	sessionPath := filepath.Join(projectPath, sessionFileName)
 	if file.Exists(sessionPath) {
-		if opts.KeepSession {
+		linked, err := linkSessionCheckpoint(sessionPath, commitHash)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: failed to link checkpoint to session: %v\n", err)
+		}
+		if linked {
+			fmt.Printf("Session linked to checkpoint %s\n", commitHash)
+		} else if opts.KeepSession {
 			fmt.Println("Session preserved (--keep-session)")
 		} else {
 			if err := os.Remove(sessionPath); err != nil {
@@ -251,8 +586,19 @@ func CommitWithOptions(projectPath string, opts CommitOptions, version string) {
 		}
 	}
 
-	fmt.Printf("✓ Checkpoint committed successfully\n")
+	if amended != nil {
+		if amended.Tag != "" {
+			fmt.Fprintf(os.Stderr, "warning: tag '%s' still points at the commit before the amend\n", amended.Tag)
+			fmt.Fprintf(os.Stderr, "hint: move it with 'git tag -f %s %s'\n", amended.Tag, commitHash)
+		}
+		fmt.Printf("✓ Checkpoint amended successfully\n")
+	} else {
+		fmt.Printf("✓ Checkpoint committed successfully\n")
+	}
 	fmt.Printf("Commit: %s\n", commitHash)
+	if opts.Tag != "" {
+		fmt.Printf("Tag: %s\n", opts.Tag)
+	}
 	fmt.Printf("Changes: %d\n", len(entry.Changes))
 	for i, c := range entry.Changes {
 		scope := c.Scope
@@ -263,22 +609,147 @@ func CommitWithOptions(projectPath string, opts CommitOptions, version string) {
 	}
 }
 
-// generateCommitMessage creates a commit message summarizing the checkpoint
-func generateCommitMessage(entry *schema.CheckpointEntry) string {
+// Commit lock timing: retries back off exponentially from commitLockMinWait
+// to commitLockMaxWait; a lock older than commitLockStaleAfter was left by
+// a commit that died and is taken over
+const (
+	commitLockMinWait    = 100 * time.Millisecond
+	commitLockMaxWait    = 2 * time.Second
+	commitLockStaleAfter = 10 * time.Minute
+)
+
+// acquireCommitLock takes the commit lock in the git directory and returns
+// its path. When the lock is held it fails at once, or with opts.RetryOnLock
+// retries with exponential backoff until opts.LockTimeout has passed.
+func acquireCommitLock(projectPath string, opts CommitOptions) (string, error) {
+	lockPath, err := git.GitPath(projectPath, config.CommitLockFileName)
+	if err != nil {
+		return "", fmt.Errorf("failed to locate commit lock: %w", err)
+	}
+	deadline := time.Now().Add(opts.LockTimeout)
+	for attempt := 0; ; attempt++ {
+		content := fmt.Sprintf("pid=%d\ntimestamp=%s\n", os.Getpid(), time.Now().Format(time.RFC3339))
+		err := file.CreateLock(lockPath, content)
+		if err == nil {
+			return lockPath, nil
+		}
+		if !errors.Is(err, file.ErrLocked) {
+			return "", fmt.Errorf("failed to create commit lock: %w", err)
+		}
+		if commitLockStale(lockPath, time.Now()) {
+			fmt.Fprintf(os.Stderr, "warning: taking over stale commit lock %s\n", lockPath)
+			if err := os.Remove(lockPath); err != nil && !os.IsNotExist(err) {
+				return "", fmt.Errorf("failed to remove stale commit lock: %w", err)
+			}
+			continue
+		}
+		if !opts.RetryOnLock {
+			return "", fmt.Errorf("another checkpoint commit is in progress (lock %s)", lockPath)
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return "", fmt.Errorf("timed out after %s waiting for the commit lock %s", opts.LockTimeout, lockPath)
+		}
+		wait := commitLockBackoff(attempt)
+		if wait > remaining {
+			wait = remaining
+		}
+		if opts.Verbose {
+			fmt.Fprintf(os.Stderr, "commit lock held; retry %d in %s\n", attempt+1, wait)
+		}
+		time.Sleep(wait)
+	}
+}
+
+// commitLockBackoff is the wait before retry attempt+1
+func commitLockBackoff(attempt int) time.Duration {
+	wait := commitLockMinWait
+	for i := 0; i < attempt && wait < commitLockMaxWait; i++ {
+		wait *= 2
+	}
+	if wait > commitLockMaxWait {
+		wait = commitLockMaxWait
+	}
+	return wait
+}
+
+// commitLockStale reports whether the lock's recorded timestamp is older
+// than commitLockStaleAfter; an unreadable lock is treated as live
+func commitLockStale(lockPath string, now time.Time) bool {
+	content, err := file.ReadFile(lockPath)
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(content, "\n") {
+		if ts, ok := strings.CutPrefix(line, "timestamp="); ok {
+			t, err := time.Parse(time.RFC3339, strings.TrimSpace(ts))
+			return err == nil && now.Sub(t) > commitLockStaleAfter
+		}
+	}
+	return false
+}
+
+func releaseCommitLock(lockPath string) {
+	if err := os.Remove(lockPath); err != nil && !os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "warning: failed to release commit lock: %v\n", err)
+	}
+}
+
+// amendTarget returns the last checkpoint for --amend, refusing when there
+// is none or when HEAD is not the commit that recorded it
+func amendTarget(projectPath string) (*schema.CheckpointEntry, error) {
+	last, err := changelog.LastEntry(filepath.Join(projectPath, config.ChangelogFileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read changelog: %w", err)
+	}
+	if last == nil {
+		return nil, fmt.Errorf("no checkpoint to amend")
+	}
+	headHash, headSubject, err := git.HeadCommit(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HEAD: %w", err)
+	}
+	if !isCheckpointHead(last, headHash, headSubject) {
+		return nil, fmt.Errorf("HEAD (%s) is not a checkpoint commit; refusing to amend", headHash[:min(8, len(headHash))])
+	}
+	return last, nil
+}
+
+// generateCommitMessage creates a commit message summarizing the checkpoint.
+// Breaking changes mark their type with '!'. Co-authors are credited with
+// Co-authored-by trailers in a final paragraph.
+func generateCommitMessage(entry *schema.CheckpointEntry, coAuthors []string) string {
+	return generateCommitSubject(entry) + coAuthorTrailers(coAuthors)
+}
+
+// resolveConventional decides whether to write a Conventional Commits
+// message: the --conventional flag wins, then commit.conventional in
+// project.yaml, then conventional_commits in the user config
+func resolveConventional(flag, project *bool, user bool) bool {
+	if flag != nil {
+		return *flag
+	}
+	if project != nil {
+		return *project
+	}
+	return user
+}
+
+func generateCommitSubject(entry *schema.CheckpointEntry) string {
 	if len(entry.Changes) == 1 {
 		c := entry.Changes[0]
 		scope := c.Scope
 		if scope != "" {
-			return fmt.Sprintf("Checkpoint: %s (%s) - %s", c.ChangeType, scope, c.Summary)
+			return fmt.Sprintf("Checkpoint: %s (%s) - %s", changeTypeLabel(c), scope, c.Summary)
 		}
-		return fmt.Sprintf("Checkpoint: %s - %s", c.ChangeType, c.Summary)
+		return fmt.Sprintf("Checkpoint: %s - %s", changeTypeLabel(c), c.Summary)
 	}
 
 	// Multiple changes: summarize by type and scope
 	types := make(map[string]int)
 	scopes := make(map[string]int)
 	for _, c := range entry.Changes {
-		types[c.ChangeType]++
+		types[changeTypeLabel(c)]++
 		if c.Scope != "" {
 			scopes[c.Scope]++
 		}
@@ -305,6 +776,266 @@ func generateCommitMessage(entry *schema.CheckpointEntry) string {
 	return msg
 }
 
+// changeTypeLabel returns the change type, suffixed with '!' for breaking changes
+func changeTypeLabel(c schema.Change) string {
+	if c.Breaking {
+		return c.ChangeType + "!"
+	}
+	return c.ChangeType
+}
+
+// conventionalTypes maps checkpoint change types to Conventional Commits
+// types; types without an entry (such as build or ci from schema.yml) are
+// used as they are
+var conventionalTypes = map[string]string{
+	"feature": "feat",
+	"other":   "chore",
+}
+
+// generateConventionalMessage creates a Conventional Commits message: the
+// first change is the subject, the rest are listed in the body, followed by
+// BREAKING CHANGE footers and Co-authored-by trailers
+func generateConventionalMessage(entry *schema.CheckpointEntry, coAuthors []string) string {
+	msg := conventionalLine(entry.Changes[0])
+	if len(entry.Changes) > 1 {
+		var lines []string
+		for _, c := range entry.Changes[1:] {
+			lines = append(lines, "- "+conventionalLine(c))
+		}
+		msg += "\n\n" + strings.Join(lines, "\n")
+	}
+	return msg + breakingChangeFooter(entry) + coAuthorTrailers(coAuthors)
+}
+
+// conventionalLine formats one change as "type(scope)!: summary"
+func conventionalLine(c schema.Change) string {
+	ccType, ok := conventionalTypes[c.ChangeType]
+	if !ok {
+		ccType = c.ChangeType
+	}
+	if c.Scope != "" {
+		ccType += "(" + c.Scope + ")"
+	}
+	if c.Breaking {
+		ccType += "!"
+	}
+	return ccType + ": " + c.Summary
+}
+
+// breakingChangeFooter returns "BREAKING CHANGE:" footer lines for conventional commits
+func breakingChangeFooter(entry *schema.CheckpointEntry) string {
+	var lines []string
+	for _, c := range entry.Changes {
+		if !c.Breaking {
+			continue
+		}
+		desc := c.Summary
+		if details := strings.TrimSpace(c.Details); details != "" {
+			desc += "\n" + details
+		}
+		lines = append(lines, "BREAKING CHANGE: "+desc)
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "\n\n" + strings.Join(lines, "\n")
+}
+
+// coAuthorPattern matches "Name <email>" as used in Co-authored-by trailers
+var coAuthorPattern = regexp.MustCompile(`^([^<>]+?)\s*<([^<>\s@]+@[^<>\s@]+)>$`)
+
+// parseCoAuthors validates --co-author values and drops repeats of the
+// same email (case-insensitive), keeping the first spelling
+func parseCoAuthors(values []string) ([]string, error) {
+	var authors []string
+	seen := make(map[string]bool)
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		m := coAuthorPattern.FindStringSubmatch(v)
+		if m == nil || strings.TrimSpace(m[1]) == "" {
+			return nil, fmt.Errorf("invalid --co-author %q: expected \"Name <email>\"", v)
+		}
+		email := strings.ToLower(m[2])
+		if seen[email] {
+			continue
+		}
+		seen[email] = true
+		authors = append(authors, fmt.Sprintf("%s <%s>", strings.TrimSpace(m[1]), m[2]))
+	}
+	return authors, nil
+}
+
+// coAuthorTrailers returns a Co-authored-by trailer paragraph for the authors
+func coAuthorTrailers(authors []string) string {
+	if len(authors) == 0 {
+		return ""
+	}
+	lines := make([]string, len(authors))
+	for i, a := range authors {
+		lines[i] = "Co-authored-by: " + a
+	}
+	return "\n\n" + strings.Join(lines, "\n")
+}
+
+// readExternalContext loads a --context-from file as an external context item
+func readExternalContext(path string) (context.ExternalContext, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return context.ExternalContext{}, fmt.Errorf("read context file %s: %w", path, err)
+	}
+	content := strings.TrimSpace(strings.ReplaceAll(string(data), "\r\n", "\n"))
+	if content == "" {
+		return context.ExternalContext{}, fmt.Errorf("context file %s is empty", path)
+	}
+	return context.ExternalContext{Source: filepath.ToSlash(path), Content: content}, nil
+}
+
+// commitEditor returns the editor for --edit-message: $VISUAL, then $EDITOR, then vi
+func commitEditor() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if v := strings.TrimSpace(os.Getenv(env)); v != "" {
+			return v
+		}
+	}
+	return "vi"
+}
+
+// editCommitMessage opens msg in the user's editor and returns the cleaned result
+func editCommitMessage(msg string) (string, error) {
+	f, err := os.CreateTemp("", "checkpoint-commit-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("create message file: %w", err)
+	}
+	path := f.Name()
+	defer func() { _ = os.Remove(path) }()
+
+	content := msg + "\n\n# Edit the commit message above. Lines starting with '#' are ignored;\n# an empty message aborts the commit.\n"
+	if _, err := f.WriteString(content); err != nil {
+		_ = f.Close()
+		return "", fmt.Errorf("write message file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("write message file: %w", err)
+	}
+
+	// Run through the shell so editors with arguments (e.g. "code --wait") work
+	editor := commitEditor()
+	c := exec.Command("sh", "-c", editor+` "$1"`, "sh", path)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := c.Run(); err != nil {
+		return "", fmt.Errorf("editor %s failed: %w", editor, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("read message file: %w", err)
+	}
+	return cleanCommitMessage(string(data)), nil
+}
+
+// cleanCommitMessage drops '#' comment lines and surrounding blank lines
+func cleanCommitMessage(s string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \t"))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// projectSettings holds optional settings from .checkpoint/project.yaml
+type projectSettings struct {
+	ContextRetention context.RetentionPolicy `yaml:"context_retention"`
+	Commit           struct {
+		Conventional   *bool  `yaml:"conventional"`    // Conventional Commits messages; unset defers to the user config
+		RequireContext bool   `yaml:"require_context"` // reject entries without problem_statement and a decision/insight
+		HistoryCheck   string `yaml:"history_check"`   // confirm (default; warns when not a terminal), strict, warn, off
+	} `yaml:"commit"`
+	Session struct {
+		MaxSnapshots int `yaml:"max_snapshots"` // 0 uses defaultMaxSessionSnapshots
+	} `yaml:"session"`
+	Hooks struct {
+		PrePush string `yaml:"pre_push"` // warn (default) or block; read by init --git-hook
+	} `yaml:"hooks"`
+	Doctor struct {
+		ChangelogMaxMB      int `yaml:"changelog_max_mb"`      // 0 uses defaultChangelogMaxMB
+		ChangelogMaxEntries int `yaml:"changelog_max_entries"` // 0 uses defaultChangelogMaxEntries
+	} `yaml:"doctor"`
+}
+
+// historyCheckAction decides what to do about unreachable checkpoints: fail,
+// prompt, or continue. confirm only prompts on a terminal; without one (agents,
+// CI) it continues like warn so a rebase does not break unattended commits.
+func historyCheckAction(mode string, interactive bool) string {
+	switch mode {
+	case "strict":
+		return "fail"
+	case "confirm":
+		if interactive {
+			return "prompt"
+		}
+	}
+	return "continue"
+}
+
+// historyCheckMode validates commit.history_check, defaulting to confirm
+func historyCheckMode(mode string) (string, error) {
+	switch mode = strings.ToLower(strings.TrimSpace(mode)); mode {
+	case "":
+		return "confirm", nil
+	case "confirm", "strict", "warn", "off":
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid commit.history_check '%s'", mode)
+	}
+}
+
+// unreachableCheckpoints returns the short hashes among the last n recorded
+// checkpoint commits that HEAD no longer contains
+func unreachableCheckpoints(projectPath string, n int) ([]string, error) {
+	changelogPath := filepath.Join(projectPath, config.ChangelogFileName)
+	if !file.Exists(changelogPath) {
+		return nil, nil
+	}
+	entries, err := loadChangelogEntries(changelogPath)
+	if err != nil {
+		return nil, err
+	}
+	var missing []string
+	for i := len(entries) - 1; i >= 0 && n > 0; i-- {
+		hash := entries[i].CommitHash
+		if hash == "" {
+			continue
+		}
+		n--
+		ok, err := git.IsAncestor(projectPath, hash, "HEAD")
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			missing = append(missing, hash[:min(8, len(hash))])
+		}
+	}
+	return missing, nil
+}
+
+// loadProjectSettings reads optional settings from .checkpoint/project.yaml;
+// a missing file or invalid section leaves defaults (unlimited retention,
+// non-conventional commit messages, no context requirement)
+func loadProjectSettings(projectPath string) projectSettings {
+	projectYaml := file.FindWithFallback(
+		filepath.Join(projectPath, config.CheckpointDir, config.ExplainProjectYaml),
+		filepath.Join(projectPath, config.CheckpointDir, config.ExplainProjectYmlLegacy),
+	)
+	var cfg projectSettings
+	if data, err := os.ReadFile(projectYaml); err == nil {
+		_ = yaml.Unmarshal(data, &cfg)
+	}
+	return cfg
+}
+
 // generateProjectRecommendations extracts project-scoped items from checkpoint context
 func generateProjectRecommendations(ctx context.CheckpointContext) *struct {
 	Additions project.ProjectAdditions
@@ -393,24 +1124,35 @@ func generateStatusFile(entry *schema.CheckpointEntry, commitMsg string, project
 	// Commit metadata
 	b.WriteString(fmt.Sprintf("last_commit_hash: \"%s\"\n", entry.CommitHash))
 	b.WriteString(fmt.Sprintf("last_commit_timestamp: \"%s\"\n", entry.Timestamp))
-	b.WriteString(fmt.Sprintf("last_commit_message: \"%s\"\n", commitMsg))
+	// Only the subject: commit messages carry quotes, bodies and trailers that
+	// must not break the YAML
+	subject, _, _ := strings.Cut(commitMsg, "\n")
+	b.WriteString(fmt.Sprintf("last_commit_message: %s\n", strconv.Quote(strings.TrimSpace(subject))))
 	b.WriteString("status: \"success\"\n")
 	b.WriteString(fmt.Sprintf("changes_count: %d\n", len(entry.Changes)))
-	if len(entry.NextSteps) > 0 {
-		b.WriteString("next_steps:\n")
-		for _, ns := range entry.NextSteps {
-			b.WriteString("  - summary: \"")
-			b.WriteString(strings.ReplaceAll(ns.Summary, "\"", "'"))
-			b.WriteString("\"\n")
-			if ns.Details != "" {
-				b.WriteString("    details: \"" + strings.ReplaceAll(ns.Details, "\"", "'") + "\"\n")
-			}
-			if ns.Priority != "" {
-				b.WriteString("    priority: \"" + ns.Priority + "\"\n")
-			}
-			if ns.Scope != "" {
-				b.WriteString("    scope: \"" + ns.Scope + "\"\n")
-			}
+	b.WriteString(renderStatusNextSteps(entry.NextSteps))
+	return b.String()
+}
+
+// renderStatusNextSteps renders the status file's next_steps block (empty when there are none)
+func renderStatusNextSteps(steps []schema.NextStep) string {
+	if len(steps) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("next_steps:\n")
+	for _, ns := range steps {
+		b.WriteString("  - summary: \"")
+		b.WriteString(strings.ReplaceAll(ns.Summary, "\"", "'"))
+		b.WriteString("\"\n")
+		if ns.Details != "" {
+			b.WriteString("    details: \"" + strings.ReplaceAll(ns.Details, "\"", "'") + "\"\n")
+		}
+		if ns.Priority != "" {
+			b.WriteString("    priority: \"" + ns.Priority + "\"\n")
+		}
+		if ns.Scope != "" {
+			b.WriteString("    scope: \"" + ns.Scope + "\"\n")
 		}
 	}
 	return b.String()
@@ -7,10 +7,15 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/dmoose/checkpoint/internal/changelog"
+	"github.com/dmoose/checkpoint/internal/context"
 	"github.com/dmoose/checkpoint/internal/file"
 	"github.com/dmoose/checkpoint/internal/schema"
 	"github.com/dmoose/checkpoint/pkg/config"
+
+	"gopkg.in/yaml.v3"
 )
 
 // TestCommitValidation tests the validation logic in commit command
@@ -148,32 +153,120 @@ next_steps:
 func TestGenerateCommitMessage(t *testing.T) {
 	tests := []struct {
 		name     string
-		changes  []string // simplified - just summaries
+		changes  []schema.Change
 		expected string
 	}{
 		{
 			name:     "single change",
-			changes:  []string{"Add new feature"},
+			changes:  []schema.Change{{Summary: "Add new feature", ChangeType: "feature"}},
 			expected: "Checkpoint: feature - Add new feature",
 		},
 		{
-			name:     "multiple changes",
-			changes:  []string{"Add feature", "Fix bug"},
-			expected: "Checkpoint: 2 changes - feature, fix",
+			name:     "single change with scope",
+			changes:  []schema.Change{{Summary: "Fix parser", ChangeType: "fix", Scope: "schema"}},
+			expected: "Checkpoint: fix (schema) - Fix parser",
+		},
+		{
+			name:     "breaking change marks type",
+			changes:  []schema.Change{{Summary: "Rename flags", ChangeType: "feature", Scope: "cli", Breaking: true}},
+			expected: "Checkpoint: feature! (cli) - Rename flags",
+		},
+		{
+			name: "multiple breaking changes get no footer",
+			changes: []schema.Change{
+				{Summary: "Drop v1 API", ChangeType: "refactor", Breaking: true},
+				{Summary: "Drop v1 docs", ChangeType: "refactor", Breaking: true},
+			},
+			expected: "Checkpoint: 2 changes - refactor!(2)",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// This would require refactoring generateCommitMessage to be more testable
-			// For now, we verify the basic structure exists
-			if len(tt.changes) == 0 {
-				t.Skip("Need to refactor generateCommitMessage for better testability")
+			entry := &schema.CheckpointEntry{Changes: tt.changes}
+			if got := generateCommitMessage(entry, nil); got != tt.expected {
+				t.Errorf("generateCommitMessage() = %q, want %q", got, tt.expected)
 			}
 		})
 	}
 }
 
+func TestResolveConventional(t *testing.T) {
+	yes, no := true, false
+	tests := []struct {
+		name    string
+		flag    *bool
+		project *bool
+		user    bool
+		want    bool
+	}{
+		{"nothing set", nil, nil, false, false},
+		{"user default", nil, nil, true, true},
+		{"project overrides user", nil, &no, true, false},
+		{"project enables", nil, &yes, false, true},
+		{"flag overrides project", &no, &yes, true, false},
+		{"flag enables", &yes, nil, false, true},
+	}
+	for _, tt := range tests {
+		if got := resolveConventional(tt.flag, tt.project, tt.user); got != tt.want {
+			t.Errorf("%s: resolveConventional() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateConventionalMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		changes  []schema.Change
+		expected string
+	}{
+		{
+			name:     "single change with scope",
+			changes:  []schema.Change{{Summary: "Add rate limiter", ChangeType: "feature", Scope: "api"}},
+			expected: "feat(api): Add rate limiter",
+		},
+		{
+			name:     "other maps to chore",
+			changes:  []schema.Change{{Summary: "Bump dependencies", ChangeType: "other"}},
+			expected: "chore: Bump dependencies",
+		},
+		{
+			name:     "project change types pass through",
+			changes:  []schema.Change{{Summary: "Cache modules", ChangeType: "ci"}},
+			expected: "ci: Cache modules",
+		},
+		{
+			name: "additional changes in the body",
+			changes: []schema.Change{
+				{Summary: "Add search --sort", ChangeType: "feature", Scope: "search"},
+				{Summary: "Fix empty query crash", ChangeType: "fix", Scope: "search"},
+				{Summary: "Document sorting", ChangeType: "docs"},
+			},
+			expected: "feat(search): Add search --sort\n\n- fix(search): Fix empty query crash\n- docs: Document sorting",
+		},
+		{
+			name:     "breaking change",
+			changes:  []schema.Change{{Summary: "Rename flags", Details: "--out is now --output", ChangeType: "feature", Scope: "cli", Breaking: true}},
+			expected: "feat(cli)!: Rename flags\n\nBREAKING CHANGE: Rename flags\n--out is now --output",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := &schema.CheckpointEntry{Changes: tt.changes}
+			if got := generateConventionalMessage(entry, nil); got != tt.expected {
+				t.Errorf("generateConventionalMessage() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	entry := &schema.CheckpointEntry{Changes: []schema.Change{{Summary: "Fix parser", ChangeType: "fix"}}}
+	want := "fix: Fix parser\n\nCo-authored-by: Pair <pair@example.com>"
+	if got := generateConventionalMessage(entry, []string{"Pair <pair@example.com>"}); got != want {
+		t.Errorf("generateConventionalMessage() = %q, want %q", got, want)
+	}
+}
+
 // TestCommitDryRun tests dry-run functionality
 func TestCommitDryRun(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "checkpoint-test")
@@ -349,3 +442,433 @@ func runGitCmd(dir string, args ...string) error {
 	}
 	return nil
 }
+
+func TestCleanCommitMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"comments dropped", "Fix parser\n\nHandle CRLF input\n# comment\n", "Fix parser\n\nHandle CRLF input"},
+		{"only comments", "# nothing here\n#\n\n", ""},
+		{"crlf and trailing spaces", "Fix parser  \r\n\r\nBody\r\n", "Fix parser\n\nBody"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cleanCommitMessage(tt.in); got != tt.want {
+				t.Errorf("cleanCommitMessage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditCommitMessage(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir()) // keeps sed's backup file out of the real temp dir
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "sed -i.bak s/Add/Rework/")
+
+	got, err := editCommitMessage("Add parser\n\n- details")
+	if err != nil {
+		t.Fatalf("editCommitMessage error: %v", err)
+	}
+	if got != "Rework parser\n\n- details" {
+		t.Errorf("unexpected edited message: %q", got)
+	}
+
+	t.Setenv("EDITOR", "false")
+	if _, err := editCommitMessage("Add parser"); err == nil {
+		t.Errorf("expected error when editor fails")
+	}
+}
+
+func TestCommitContextFrom(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test User"},
+	} {
+		if err := runGitCmd(tmpDir, args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+
+	inputYAML := `schema_version: "1"
+timestamp: "2023-01-01T12:00:00Z"
+changes:
+  - summary: "Add rate limiter"
+    change_type: "feature"
+context:
+  problem_statement: "API abuse"
+`
+	if err := file.WriteFile(filepath.Join(tmpDir, config.InputFileName), inputYAML); err != nil {
+		t.Fatalf("write input: %v", err)
+	}
+	notesPath := filepath.Join(t.TempDir(), "design.md")
+	if err := os.WriteFile(notesPath, []byte("# Design\r\n\r\nToken bucket per API key.\r\n"), 0644); err != nil {
+		t.Fatalf("write notes: %v", err)
+	}
+
+	CommitWithOptions(tmpDir, CommitOptions{ContextFrom: []string{notesPath}}, "test-version")
+
+	entries, err := context.GetRecentContextEntries(filepath.Join(tmpDir, config.ContextFileName), 1)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected one context entry, got %d (err %v)", len(entries), err)
+	}
+	ext := entries[0].Context.ExternalContext
+	if len(ext) != 1 || ext[0].Source != filepath.ToSlash(notesPath) || ext[0].Content != "# Design\n\nToken bucket per API key." {
+		t.Errorf("unexpected external context: %+v", ext)
+	}
+	if entries[0].Context.ProblemStatement != "API abuse" {
+		t.Errorf("problem_statement should be kept, got %q", entries[0].Context.ProblemStatement)
+	}
+
+	if _, err := readExternalContext(filepath.Join(tmpDir, "missing.md")); err == nil {
+		t.Errorf("expected error for missing context file")
+	}
+}
+
+func TestCommitNoContext(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test User"},
+	} {
+		if err := runGitCmd(tmpDir, args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(tmpDir, config.CheckpointDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	projectYAML := "commit:\n  require_context: true\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, config.CheckpointDir, config.ExplainProjectYaml), []byte(projectYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// --no-context drops this context, so require_context would reject the
+	// entry unless the gate is bypassed (a rejection exits the test binary)
+	inputYAML := `schema_version: "1"
+timestamp: "2023-01-01T12:00:00Z"
+changes:
+  - summary: "Bump dependency"
+    change_type: "other"
+context:
+  problem_statement: "Outdated dependency"
+  key_insights:
+    - insight: "Minor release, no API changes"
+`
+	if err := file.WriteFile(filepath.Join(tmpDir, config.InputFileName), inputYAML); err != nil {
+		t.Fatalf("write input: %v", err)
+	}
+
+	CommitWithOptions(tmpDir, CommitOptions{NoContext: true}, "test-version")
+
+	out, err := exec.Command("git", "-C", tmpDir, "log", "--format=%s").Output()
+	if err != nil {
+		t.Fatalf("git log: %v", err)
+	}
+	if !strings.Contains(string(out), "Bump dependency") {
+		t.Errorf("expected checkpoint commit despite require_context, got log:\n%s", out)
+	}
+	if file.Exists(filepath.Join(tmpDir, config.ContextFileName)) {
+		t.Error("--no-context should not write a context document")
+	}
+}
+
+func TestCommitAmend(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test User"},
+	} {
+		if err := runGitCmd(tmpDir, args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	writeInput := func(summary, timestamp string) {
+		input := fmt.Sprintf("schema_version: \"1\"\ntimestamp: %q\nchanges:\n  - summary: %q\n    change_type: \"feature\"\ncontext:\n  problem_statement: %q\n",
+			timestamp, summary, summary+" problem")
+		if err := file.WriteFile(filepath.Join(tmpDir, config.InputFileName), input); err != nil {
+			t.Fatalf("write input: %v", err)
+		}
+	}
+	changelogPath := filepath.Join(tmpDir, config.ChangelogFileName)
+
+	if _, err := amendTarget(tmpDir); err == nil {
+		t.Fatal("expected error amending without a checkpoint")
+	}
+
+	writeInput("Add rate limiter", "2023-01-01T12:00:00Z")
+	CommitWithOptions(tmpDir, CommitOptions{}, "test-version")
+	writeInput("Add token bucket rate limiter", "2023-01-02T12:00:00Z")
+	CommitWithOptions(tmpDir, CommitOptions{Amend: true}, "test-version")
+
+	out, err := exec.Command("git", "-C", tmpDir, "log", "--format=%s").Output()
+	if err != nil {
+		t.Fatalf("git log: %v", err)
+	}
+	if got := strings.TrimSpace(string(out)); got != "Checkpoint: feature - Add token bucket rate limiter" {
+		t.Errorf("expected a single amended commit, got %q", got)
+	}
+	last, err := changelog.LastEntry(changelogPath)
+	if err != nil || last == nil {
+		t.Fatalf("LastEntry = %v, %v", last, err)
+	}
+	if last.Changes[0].Summary != "Add token bucket rate limiter" || last.Timestamp != "2023-01-01T12:00:00Z" {
+		t.Errorf("expected amended content with the original timestamp, got %+v", last)
+	}
+	if meta, err := changelog.ReadMetaDocument(changelogPath); err != nil || meta == nil {
+		t.Errorf("meta document lost: %v", err)
+	}
+	entries, err := context.GetRecentContextEntries(filepath.Join(tmpDir, config.ContextFileName), 5)
+	if err != nil || len(entries) != 1 || entries[0].Context.ProblemStatement != "Add token bucket rate limiter problem" {
+		t.Errorf("expected the context entry replaced, got %+v (err %v)", entries, err)
+	}
+
+	// A non-checkpoint commit on top can't be amended
+	if err := runGitCmd(tmpDir, "commit", "--allow-empty", "-m", "unrelated"); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, err := amendTarget(tmpDir); err == nil {
+		t.Error("expected refusal when HEAD is not a checkpoint commit")
+	}
+}
+
+func TestUnreachableCheckpoints(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test User"},
+		{"commit", "--allow-empty", "-m", "first"},
+		{"commit", "--allow-empty", "-m", "second"},
+	} {
+		if err := runGitCmd(tmpDir, args...); err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+	revParse := func(rev string) string {
+		out, err := exec.Command("git", "-C", tmpDir, "rev-parse", rev).Output()
+		if err != nil {
+			t.Fatalf("rev-parse %s: %v", rev, err)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	first, second := revParse("HEAD~1"), revParse("HEAD")
+
+	changelog := fmt.Sprintf("---\nschema_version: \"1\"\ndocument_type: meta\n"+
+		"---\nschema_version: \"1\"\ntimestamp: \"2025-01-01T00:00:00Z\"\ncommit_hash: %s\n"+
+		"---\nschema_version: \"1\"\ntimestamp: \"2025-01-02T00:00:00Z\"\ncommit_hash: %s\n", first, second)
+	if err := os.WriteFile(filepath.Join(tmpDir, config.ChangelogFileName), []byte(changelog), 0644); err != nil {
+		t.Fatalf("write changelog: %v", err)
+	}
+
+	if missing, err := unreachableCheckpoints(tmpDir, 2); err != nil || len(missing) != 0 {
+		t.Fatalf("expected intact history, got %v, %v", missing, err)
+	}
+
+	// Simulate a rebase that dropped the last checkpoint commit
+	if err := runGitCmd(tmpDir, "reset", "--hard", "HEAD~1"); err != nil {
+		t.Fatalf("%v", err)
+	}
+	missing, err := unreachableCheckpoints(tmpDir, 2)
+	if err != nil || len(missing) != 1 || missing[0] != second[:8] {
+		t.Errorf("expected %s unreachable, got %v, %v", second[:8], missing, err)
+	}
+	// Only the requested number of parents is checked
+	if missing, _ := unreachableCheckpoints(tmpDir, 0); len(missing) != 0 {
+		t.Errorf("expected no checks with n=0, got %v", missing)
+	}
+}
+
+func TestHistoryCheckMode(t *testing.T) {
+	for in, want := range map[string]string{"": "confirm", "Strict": "strict", "warn": "warn", "off": "off"} {
+		if got, err := historyCheckMode(in); err != nil || got != want {
+			t.Errorf("historyCheckMode(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := historyCheckMode("sometimes"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
+
+func TestHistoryCheckAction(t *testing.T) {
+	tests := []struct {
+		mode        string
+		interactive bool
+		want        string
+	}{
+		{"confirm", true, "prompt"},
+		{"confirm", false, "continue"},
+		{"strict", true, "fail"},
+		{"strict", false, "fail"},
+		{"warn", false, "continue"},
+		{"off", false, "continue"},
+	}
+	for _, tt := range tests {
+		if got := historyCheckAction(tt.mode, tt.interactive); got != tt.want {
+			t.Errorf("historyCheckAction(%q, %v) = %q, want %q", tt.mode, tt.interactive, got, tt.want)
+		}
+	}
+}
+
+func TestCommitRewrittenHistoryNonInteractive(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "user.email", "test@example.com"},
+		{"config", "user.name", "Test User"},
+	} {
+		if err := runGitCmd(tmpDir, args...); err != nil {
+			t.Fatalf("git %v: %v", args, err)
+		}
+	}
+	writeInput := func(summary, timestamp string) {
+		input := fmt.Sprintf("schema_version: \"1\"\ntimestamp: %q\nchanges:\n  - summary: %q\n    change_type: \"feature\"\n", timestamp, summary)
+		if err := file.WriteFile(filepath.Join(tmpDir, config.InputFileName), input); err != nil {
+			t.Fatalf("write input: %v", err)
+		}
+	}
+
+	writeInput("Add rate limiter", "2023-01-01T12:00:00Z")
+	CommitWithOptions(tmpDir, CommitOptions{Parents: 1}, "test-version")
+	// Rewrite the checkpoint commit so its recorded hash is no longer reachable
+	if err := runGitCmd(tmpDir, "commit", "--amend", "-m", "Rewritten checkpoint"); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if missing, err := unreachableCheckpoints(tmpDir, 1); err != nil || len(missing) != 1 {
+		t.Fatalf("expected the checkpoint to be unreachable, got %v (err %v)", missing, err)
+	}
+
+	// A regular file as stdin is not a terminal; the default confirm mode
+	// must warn and continue instead of exiting
+	stdin, err := os.CreateTemp(t.TempDir(), "stdin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = stdin.Close() }()
+	oldStdin := os.Stdin
+	os.Stdin = stdin
+	defer func() { os.Stdin = oldStdin }()
+	if stdinIsTerminal() {
+		t.Fatal("expected a regular file not to count as a terminal")
+	}
+
+	writeInput("Add token bucket", "2023-01-02T12:00:00Z")
+	CommitWithOptions(tmpDir, CommitOptions{Parents: 1}, "test-version")
+
+	out, err := exec.Command("git", "-C", tmpDir, "log", "-1", "--format=%s").Output()
+	if err != nil {
+		t.Fatalf("git log: %v", err)
+	}
+	if got := strings.TrimSpace(string(out)); got != "Checkpoint: feature - Add token bucket" {
+		t.Errorf("expected the non-interactive commit to go through, got %q", got)
+	}
+}
+
+func TestParseCoAuthors(t *testing.T) {
+	authors, err := parseCoAuthors([]string{
+		"Jane Doe <jane@example.com>",
+		" Pair Bot<bot@example.com> ",
+		"J. Doe <JANE@example.com>",
+	})
+	if err != nil {
+		t.Fatalf("parseCoAuthors: %v", err)
+	}
+	want := []string{"Jane Doe <jane@example.com>", "Pair Bot <bot@example.com>"}
+	if strings.Join(authors, "|") != strings.Join(want, "|") {
+		t.Errorf("got %v, want %v", authors, want)
+	}
+
+	for _, bad := range []string{"jane@example.com", "<jane@example.com>", "Jane <not-an-email>", "Jane Doe"} {
+		if _, err := parseCoAuthors([]string{bad}); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+
+	entry := &schema.CheckpointEntry{Changes: []schema.Change{{Summary: "Add feature", ChangeType: "feature"}}}
+	got := generateCommitMessage(entry, authors)
+	expected := "Checkpoint: feature - Add feature\n\nCo-authored-by: Jane Doe <jane@example.com>\nCo-authored-by: Pair Bot <bot@example.com>"
+	if got != expected {
+		t.Errorf("generateCommitMessage() = %q, want %q", got, expected)
+	}
+}
+
+func TestCommitLockBackoff(t *testing.T) {
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, 1600 * time.Millisecond, 2 * time.Second, 2 * time.Second}
+	for attempt, w := range want {
+		if got := commitLockBackoff(attempt); got != w {
+			t.Errorf("commitLockBackoff(%d) = %s, want %s", attempt, got, w)
+		}
+	}
+}
+
+func TestAcquireCommitLock(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := runGitCmd(tmpDir, "init"); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	lockPath, err := acquireCommitLock(tmpDir, CommitOptions{})
+	if err != nil {
+		t.Fatalf("acquireCommitLock: %v", err)
+	}
+	if !strings.Contains(filepath.ToSlash(lockPath), "/.git/") {
+		t.Errorf("lock should live in the git directory, got %s", lockPath)
+	}
+
+	// Held: fail at once, or time out while retrying
+	if _, err := acquireCommitLock(tmpDir, CommitOptions{}); err == nil {
+		t.Fatal("expected error while the lock is held")
+	}
+	if _, err := acquireCommitLock(tmpDir, CommitOptions{RetryOnLock: true, LockTimeout: 150 * time.Millisecond}); err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected timeout, got %v", err)
+	}
+
+	// Retrying picks the lock up once the other commit releases it
+	go func() {
+		time.Sleep(150 * time.Millisecond)
+		releaseCommitLock(lockPath)
+	}()
+	if _, err := acquireCommitLock(tmpDir, CommitOptions{RetryOnLock: true, LockTimeout: 5 * time.Second}); err != nil {
+		t.Fatalf("expected the lock after release, got %v", err)
+	}
+
+	// A lock left behind by a dead commit is taken over
+	old := fmt.Sprintf("pid=1\ntimestamp=%s\n", time.Now().Add(-time.Hour).Format(time.RFC3339))
+	if err := os.WriteFile(lockPath, []byte(old), 0644); err != nil {
+		t.Fatalf("write lock: %v", err)
+	}
+	if _, err := acquireCommitLock(tmpDir, CommitOptions{}); err != nil {
+		t.Errorf("expected stale lock takeover, got %v", err)
+	}
+}
+
+func TestGenerateStatusFileQuotesMessage(t *testing.T) {
+	entry := &schema.CheckpointEntry{
+		Timestamp:  "2025-01-01T00:00:00Z",
+		CommitHash: "abc123",
+		Changes:    []schema.Change{{Summary: "Add f", ChangeType: "feature"}},
+		NextSteps:  []schema.NextStep{{Summary: "Test f", Priority: "high"}},
+	}
+	msg := "Add \"f\" helper \\ tidy\n\nBody line\n\nCo-authored-by: A <a@b>"
+	content := generateStatusFile(entry, msg, "", "")
+
+	var status struct {
+		LastCommitMessage string `yaml:"last_commit_message"`
+		ChangesCount      int    `yaml:"changes_count"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &status); err != nil {
+		t.Fatalf("status file is not valid YAML: %v\n%s", err, content)
+	}
+	if status.LastCommitMessage != `Add "f" helper \ tidy` || status.ChangesCount != 1 {
+		t.Errorf("unexpected status %+v", status)
+	}
+	steps := schema.ExtractNextStepsFromStatus(content)
+	if len(steps) != 1 || steps[0].Summary != "Test f" {
+		t.Errorf("expected next step to survive, got %+v", steps)
+	}
+}
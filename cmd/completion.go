@@ -2,11 +2,20 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
 
+	"github.com/dmoose/checkpoint/internal/explain"
+	"github.com/dmoose/checkpoint/internal/file"
+	"github.com/dmoose/checkpoint/internal/prompts"
+	"github.com/dmoose/checkpoint/pkg/config"
+
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 func init() {
@@ -15,7 +24,7 @@ func init() {
 }
 
 var completionCmd = &cobra.Command{
-	Use:   "completion [bash|zsh|fish|powershell]",
+	Use:   "completion [bash|zsh|fish|powershell|pwsh]",
 	Short: "Generate shell completion scripts",
 	Long: `Generate shell completion scripts for checkpoint.
 
@@ -36,30 +45,48 @@ Zsh:
 Fish:
   $ checkpoint completion fish > ~/.config/fish/completions/checkpoint.fish
 
-PowerShell:
-  PS> checkpoint completion powershell | Out-String | Invoke-Expression
+PowerShell ('pwsh' is accepted as an alias):
+  PS> checkpoint completion pwsh | Out-String | Invoke-Expression
   # To load completions for each session, add to your profile:
-  PS> checkpoint completion powershell >> $PROFILE
+  PS> checkpoint completion pwsh >> $PROFILE
 
 Or use 'checkpoint completion install' to auto-detect and install.
+
+Completions are dynamic: skill names for 'skill show/add', prompt ids for
+'prompt' and 'prompt diff', and changelog scopes for 'search --scope' are read
+from the project in the current directory.
 `,
 	DisableFlagsInUseLine: true,
-	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell", "pwsh"},
 	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
 	Run: func(cmd *cobra.Command, args []string) {
-		switch args[0] {
-		case "bash":
-			_ = rootCmd.GenBashCompletion(os.Stdout)
-		case "zsh":
-			_ = rootCmd.GenZshCompletion(os.Stdout)
-		case "fish":
-			_ = rootCmd.GenFishCompletion(os.Stdout, true)
-		case "powershell":
-			_ = rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
-		}
+		_ = writeCompletion(args[0], os.Stdout)
 	},
 }
 
+// writeCompletion writes the completion script for shell; pwsh is an alias for powershell
+func writeCompletion(shell string, w io.Writer) error {
+	switch shell {
+	case "bash":
+		return rootCmd.GenBashCompletion(w)
+	case "zsh":
+		return rootCmd.GenZshCompletion(w)
+	case "fish":
+		return rootCmd.GenFishCompletion(w, true)
+	case "powershell", "pwsh":
+		return rootCmd.GenPowerShellCompletionWithDesc(w)
+	}
+	return fmt.Errorf("unsupported shell '%s'", shell)
+}
+
+// pwshCompletionPath is where install writes the PowerShell script for goos
+func pwshCompletionPath(home, goos string) string {
+	if goos == "windows" {
+		return filepath.Join(home, "Documents", "PowerShell", "checkpoint.ps1")
+	}
+	return filepath.Join(home, ".config", "powershell", "checkpoint.ps1")
+}
+
 var completionInstallCmd = &cobra.Command{
 	Use:   "install",
 	Short: "Auto-detect shell and install completions",
@@ -69,13 +96,17 @@ Supported shells and locations:
   Fish: ~/.config/fish/completions/checkpoint.fish
   Zsh:  ~/.oh-my-zsh/completions/_checkpoint (if oh-my-zsh detected)
   Bash: ~/.local/share/bash-completion/completions/checkpoint
+  Pwsh: ~/.config/powershell/checkpoint.ps1 (Documents\PowerShell on Windows),
+        dot-sourced from $PROFILE
+
+PowerShell is detected from $SHELL, or on Windows when $SHELL is unset.
 
 If the install location cannot be determined, the command will error with manual instructions.
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		shell := detectShell()
 		if shell == "" {
-			fmt.Fprintln(os.Stderr, "error: cannot detect shell from $SHELL")
+			fmt.Fprintln(os.Stderr, "error: cannot detect shell from $SHELL (supported: bash, zsh, fish, pwsh)")
 			fmt.Fprintln(os.Stderr, "hint: Run 'checkpoint completion --help' for manual installation")
 			os.Exit(1)
 		}
@@ -155,8 +186,19 @@ If the install location cannot be determined, the command will error with manual
 				return rootCmd.GenBashCompletion(f)
 			}
 
+		case "pwsh":
+			installPath = pwshCompletionPath(home, runtime.GOOS)
+			generator = func() error {
+				f, err := os.Create(installPath)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = f.Close() }()
+				return writeCompletion(shell, f)
+			}
+
 		default:
-			fmt.Fprintf(os.Stderr, "error: unsupported shell '%s'\n", shell)
+			fmt.Fprintf(os.Stderr, "error: unsupported shell '%s' (supported: bash, zsh, fish, pwsh)\n", shell)
 			fmt.Fprintln(os.Stderr, "hint: Run 'checkpoint completion --help' for manual installation")
 			os.Exit(1)
 		}
@@ -176,16 +218,26 @@ If the install location cannot be determined, the command will error with manual
 		if shell == "zsh" || shell == "bash" {
 			fmt.Println("Restart your shell or source your profile to enable completions")
 		}
+		if shell == "pwsh" {
+			fmt.Println("Enable it by adding this line to your $PROFILE, then restart PowerShell:")
+			fmt.Printf("  . %s\n", installPath)
+		}
 	},
 }
 
 func detectShell() string {
 	shell := os.Getenv("SHELL")
 	if shell == "" {
+		// Windows has no $SHELL; assume PowerShell when it is available
+		if runtime.GOOS == "windows" && os.Getenv("PSModulePath") != "" {
+			return "pwsh"
+		}
 		return ""
 	}
 	base := filepath.Base(shell)
 	switch {
+	case strings.Contains(base, "pwsh"), strings.Contains(base, "powershell"):
+		return "pwsh"
 	case strings.Contains(base, "fish"):
 		return "fish"
 	case strings.Contains(base, "zsh"):
@@ -201,3 +253,145 @@ func dirExists(path string) bool {
 	info, err := os.Stat(path)
 	return err == nil && info.IsDir()
 }
+
+// Dynamic completions. The scripts generated above call cobra's hidden
+// '__complete' command, which runs these functions against the project in
+// the current directory.
+
+// completeSkillArgs completes the skill action, then skill names: every known
+// skill for show, and global skills not yet configured for add
+func completeSkillArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch {
+	case len(args) == 0:
+		return filterCompletions([]string{"list", "show", "add", "create"}, toComplete), cobra.ShellCompDirectiveNoFileComp
+	case len(args) == 1 && args[0] == "show":
+		return filterCompletions(knownSkillNames("."), toComplete), cobra.ShellCompDirectiveNoFileComp
+	case len(args) == 1 && args[0] == "add":
+		return filterCompletions(addableSkillNames("."), toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completePromptIDs completes prompt ids from the project's prompt library
+func completePromptIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return filterCompletions(promptIDs("."), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeSearchScope completes --scope with the scopes used in the changelog
+func completeSearchScope(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return filterCompletions(changelogScopes("."), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// knownSkillNames lists local, configured and global skills
+func knownSkillNames(projectPath string) []string {
+	var names []string
+	skillsDir := filepath.Join(projectPath, config.CheckpointDir, config.SkillsDir)
+	if entries, err := os.ReadDir(skillsDir); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() && file.Exists(filepath.Join(skillsDir, entry.Name(), "skill.md")) {
+				names = append(names, entry.Name())
+			}
+		}
+	}
+	names = append(names, configuredGlobalSkills(projectPath)...)
+	names = append(names, listAvailableGlobalSkills()...)
+	return uniqueSorted(names)
+}
+
+// addableSkillNames lists global skills the project does not use yet
+func addableSkillNames(projectPath string) []string {
+	configured := make(map[string]bool)
+	for _, name := range configuredGlobalSkills(projectPath) {
+		configured[name] = true
+	}
+	var names []string
+	for _, name := range listAvailableGlobalSkills() {
+		if !configured[name] {
+			names = append(names, name)
+		}
+	}
+	return uniqueSorted(names)
+}
+
+// configuredGlobalSkills reads the global skill names from skills.yaml
+func configuredGlobalSkills(projectPath string) []string {
+	skillsPath := file.FindWithFallback(
+		filepath.Join(projectPath, config.CheckpointDir, config.ExplainSkillsYaml),
+		filepath.Join(projectPath, config.CheckpointDir, config.ExplainSkillsYmlLegacy),
+	)
+	data, err := os.ReadFile(skillsPath)
+	if err != nil {
+		return nil
+	}
+	var cfg explain.SkillsConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil
+	}
+	return cfg.Global
+}
+
+// promptIDs lists the ids in .checkpoint/prompts/prompts.yaml
+func promptIDs(projectPath string) []string {
+	cfg, err := prompts.LoadPromptsConfig(filepath.Join(projectPath, ".checkpoint", "prompts"))
+	if err != nil {
+		return nil
+	}
+	var ids []string
+	for _, p := range cfg.Prompts {
+		ids = append(ids, p.ID)
+	}
+	return uniqueSorted(ids)
+}
+
+// changelogScopes collects the change and next step scopes in the changelog
+func changelogScopes(projectPath string) []string {
+	data, err := os.ReadFile(filepath.Join(projectPath, config.ChangelogFileName))
+	if err != nil {
+		return nil
+	}
+	var scopes []string
+	for _, doc := range splitYAMLDocuments(string(data)) {
+		var entry struct {
+			Changes   []struct{ Scope string } `yaml:"changes"`
+			NextSteps []struct{ Scope string } `yaml:"next_steps"`
+		}
+		if err := yaml.Unmarshal([]byte(doc), &entry); err != nil {
+			continue
+		}
+		for _, c := range entry.Changes {
+			scopes = append(scopes, c.Scope)
+		}
+		for _, s := range entry.NextSteps {
+			scopes = append(scopes, s.Scope)
+		}
+	}
+	return uniqueSorted(scopes)
+}
+
+// filterCompletions keeps the candidates starting with toComplete
+func filterCompletions(candidates []string, toComplete string) []string {
+	var out []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, toComplete) {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// uniqueSorted drops empty and duplicate names and sorts the rest
+func uniqueSorted(names []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, n := range names {
+		if n != "" && !seen[n] {
+			seen[n] = true
+			out = append(out, n)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dmoose/checkpoint/pkg/config"
+)
+
+func TestChangelogScopes(t *testing.T) {
+	dir := t.TempDir()
+	content := `---
+schema_version: "1"
+document_type: meta
+---
+schema_version: "1"
+timestamp: "2025-01-01T00:00:00Z"
+changes:
+  - summary: One
+    change_type: fix
+    scope: cli
+  - summary: Two
+    change_type: docs
+next_steps:
+  - summary: Three
+    scope: api
+---
+schema_version: "1"
+timestamp: "2025-01-02T00:00:00Z"
+changes:
+  - summary: Four
+    change_type: fix
+    scope: cli
+`
+	if err := os.WriteFile(filepath.Join(dir, config.ChangelogFileName), []byte(content), 0644); err != nil {
+		t.Fatalf("write changelog: %v", err)
+	}
+
+	if got := changelogScopes(dir); !reflect.DeepEqual(got, []string{"api", "cli"}) {
+		t.Errorf("changelogScopes = %v, want [api cli]", got)
+	}
+	if got := filterCompletions(changelogScopes(dir), "c"); !reflect.DeepEqual(got, []string{"cli"}) {
+		t.Errorf("filterCompletions = %v, want [cli]", got)
+	}
+	if got := changelogScopes(t.TempDir()); got != nil {
+		t.Errorf("expected no scopes without a changelog, got %v", got)
+	}
+}
+
+func TestKnownSkillNames(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	skillsDir := filepath.Join(dir, config.CheckpointDir, config.SkillsDir)
+	for _, name := range []string{"deploy", "build"} {
+		if err := os.MkdirAll(filepath.Join(skillsDir, name), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(skillsDir, name, "skill.md"), []byte("# "+name), 0644); err != nil {
+			t.Fatalf("write skill: %v", err)
+		}
+	}
+	// A directory without skill.md is not a skill
+	if err := os.MkdirAll(filepath.Join(skillsDir, "draft"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	if got := knownSkillNames(dir); !reflect.DeepEqual(got, []string{"build", "deploy"}) {
+		t.Errorf("knownSkillNames = %v, want [build deploy]", got)
+	}
+	if got := addableSkillNames(dir); got != nil {
+		t.Errorf("expected no addable skills without global skills, got %v", got)
+	}
+}
+
+func TestPwshCompletion(t *testing.T) {
+	var pwsh, powershell bytes.Buffer
+	if err := writeCompletion("pwsh", &pwsh); err != nil {
+		t.Fatalf("writeCompletion(pwsh): %v", err)
+	}
+	if err := writeCompletion("powershell", &powershell); err != nil {
+		t.Fatalf("writeCompletion(powershell): %v", err)
+	}
+	if pwsh.String() != powershell.String() {
+		t.Error("pwsh and powershell should generate the same script")
+	}
+	if !strings.Contains(pwsh.String(), "Register-ArgumentCompleter") {
+		t.Errorf("expected a PowerShell completion script, got:\n%.200s", pwsh.String())
+	}
+	if err := writeCompletion("tcsh", &bytes.Buffer{}); err == nil {
+		t.Error("expected error for unsupported shell")
+	}
+
+	t.Setenv("SHELL", "/usr/local/bin/pwsh")
+	if got := detectShell(); got != "pwsh" {
+		t.Errorf("detectShell() = %q, want pwsh", got)
+	}
+	home := filepath.Join("home", "user")
+	if got, want := pwshCompletionPath(home, "linux"), filepath.Join(home, ".config", "powershell", "checkpoint.ps1"); got != want {
+		t.Errorf("pwshCompletionPath(linux) = %q, want %q", got, want)
+	}
+	if got, want := pwshCompletionPath(home, "windows"), filepath.Join(home, "Documents", "PowerShell", "checkpoint.ps1"); got != want {
+		t.Errorf("pwshCompletionPath(windows) = %q, want %q", got, want)
+	}
+}
@@ -1,12 +1,17 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/dmoose/checkpoint/internal/file"
+	"github.com/dmoose/checkpoint/internal/userconfig"
 	"github.com/dmoose/checkpoint/pkg/config"
 
 	"github.com/spf13/cobra"
@@ -18,6 +23,29 @@ func init() {
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configSetCmd)
 	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configExportCmd)
+	configCmd.AddCommand(configImportCmd)
+	configGetCmd.Flags().BoolVar(&configGlobalOpts.get, "global", false, "Read a key from the user config (~/.config/checkpoint/config.json)")
+	configSetCmd.Flags().BoolVar(&configGlobalOpts.set, "global", false, "Write a key to the user config (~/.config/checkpoint/config.json)")
+	configExportCmd.Flags().StringVarP(&configExportOpts.output, "output", "o", "", "Write bundle to file instead of stdout")
+	configExportCmd.Flags().BoolVar(&configExportOpts.skills, "skills", false, "Include local skill files from .checkpoint/skills/")
+	configImportCmd.Flags().BoolVar(&configImportOpts.merge, "merge", false, "Merge into existing files (existing values win, lists are combined)")
+	configImportCmd.Flags().BoolVar(&configImportOpts.overwrite, "overwrite", false, "Replace existing files")
+}
+
+var configGlobalOpts struct {
+	get bool
+	set bool
+}
+
+var configExportOpts struct {
+	output string
+	skills bool
+}
+
+var configImportOpts struct {
+	merge     bool
+	overwrite bool
 }
 
 var configCmd = &cobra.Command{
@@ -28,20 +56,39 @@ var configCmd = &cobra.Command{
 Subcommands:
   get <file>                 Read a config file as JSON
   set <file> <path> <value>  Update a config value
-  list                       List available config files`,
+  list                       List available config files
+  export                     Bundle config files for another repo
+  import <bundle>            Unpack a config bundle into this repo`,
 }
 
 var configGetCmd = &cobra.Command{
-	Use:   "get <file>",
+	Use:   "get <file> | --global [key]",
 	Short: "Read a config file as JSON",
 	Long: `Read any .checkpoint/*.yml file and output as JSON.
 
+With --global, read the user config ~/.config/checkpoint/config.json instead:
+one key, or the whole file without a key.` + userConfigKeysHelp() + `
+
 Examples:
   checkpoint config get project.yml
   checkpoint config get tools.yml
-  checkpoint config get guidelines.yml`,
-	Args: cobra.ExactArgs(1),
+  checkpoint config get guidelines.yml
+  checkpoint config get --global roots`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if configGlobalOpts.get {
+			return cobra.MaximumNArgs(1)(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
+		if configGlobalOpts.get {
+			key := ""
+			if len(args) > 0 {
+				key = args[0]
+			}
+			userConfigGet(key)
+			return
+		}
 		projectPath := "."
 		absPath, err := filepath.Abs(projectPath)
 		if err != nil {
@@ -53,16 +100,31 @@ Examples:
 }
 
 var configSetCmd = &cobra.Command{
-	Use:   "set <file> <path> <value>",
+	Use:   "set <file> <path> <value> | --global <key> <value>",
 	Short: "Update a config value",
 	Long: `Update a configuration value using dot-notation path.
 
+With --global, set a key in the user config ~/.config/checkpoint/config.json,
+which holds defaults for every project. Only known keys are accepted; other
+keys already in the file are kept.` + userConfigKeysHelp() + `
+
 Examples:
   checkpoint config set project.yml name "My Project"
   checkpoint config set tools.yml build.default.command "make build"
-  checkpoint config set guidelines.yml rules[0] "New rule"`,
-	Args: cobra.ExactArgs(3),
+  checkpoint config set guidelines.yml rules[0] "New rule"
+  checkpoint config set --global roots ~/src,~/work
+  checkpoint config set --global conventional_commits true`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if configGlobalOpts.set {
+			return cobra.ExactArgs(2)(cmd, args)
+		}
+		return cobra.ExactArgs(3)(cmd, args)
+	},
 	Run: func(cmd *cobra.Command, args []string) {
+		if configGlobalOpts.set {
+			userConfigSet(args[0], args[1])
+			return
+		}
 		projectPath := "."
 		absPath, err := filepath.Abs(projectPath)
 		if err != nil {
@@ -88,6 +150,58 @@ var configListCmd = &cobra.Command{
 	},
 }
 
+var configExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Bundle config files for another repo",
+	Long: `Package project, tools, guidelines and skills config into a single
+YAML bundle that can be imported into a sister project.
+
+Examples:
+  checkpoint config export --output config-bundle.yaml
+  checkpoint config export --skills -o config-bundle.yaml`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		absPath, err := filepath.Abs(".")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: cannot resolve path: %v\n", err)
+			os.Exit(1)
+		}
+		configExport(absPath, configExportOpts.output, configExportOpts.skills)
+	},
+}
+
+var configImportCmd = &cobra.Command{
+	Use:   "import <bundle>",
+	Short: "Unpack a config bundle into this repo",
+	Long: `Unpack a bundle created by 'checkpoint config export' into .checkpoint/.
+
+By default existing files are left untouched. Use --merge to add missing
+keys and list items to existing YAML files, or --overwrite to replace them.
+
+Examples:
+  checkpoint config import config-bundle.yaml
+  checkpoint config import config-bundle.yaml --merge`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		absPath, err := filepath.Abs(".")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: cannot resolve path: %v\n", err)
+			os.Exit(1)
+		}
+		if configImportOpts.merge && configImportOpts.overwrite {
+			fmt.Fprintf(os.Stderr, "error: --merge and --overwrite are mutually exclusive\n")
+			os.Exit(1)
+		}
+		mode := importSkip
+		if configImportOpts.merge {
+			mode = importMerge
+		} else if configImportOpts.overwrite {
+			mode = importOverwrite
+		}
+		configImport(absPath, args[0], mode)
+	},
+}
+
 func configGet(projectPath string, filename string) {
 	// Resolve file path
 	var filePath string
@@ -121,6 +235,51 @@ func configGet(projectPath string, filename string) {
 	fmt.Println(string(jsonData))
 }
 
+// userConfigKeysHelp lists the user config keys for help text
+func userConfigKeysHelp() string {
+	var sb strings.Builder
+	sb.WriteString("\n\nUser config keys:\n")
+	for _, k := range userconfig.Keys {
+		fmt.Fprintf(&sb, "  %-22s %s (%s)\n", k.Name, k.Description, k.Kind)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
+// userConfigGet prints one user config key as JSON, or the whole file when
+// key is empty
+func userConfigGet(key string) {
+	var value any
+	var err error
+	if key == "" {
+		value, err = userconfig.All()
+	} else {
+		value, err = userconfig.Get(key)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	if value == nil {
+		path, _ := userconfig.Path()
+		fmt.Fprintf(os.Stderr, "error: %s is not set in %s\n", key, path)
+		fmt.Fprintf(os.Stderr, "hint: Run 'checkpoint config set --global %s <value>'\n", key)
+		os.Exit(1)
+	}
+	writeJSON(value)
+}
+
+// userConfigSet writes one known key to the user config
+func userConfigSet(key, value string) {
+	parsed, err := userconfig.Set(key, value)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	path, _ := userconfig.Path()
+	data, _ := json.Marshal(parsed)
+	fmt.Printf("✓ Set %s = %s in %s\n", key, data, path)
+}
+
 func configSet(projectPath string, filename string, path string, value string) {
 	// Resolve file path
 	var filePath string
@@ -292,3 +451,296 @@ func configList(projectPath string) {
 	fmt.Println("  checkpoint config get <file>              Read as JSON")
 	fmt.Println("  checkpoint config set <file> <path> <v>   Update value")
 }
+
+// configBundle is the export format: file contents keyed by path relative to .checkpoint/
+type configBundle struct {
+	SchemaVersion string            `yaml:"schema_version"`
+	Project       string            `yaml:"project,omitempty"`
+	ExportedAt    string            `yaml:"exported_at"`
+	Files         map[string]string `yaml:"files"`
+}
+
+const configBundleVersion = "1"
+
+// bundledConfigFiles lists the config files exported, as primary/legacy name pairs
+var bundledConfigFiles = [][2]string{
+	{config.ExplainProjectYaml, config.ExplainProjectYmlLegacy},
+	{config.ExplainToolsYaml, config.ExplainToolsYmlLegacy},
+	{config.ExplainGuidelinesYaml, config.ExplainGuidelinesYmlLegacy},
+	{config.ExplainSkillsYaml, config.ExplainSkillsYmlLegacy},
+}
+
+type importMode int
+
+const (
+	importSkip importMode = iota
+	importMerge
+	importOverwrite
+)
+
+// buildConfigBundle reads the project's config files (and optionally local skills) into a bundle
+func buildConfigBundle(projectPath string, includeSkills bool) (*configBundle, error) {
+	checkpointDir := filepath.Join(projectPath, config.CheckpointDir)
+	bundle := &configBundle{
+		SchemaVersion: configBundleVersion,
+		Project:       filepath.Base(projectPath),
+		ExportedAt:    time.Now().Format(time.RFC3339),
+		Files:         make(map[string]string),
+	}
+
+	for _, names := range bundledConfigFiles {
+		path := file.FindWithFallback(filepath.Join(checkpointDir, names[0]), filepath.Join(checkpointDir, names[1]))
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue // optional
+		}
+		bundle.Files[names[0]] = string(data)
+	}
+
+	if includeSkills {
+		skillsDir := filepath.Join(checkpointDir, config.SkillsDir)
+		err := filepath.WalkDir(skillsDir, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(checkpointDir, path)
+			if err != nil {
+				return err
+			}
+			bundle.Files[filepath.ToSlash(rel)] = string(data)
+			return nil
+		})
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("read skills: %w", err)
+		}
+	}
+
+	if len(bundle.Files) == 0 {
+		return nil, fmt.Errorf("no config files found in %s", config.CheckpointDir)
+	}
+	return bundle, nil
+}
+
+// applyConfigBundle writes bundle files into .checkpoint/ and returns one status line per file
+func applyConfigBundle(projectPath string, bundle *configBundle, mode importMode) ([]string, error) {
+	checkpointDir := filepath.Join(projectPath, config.CheckpointDir)
+
+	names := make([]string, 0, len(bundle.Files))
+	for name := range bundle.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var report []string
+	for _, name := range names {
+		rel := filepath.FromSlash(name)
+		if filepath.IsAbs(rel) || strings.HasPrefix(filepath.Clean(rel), "..") {
+			return report, fmt.Errorf("bundle entry %q escapes %s", name, config.CheckpointDir)
+		}
+		target := filepath.Join(checkpointDir, rel)
+		// Keep writing to a legacy .yml file when that is what the project uses
+		for _, names := range bundledConfigFiles {
+			if name == names[0] {
+				target = file.FindWithFallback(target, filepath.Join(checkpointDir, names[1]))
+			}
+		}
+		content := bundle.Files[name]
+		status := "created"
+
+		if file.Exists(target) {
+			switch mode {
+			case importSkip:
+				report = append(report, fmt.Sprintf("skipped %s (exists)", name))
+				continue
+			case importMerge:
+				if !isYAMLFile(target) {
+					report = append(report, fmt.Sprintf("skipped %s (exists, not mergeable)", name))
+					continue
+				}
+				existing, err := os.ReadFile(target)
+				if err != nil {
+					return report, fmt.Errorf("read %s: %w", name, err)
+				}
+				merged, err := mergeYAMLContent(existing, []byte(content))
+				if err != nil {
+					return report, fmt.Errorf("merge %s: %w", name, err)
+				}
+				content = string(merged)
+				status = "merged"
+			case importOverwrite:
+				status = "overwrote"
+			}
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return report, fmt.Errorf("create directory for %s: %w", name, err)
+		}
+		if err := os.WriteFile(target, []byte(content), 0644); err != nil {
+			return report, fmt.Errorf("write %s: %w", name, err)
+		}
+		report = append(report, fmt.Sprintf("%s %s", status, name))
+	}
+	return report, nil
+}
+
+func isYAMLFile(path string) bool {
+	return strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml")
+}
+
+// mergeYAMLContent merges incoming into existing: existing scalars win, maps
+// merge recursively and lists gain incoming items they don't already contain.
+// It works on yaml.Node so the existing file keeps its comments and key order.
+func mergeYAMLContent(existing, incoming []byte) ([]byte, error) {
+	var base, add yaml.Node
+	if err := yaml.Unmarshal(existing, &base); err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(incoming, &add); err != nil {
+		return nil, err
+	}
+	if len(add.Content) == 0 {
+		return existing, nil
+	}
+	if len(base.Content) == 0 {
+		return incoming, nil
+	}
+	baseRoot, addRoot := base.Content[0], add.Content[0]
+	if baseRoot.Kind != yaml.MappingNode || addRoot.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("top level must be a mapping")
+	}
+	if err := mergeMappingNodes(baseRoot, addRoot); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&base); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func mergeMappingNodes(base, add *yaml.Node) error {
+	for i := 0; i+1 < len(add.Content); i += 2 {
+		key, val := add.Content[i], add.Content[i+1]
+		cur := mappingValue(base, key.Value)
+		if cur == nil {
+			base.Content = append(base.Content, key, val)
+			continue
+		}
+		switch {
+		case cur.Kind == yaml.MappingNode && val.Kind == yaml.MappingNode:
+			if err := mergeMappingNodes(cur, val); err != nil {
+				return err
+			}
+		case cur.Kind == yaml.SequenceNode && val.Kind == yaml.SequenceNode:
+			if err := mergeSequenceNodes(cur, val); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func mergeSequenceNodes(base, add *yaml.Node) error {
+	seen := make(map[string]bool)
+	for _, item := range base.Content {
+		key, err := nodeKey(item)
+		if err != nil {
+			return err
+		}
+		seen[key] = true
+	}
+	for _, item := range add.Content {
+		key, err := nodeKey(item)
+		if err != nil {
+			return err
+		}
+		if !seen[key] {
+			seen[key] = true
+			base.Content = append(base.Content, item)
+		}
+	}
+	return nil
+}
+
+// nodeKey identifies a list item by its decoded value, ignoring comments and style
+func nodeKey(n *yaml.Node) (string, error) {
+	var v any
+	if err := n.Decode(&v); err != nil {
+		return "", err
+	}
+	return fmt.Sprint(v), nil
+}
+
+func configExport(projectPath string, output string, includeSkills bool) {
+	bundle, err := buildConfigBundle(projectPath, includeSkills)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "hint: Run 'checkpoint init' to create config files\n")
+		os.Exit(1)
+	}
+
+	data, err := yaml.Marshal(bundle)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: cannot serialize bundle: %v\n", err)
+		os.Exit(1)
+	}
+
+	if output == "" {
+		fmt.Print(string(data))
+		return
+	}
+	if err := os.WriteFile(output, data, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "error: cannot write %s: %v\n", output, err)
+		os.Exit(1)
+	}
+	fmt.Printf("✓ Exported %d files to %s\n", len(bundle.Files), output)
+}
+
+func configImport(projectPath string, bundlePath string, mode importMode) {
+	data, err := os.ReadFile(bundlePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: cannot read %s: %v\n", bundlePath, err)
+		os.Exit(1)
+	}
+
+	var bundle configBundle
+	if err := yaml.Unmarshal(data, &bundle); err != nil {
+		fmt.Fprintf(os.Stderr, "error: cannot parse %s: %v\n", bundlePath, err)
+		os.Exit(1)
+	}
+	if bundle.SchemaVersion != configBundleVersion || len(bundle.Files) == 0 {
+		fmt.Fprintf(os.Stderr, "error: %s is not a checkpoint config bundle\n", bundlePath)
+		fmt.Fprintf(os.Stderr, "hint: Create one with 'checkpoint config export --output %s'\n", bundlePath)
+		os.Exit(1)
+	}
+
+	report, err := applyConfigBundle(projectPath, &bundle, mode)
+	for _, line := range report {
+		fmt.Printf("  %s\n", line)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("✓ Imported config bundle from %s\n", bundlePath)
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigBundleRoundTrip(t *testing.T) {
+	srcDir, err := os.MkdirTemp("", "checkpoint-bundle-src")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(srcDir) }()
+	dstDir, err := os.MkdirTemp("", "checkpoint-bundle-dst")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dstDir) }()
+
+	write := func(dir, rel, content string) {
+		path := filepath.Join(dir, ".checkpoint", rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(srcDir, "guidelines.yaml", "rules:\n  - shared rule\nnaming:\n  files: snake_case\n")
+	write(srcDir, "tools.yml", "build:\n  default:\n    command: make\n")
+	write(srcDir, "skills/review/skill.md", "# Review\n")
+	write(dstDir, "guidelines.yml", "rules:\n  - local rule\nnaming:\n  files: kebab-case\n")
+
+	bundle, err := buildConfigBundle(srcDir, true)
+	if err != nil {
+		t.Fatalf("buildConfigBundle: %v", err)
+	}
+	for _, name := range []string{"guidelines.yaml", "tools.yaml", "skills/review/skill.md"} {
+		if _, ok := bundle.Files[name]; !ok {
+			t.Errorf("bundle missing %s", name)
+		}
+	}
+
+	// Default mode leaves existing files alone
+	if _, err := applyConfigBundle(dstDir, bundle, importSkip); err != nil {
+		t.Fatalf("apply skip: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dstDir, ".checkpoint", "guidelines.yml"))
+	if strings.Contains(string(data), "shared rule") {
+		t.Error("skip mode modified existing guidelines")
+	}
+	if _, err := os.Stat(filepath.Join(dstDir, ".checkpoint", "skills", "review", "skill.md")); err != nil {
+		t.Errorf("skill file not recreated: %v", err)
+	}
+
+	// Merge keeps existing values and combines lists, writing to the legacy file
+	if _, err := applyConfigBundle(dstDir, bundle, importMerge); err != nil {
+		t.Fatalf("apply merge: %v", err)
+	}
+	data, _ = os.ReadFile(filepath.Join(dstDir, ".checkpoint", "guidelines.yml"))
+	got := string(data)
+	for _, want := range []string{"local rule", "shared rule", "kebab-case"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("merged guidelines missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "snake_case") {
+		t.Errorf("merge overrode existing scalar:\n%s", got)
+	}
+	if _, err := os.Stat(filepath.Join(dstDir, ".checkpoint", "guidelines.yaml")); err == nil {
+		t.Error("merge created guidelines.yaml alongside legacy guidelines.yml")
+	}
+
+	// Overwrite replaces the file
+	if _, err := applyConfigBundle(dstDir, bundle, importOverwrite); err != nil {
+		t.Fatalf("apply overwrite: %v", err)
+	}
+	data, _ = os.ReadFile(filepath.Join(dstDir, ".checkpoint", "guidelines.yml"))
+	if strings.Contains(string(data), "local rule") {
+		t.Error("overwrite kept existing content")
+	}
+}
+
+func TestApplyConfigBundleRejectsEscapingPaths(t *testing.T) {
+	dir, err := os.MkdirTemp("", "checkpoint-bundle-escape")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	bundle := &configBundle{SchemaVersion: configBundleVersion, Files: map[string]string{"../evil.yaml": "x: 1\n"}}
+	if _, err := applyConfigBundle(dir, bundle, importOverwrite); err == nil {
+		t.Error("expected error for path outside .checkpoint/")
+	}
+}
+
+func TestMergeYAMLContentKeepsComments(t *testing.T) {
+	existing := `# Project guidelines
+naming:
+  files: kebab-case # local convention
+rules:
+  # Team rules
+  - local rule
+avoid:
+  - globals
+`
+	incoming := `rules:
+  - shared rule
+  - local rule
+naming:
+  files: snake_case
+  types: PascalCase
+principles:
+  - keep it simple
+`
+	merged, err := mergeYAMLContent([]byte(existing), []byte(incoming))
+	if err != nil {
+		t.Fatalf("mergeYAMLContent: %v", err)
+	}
+	got := string(merged)
+	for _, want := range []string{"# Project guidelines", "# local convention", "# Team rules", "shared rule", "types: PascalCase", "keep it simple"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("merged YAML missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "snake_case") {
+		t.Errorf("merge overrode existing scalar:\n%s", got)
+	}
+	if strings.Count(got, "local rule") != 1 {
+		t.Errorf("duplicate list item after merge:\n%s", got)
+	}
+	// Existing keys keep their order; new keys follow
+	if !(strings.Index(got, "naming:") < strings.Index(got, "rules:") &&
+		strings.Index(got, "rules:") < strings.Index(got, "avoid:") &&
+		strings.Index(got, "avoid:") < strings.Index(got, "principles:")) {
+		t.Errorf("merge reordered keys:\n%s", got)
+	}
+}
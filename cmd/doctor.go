@@ -1,14 +1,21 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/dmoose/checkpoint/internal/changelog"
 	"github.com/dmoose/checkpoint/internal/detect"
 	"github.com/dmoose/checkpoint/internal/explain"
 	"github.com/dmoose/checkpoint/internal/file"
+	"github.com/dmoose/checkpoint/internal/schema"
 	"github.com/dmoose/checkpoint/pkg/config"
 
 	"github.com/spf13/cobra"
@@ -18,19 +25,36 @@ import (
 var doctorOpts struct {
 	fix     bool
 	verbose bool
+	since   string
+	json    bool
 }
 
 func init() {
 	rootCmd.AddCommand(doctorCmd)
 	doctorCmd.Flags().BoolVar(&doctorOpts.fix, "fix", false, "Auto-fix issues where possible")
 	doctorCmd.Flags().BoolVarP(&doctorOpts.verbose, "verbose", "v", false, "Show detected project info")
+	doctorCmd.Flags().BoolVar(&doctorOpts.json, "json", false, "Output checks and summary as JSON (exit status 1 on errors or missing items)")
+	doctorCmd.Flags().StringVar(&doctorOpts.since, "since", "", "Warn when the changelog was created by a checkpoint version older than this")
 }
 
 var doctorCmd = &cobra.Command{
 	Use:   "doctor [path]",
 	Short: "Check project setup and suggest fixes",
-	Long:  `Validates configuration, detects missing tools, suggests commands.`,
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Validates configuration, detects missing tools, suggests commands.
+
+The changelog's meta tool_version is compared with this binary's version;
+a project created by an older major (or 0.x minor) release is flagged.
+Use --since <version> to set the oldest acceptable version explicitly.
+
+--fix applies the fixes that are safe to automate: appending missing
+.gitignore entries, creating .checkpoint/, filling empty build/test/lint
+groups in tools.yaml with detected commands, and others marked fixable.
+Anything else still prints its suggested command.
+
+--json prints the checks, summary counts and (with --verbose) detected
+project info as JSON for CI. It exits 1 when any check is an error or
+missing, matching the report's exit_status.`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		projectPath := "."
 		if len(args) > 0 {
@@ -41,61 +65,76 @@ var doctorCmd = &cobra.Command{
 			fmt.Fprintf(os.Stderr, "error: cannot resolve path: %v\n", err)
 			os.Exit(1)
 		}
-		Doctor(absPath, DoctorOptions{Fix: doctorOpts.fix, Verbose: doctorOpts.verbose})
+		Doctor(absPath, DoctorOptions{Fix: doctorOpts.fix, Verbose: doctorOpts.verbose, Since: doctorOpts.since, JSON: doctorOpts.json})
 	},
 }
 
 // DoctorOptions holds flags for the doctor command
 type DoctorOptions struct {
-	Fix     bool // --fix flag to auto-fix issues
-	Verbose bool // --verbose flag for more detail
+	Fix     bool   // --fix flag to auto-fix issues
+	Verbose bool   // --verbose flag for more detail
+	Since   string // --since: oldest acceptable changelog tool_version
+	JSON    bool   // --json flag for machine-readable output
 }
 
 // CheckResult represents the result of a single check
 type CheckResult struct {
-	Name    string
-	Status  string // ok, warning, error, missing
-	Message string
-	Fix     string // Suggested fix command
-	AutoFix bool   // Can be auto-fixed
+	Name    string `json:"name"`
+	Status  string `json:"status"` // ok, warning, error, missing
+	Message string `json:"message"`
+	Fix     string `json:"fix,omitempty"` // Suggested fix command
+	AutoFix bool   `json:"auto_fix"`      // Can be auto-fixed
+
+	// Apply performs the auto-fix and returns a description of what changed
+	Apply func() (string, error) `json:"-"`
+
+	// Outcome of Apply under --fix (reported in JSON output)
+	Fixed    string `json:"fixed,omitempty"`
+	FixError string `json:"fix_error,omitempty"`
+
+	// Details holds check-specific data for --json output
+	Details interface{} `json:"details,omitempty"`
+}
+
+// DoctorSummary counts check results by status
+type DoctorSummary struct {
+	OK      int `json:"ok"`
+	Warning int `json:"warning"`
+	Error   int `json:"error"`
+	Missing int `json:"missing"`
+}
+
+// DoctorReport is the --json output of doctor
+type DoctorReport struct {
+	Checks     []CheckResult       `json:"checks"`
+	Summary    DoctorSummary       `json:"summary"`
+	ExitStatus int                 `json:"exit_status"`       // 1 when any check is an error or missing
+	Project    *detect.ProjectInfo `json:"project,omitempty"` // with --verbose
 }
 
 // Doctor validates project setup and suggests fixes
 func Doctor(projectPath string, opts DoctorOptions) {
+	results := runDoctorChecks(projectPath, opts)
+	if opts.JSON {
+		report := buildDoctorReport(projectPath, results, opts)
+		writeJSON(report)
+		if report.ExitStatus != 0 {
+			os.Exit(report.ExitStatus)
+		}
+		return
+	}
+
 	fmt.Println("Checkpoint Doctor")
 	fmt.Println("=================")
 	fmt.Println()
 
-	results := []CheckResult{}
-
-	// Run all checks
-	results = append(results, checkGitRepo(projectPath))
-	results = append(results, checkGitignore(projectPath))
-	results = append(results, checkCheckpointDir(projectPath))
-	results = append(results, checkProjectYml(projectPath))
-	results = append(results, checkToolsYml(projectPath))
-	results = append(results, checkGuidelinesYml(projectPath))
-	results = append(results, checkChangelog(projectPath))
-	results = append(results, checkSkills(projectPath))
-
-	// Count results
-	okCount := 0
-	warnCount := 0
-	errCount := 0
-	missingCount := 0
-
-	for _, r := range results {
-		switch r.Status {
-		case "ok":
-			okCount++
-		case "warning":
-			warnCount++
-		case "error":
-			errCount++
-		case "missing":
-			missingCount++
-		}
-	}
+	summary := summarizeChecks(results)
+	okCount := summary.OK
+	warnCount := summary.Warning
+	errCount := summary.Error
+	missingCount := summary.Missing
+	fixedCount := 0
+	manualCount := 0
 
 	// Display results
 	for _, r := range results {
@@ -103,11 +142,22 @@ func Doctor(projectPath string, opts DoctorOptions) {
 		fmt.Printf("%s %s: %s\n", icon, r.Name, r.Message)
 
 		if r.Fix != "" && (r.Status == "error" || r.Status == "missing" || r.Status == "warning") {
-			if opts.Fix && r.AutoFix {
+			if opts.Fix && r.AutoFix && r.Apply != nil {
 				fmt.Printf("   -> Auto-fixing...\n")
-				// Auto-fix would go here
+				changed, err := r.Apply()
+				if err != nil {
+					fmt.Printf("   fix failed: %v\n", err)
+					fmt.Printf("   fix: %s\n", r.Fix)
+					manualCount++
+				} else {
+					for _, line := range strings.Split(strings.TrimRight(changed, "\n"), "\n") {
+						fmt.Printf("   ✓ %s\n", line)
+					}
+					fixedCount++
+				}
 			} else {
 				fmt.Printf("   fix: %s\n", r.Fix)
+				manualCount++
 			}
 		}
 	}
@@ -117,6 +167,12 @@ func Doctor(projectPath string, opts DoctorOptions) {
 	fmt.Println("Summary")
 	fmt.Println("-------")
 	fmt.Printf("  %d passed, %d warnings, %d errors, %d missing\n", okCount, warnCount, errCount, missingCount)
+	if opts.Fix {
+		fmt.Printf("  %d fixed, %d need manual action\n", fixedCount, manualCount)
+		if fixedCount > 0 {
+			fmt.Println("  Run checkpoint doctor again to confirm the fixes.")
+		}
+	}
 
 	if errCount > 0 || missingCount > 0 {
 		fmt.Println()
@@ -142,6 +198,12 @@ func Doctor(projectPath string, opts DoctorOptions) {
 		if len(info.Languages) > 1 {
 			fmt.Printf("  Also:     %s\n", strings.Join(info.Languages[1:], ", "))
 		}
+		if len(info.GoWorkspaceModules) > 0 {
+			fmt.Printf("  Go workspace modules: %s\n", strings.Join(info.GoWorkspaceModules, ", "))
+		}
+		if len(info.Workspaces) > 0 {
+			fmt.Printf("  Workspaces: %s\n", strings.Join(info.Workspaces, ", "))
+		}
 		if info.BuildCmd != "" {
 			fmt.Printf("  Build:    %s\n", info.BuildCmd)
 		}
@@ -157,6 +219,94 @@ func Doctor(projectPath string, opts DoctorOptions) {
 	}
 }
 
+// runDoctorChecks runs every check applicable to the project
+func runDoctorChecks(projectPath string, opts DoctorOptions) []CheckResult {
+	results := []CheckResult{}
+	results = append(results, checkGitRepo(projectPath))
+	results = append(results, checkGitignore(projectPath))
+	bare := isBareSetup(projectPath)
+	if bare {
+		// Bare setups (init --bare) have no .checkpoint/ config by design
+		results = append(results, CheckResult{
+			Name:    "Setup",
+			Status:  "ok",
+			Message: "Bare setup (changelog only); run checkpoint init to add project config",
+		})
+	} else {
+		results = append(results, checkCheckpointDir(projectPath))
+		if file.Exists(filepath.Join(projectPath, config.CheckpointDir)) {
+			results = append(results, checkCheckpointGitignore(projectPath))
+		}
+		results = append(results, checkProjectYml(projectPath))
+		results = append(results, checkToolsYml(projectPath))
+		results = append(results, checkToolPrecedence(projectPath))
+		results = append(results, checkGuidelinesYml(projectPath))
+	}
+	results = append(results, checkChangelog(projectPath))
+	results = append(results, checkFileEncoding(projectPath))
+	if _, found := findInputFile(projectPath); found {
+		results = append(results, checkInputDiffFile(projectPath))
+	}
+	if file.Exists(filepath.Join(projectPath, config.ChangelogFileName)) {
+		results = append(results, checkChangelogSize(projectPath))
+		results = append(results, checkChangelogOrder(projectPath))
+		results = append(results, checkSchemaVersions(projectPath))
+		results = append(results, checkToolVersion(projectPath, Version, opts.Since))
+		results = append(results, checkPathHash(projectPath))
+	}
+	if !bare {
+		results = append(results, checkSkills(projectPath))
+		results = append(results, checkOrphanedSkills(projectPath))
+		results = append(results, checkSkillContent(projectPath))
+	}
+	return results
+}
+
+// summarizeChecks counts results by status
+func summarizeChecks(results []CheckResult) DoctorSummary {
+	var summary DoctorSummary
+	for _, r := range results {
+		switch r.Status {
+		case "ok":
+			summary.OK++
+		case "warning":
+			summary.Warning++
+		case "error":
+			summary.Error++
+		case "missing":
+			summary.Missing++
+		}
+	}
+	return summary
+}
+
+// buildDoctorReport assembles the --json report, applying auto-fixes when
+// --fix is set and recording their outcome on each check
+func buildDoctorReport(projectPath string, results []CheckResult, opts DoctorOptions) DoctorReport {
+	if opts.Fix {
+		for i, r := range results {
+			if r.Status == "ok" || !r.AutoFix || r.Apply == nil {
+				continue
+			}
+			changed, err := r.Apply()
+			if err != nil {
+				results[i].FixError = err.Error()
+			} else {
+				results[i].Fixed = strings.TrimRight(changed, "\n")
+			}
+		}
+	}
+
+	report := DoctorReport{Checks: results, Summary: summarizeChecks(results)}
+	if report.Summary.Error > 0 || report.Summary.Missing > 0 {
+		report.ExitStatus = 1
+	}
+	if opts.Verbose {
+		report.Project = detect.DetectProject(projectPath)
+	}
+	return report
+}
+
 func getStatusIcon(status string) string {
 	switch status {
 	case "ok":
@@ -191,6 +341,7 @@ func checkGitRepo(projectPath string) CheckResult {
 
 func checkGitignore(projectPath string) CheckResult {
 	gitignorePath := filepath.Join(projectPath, ".gitignore")
+	requiredEntries := []string{".checkpoint-input", ".checkpoint-input.json", ".checkpoint-diff", ".checkpoint-lock", ".checkpoint-status.yaml"}
 	data, err := os.ReadFile(gitignorePath)
 	if err != nil {
 		return CheckResult{
@@ -198,25 +349,24 @@ func checkGitignore(projectPath string) CheckResult {
 			Status:  "warning",
 			Message: ".gitignore not found - artifacts may be tracked",
 			Fix:     "checkpoint init (will create/update .gitignore)",
+			AutoFix: true,
+			Apply: func() (string, error) {
+				return appendGitignoreEntries(gitignorePath, requiredEntries)
+			},
 		}
 	}
 
-	content := string(data)
-	requiredEntries := []string{".checkpoint-input", ".checkpoint-diff", ".checkpoint-lock", ".checkpoint-status.yaml"}
-	missing := []string{}
-
-	for _, entry := range requiredEntries {
-		if !strings.Contains(content, entry) {
-			missing = append(missing, entry)
-		}
-	}
-
+	missing := missingGitignoreEntries(string(data), requiredEntries)
 	if len(missing) > 0 {
 		return CheckResult{
 			Name:    "Gitignore",
 			Status:  "warning",
 			Message: fmt.Sprintf(".gitignore missing: %s", strings.Join(missing, ", ")),
 			Fix:     "checkpoint init (will update .gitignore)",
+			AutoFix: true,
+			Apply: func() (string, error) {
+				return appendGitignoreEntries(gitignorePath, missing)
+			},
 		}
 	}
 
@@ -227,6 +377,87 @@ func checkGitignore(projectPath string) CheckResult {
 	}
 }
 
+// checkCheckpointGitignore verifies .checkpoint/.gitignore keeps local state
+// files (learn history, skill usage) out of git
+func checkCheckpointGitignore(projectPath string) CheckResult {
+	gitignorePath := filepath.Join(projectPath, config.CheckpointDir, ".gitignore")
+	existing := ""
+	if data, err := os.ReadFile(gitignorePath); err == nil {
+		existing = string(data)
+	}
+	missing := missingGitignoreEntries(existing, checkpointDirIgnores)
+	if len(missing) > 0 {
+		return CheckResult{
+			Name:    "Checkpoint Gitignore",
+			Status:  "warning",
+			Message: fmt.Sprintf(".checkpoint/.gitignore missing: %s", strings.Join(missing, ", ")),
+			Fix:     "checkpoint doctor --fix",
+			AutoFix: true,
+			Apply: func() (string, error) {
+				return appendGitignoreEntries(gitignorePath, missing)
+			},
+		}
+	}
+	return CheckResult{
+		Name:    "Checkpoint Gitignore",
+		Status:  "ok",
+		Message: ".checkpoint/.gitignore ignores local state files",
+	}
+}
+
+// missingGitignoreEntries returns the entries that are not a line of the
+// .gitignore content. Lines match exactly (ignoring surrounding space and a
+// leading '/'), so .checkpoint-input does not count for .checkpoint-input.json.
+func missingGitignoreEntries(content string, entries []string) []string {
+	present := make(map[string]bool)
+	for _, line := range strings.Split(content, "\n") {
+		present[strings.TrimPrefix(strings.TrimSpace(line), "/")] = true
+	}
+	var missing []string
+	for _, entry := range entries {
+		if !present[entry] {
+			missing = append(missing, entry)
+		}
+	}
+	return missing
+}
+
+// appendGitignoreEntries appends checkpoint artifact entries to .gitignore,
+// creating it when absent
+func appendGitignoreEntries(gitignorePath string, entries []string) (string, error) {
+	existing := ""
+	if data, err := os.ReadFile(gitignorePath); err == nil {
+		existing = string(data)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(existing)
+	if existing != "" {
+		if !strings.HasSuffix(existing, "\n") {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("# Checkpoint artifacts (temporary files, not tracked)\n")
+	for _, entry := range entries {
+		sb.WriteString(entry + "\n")
+	}
+
+	if err := os.WriteFile(gitignorePath, []byte(sb.String()), 0644); err != nil {
+		return "", fmt.Errorf("write .gitignore: %w", err)
+	}
+	return fmt.Sprintf("added to .gitignore: %s", strings.Join(entries, ", ")), nil
+}
+
+// isBareSetup reports whether the project was initialized with init --bare:
+// a changelog exists but there is no .checkpoint/ directory
+func isBareSetup(projectPath string) bool {
+	if _, err := os.Stat(filepath.Join(projectPath, config.CheckpointDir)); err == nil {
+		return false
+	}
+	return file.Exists(filepath.Join(projectPath, config.ChangelogFileName))
+}
+
 func checkCheckpointDir(projectPath string) CheckResult {
 	checkpointPath := filepath.Join(projectPath, config.CheckpointDir)
 	if _, err := os.Stat(checkpointPath); err != nil {
@@ -235,7 +466,13 @@ func checkCheckpointDir(projectPath string) CheckResult {
 			Status:  "missing",
 			Message: ".checkpoint/ directory not found",
 			Fix:     "checkpoint init",
-			AutoFix: false,
+			AutoFix: true,
+			Apply: func() (string, error) {
+				if err := os.MkdirAll(checkpointPath, 0755); err != nil {
+					return "", fmt.Errorf("create %s: %w", config.CheckpointDir, err)
+				}
+				return fmt.Sprintf("created %s/ (run checkpoint init to add config files)", config.CheckpointDir), nil
+			},
 		}
 	}
 	return CheckResult{
@@ -326,9 +563,9 @@ func checkToolsYml(projectPath string) CheckResult {
 	}
 
 	// Check for essential commands
-	hasTest := len(tools.Test) > 0
-	hasBuild := len(tools.Build) > 0
-	hasLint := len(tools.Lint) > 0 || len(tools.Check) > 0
+	hasTest := hasToolCategory(&tools, "test")
+	hasBuild := hasToolCategory(&tools, "build")
+	hasLint := hasToolCategory(&tools, "lint")
 
 	missing := []string{}
 	if !hasTest {
@@ -346,13 +583,13 @@ func checkToolsYml(projectPath string) CheckResult {
 		info := detect.DetectProject(projectPath)
 		fixes := []string{}
 		if !hasTest && info.TestCmd != "" {
-			fixes = append(fixes, fmt.Sprintf("checkpoint learn --tool test '%s'", info.TestCmd))
+			fixes = append(fixes, fmt.Sprintf("checkpoint learn %s --tool-name test --category test", shellQuote(info.TestCmd)))
 		}
 		if !hasBuild && info.BuildCmd != "" {
-			fixes = append(fixes, fmt.Sprintf("checkpoint learn --tool build '%s'", info.BuildCmd))
+			fixes = append(fixes, fmt.Sprintf("checkpoint learn %s --tool-name build --category build", shellQuote(info.BuildCmd)))
 		}
 		if !hasLint && info.LintCmd != "" {
-			fixes = append(fixes, fmt.Sprintf("checkpoint learn --tool lint '%s'", info.LintCmd))
+			fixes = append(fixes, fmt.Sprintf("checkpoint learn %s --tool-name lint --category lint", shellQuote(info.LintCmd)))
 		}
 
 		fix := strings.Join(fixes, " && ")
@@ -365,6 +602,10 @@ func checkToolsYml(projectPath string) CheckResult {
 			Status:  "warning",
 			Message: fmt.Sprintf("tools.yml missing: %s", strings.Join(missing, ", ")),
 			Fix:     fix,
+			AutoFix: len(fixes) > 0,
+			Apply: func() (string, error) {
+				return addDetectedTools(toolsYamlPath, &tools, info)
+			},
 		}
 	}
 
@@ -378,6 +619,80 @@ func checkToolsYml(projectPath string) CheckResult {
 	}
 }
 
+// addDetectedTools fills the empty build/test/lint groups of tools.yml with
+// the commands detect.DetectProject found
+func addDetectedTools(toolsYamlPath string, tools *explain.ToolsConfig, info *detect.ProjectInfo) (string, error) {
+	added := []string{}
+	add := func(group *map[string]explain.ToolCommand, kind, command, notes string) {
+		if len(*group) > 0 || command == "" {
+			return
+		}
+		*group = map[string]explain.ToolCommand{"default": {Command: command, Notes: notes}}
+		added = append(added, fmt.Sprintf("%s: %s", kind, command))
+	}
+	add(&tools.Build, "build", info.BuildCmd, "Build the project")
+	add(&tools.Test, "test", info.TestCmd, "Run tests")
+	if len(tools.Check) == 0 {
+		add(&tools.Lint, "lint", info.LintCmd, "Run linter")
+	}
+	if len(added) == 0 {
+		return "", fmt.Errorf("no commands detected")
+	}
+
+	tools.SchemaVersion = "1"
+	if err := writeToolsFile(toolsYamlPath, tools); err != nil {
+		return "", err
+	}
+	return "added to tools.yml:\n" + strings.Join(added, "\n"), nil
+}
+
+// checkToolPrecedence reports overlapping check/lint defaults. The explain summary's
+// QUICK START advertises check.default and hides lint.default when both exist.
+func checkToolPrecedence(projectPath string) CheckResult {
+	toolsYamlPath := file.FindWithFallback(
+		filepath.Join(projectPath, config.CheckpointDir, config.ExplainToolsYaml),
+		filepath.Join(projectPath, config.CheckpointDir, config.ExplainToolsYmlLegacy),
+	)
+	var tools explain.ToolsConfig
+	data, err := os.ReadFile(toolsYamlPath)
+	if err == nil {
+		err = yaml.Unmarshal(data, &tools)
+	}
+	if err != nil {
+		return CheckResult{
+			Name:    "Tool Precedence",
+			Status:  "ok",
+			Message: "Skipped (tools config unavailable)",
+		}
+	}
+
+	check, hasCheck := tools.Check["default"]
+	lint, hasLint := tools.Lint["default"]
+	if !hasCheck || !hasLint {
+		return CheckResult{
+			Name:    "Tool Precedence",
+			Status:  "ok",
+			Message: "No overlapping default commands",
+		}
+	}
+
+	if strings.TrimSpace(check.Command) == strings.TrimSpace(lint.Command) {
+		return CheckResult{
+			Name:    "Tool Precedence",
+			Status:  "warning",
+			Message: fmt.Sprintf("check.default and lint.default are both '%s'", check.Command),
+			Fix:     "remove lint.default from tools.yaml; check.default is the one advertised",
+		}
+	}
+
+	return CheckResult{
+		Name:    "Tool Precedence",
+		Status:  "warning",
+		Message: fmt.Sprintf("check.default ('%s') takes precedence over lint.default ('%s') in explain summary", check.Command, lint.Command),
+		Fix:     "rename lint.default (e.g. lint.all) or fold it into check.default if it should be advertised",
+	}
+}
+
 func checkGuidelinesYml(projectPath string) CheckResult {
 	guidelinesYamlPath := file.FindWithFallback(
 		filepath.Join(projectPath, config.CheckpointDir, config.ExplainGuidelinesYaml),
@@ -454,6 +769,648 @@ func checkChangelog(projectPath string) CheckResult {
 	}
 }
 
+// checkInputDiffFile verifies the pending input's diff_file exists and is
+// non-empty; it goes stale when clean removes the diff or the path is edited
+func checkInputDiffFile(projectPath string) CheckResult {
+	inputPath, _ := findInputFile(projectPath)
+	inputName := filepath.Base(inputPath)
+	content, err := file.ReadFile(inputPath)
+	if err != nil {
+		return CheckResult{
+			Name:    "Input Diff File",
+			Status:  "warning",
+			Message: fmt.Sprintf("Cannot read %s: %v", inputName, err),
+		}
+	}
+	entry, err := schema.ParseInputFile(content)
+	if err != nil {
+		return CheckResult{
+			Name:    "Input Diff File",
+			Status:  "warning",
+			Message: fmt.Sprintf("Cannot parse %s: %v", inputName, err),
+			Fix:     "checkpoint lint",
+		}
+	}
+	if entry.DiffFile == "" {
+		return CheckResult{
+			Name:    "Input Diff File",
+			Status:  "ok",
+			Message: fmt.Sprintf("%s has no diff_file reference", inputName),
+		}
+	}
+
+	diffPath := entry.DiffFile
+	if !filepath.IsAbs(diffPath) {
+		diffPath = filepath.Join(projectPath, diffPath)
+	}
+	info, err := os.Stat(diffPath)
+	if err != nil {
+		return CheckResult{
+			Name:    "Input Diff File",
+			Status:  "warning",
+			Message: fmt.Sprintf("%s references diff_file %q, which does not exist", inputName, entry.DiffFile),
+			Fix:     "checkpoint check (regenerates the diff) or remove diff_file from the input",
+		}
+	}
+	if info.IsDir() || info.Size() == 0 {
+		return CheckResult{
+			Name:    "Input Diff File",
+			Status:  "warning",
+			Message: fmt.Sprintf("%s references diff_file %q, which is empty", inputName, entry.DiffFile),
+			Fix:     "checkpoint check (regenerates the diff)",
+		}
+	}
+	return CheckResult{
+		Name:    "Input Diff File",
+		Status:  "ok",
+		Message: fmt.Sprintf("diff_file %s present", entry.DiffFile),
+	}
+}
+
+// utf8BOM is the byte order mark some editors prepend to UTF-8 files
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// encodingTargets lists the YAML config files and changelog checked for encoding problems
+func encodingTargets(projectPath string) []string {
+	var paths []string
+	for _, pattern := range []string{"*.yml", "*.yaml"} {
+		matches, _ := filepath.Glob(filepath.Join(projectPath, config.CheckpointDir, pattern))
+		paths = append(paths, matches...)
+	}
+	sort.Strings(paths)
+	changelogPath := filepath.Join(projectPath, config.ChangelogFileName)
+	if file.Exists(changelogPath) {
+		paths = append(paths, changelogPath)
+	}
+	return paths
+}
+
+// checkFileEncoding flags a leading UTF-8 BOM or invalid UTF-8 in config
+// files and the changelog; both cause YAML parse errors that look baffling
+func checkFileEncoding(projectPath string) CheckResult {
+	var bom, invalid []string
+	for _, path := range encodingTargets(projectPath) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		rel, _ := filepath.Rel(projectPath, path)
+		if bytes.HasPrefix(data, utf8BOM) {
+			bom = append(bom, rel)
+			data = data[len(utf8BOM):]
+		}
+		if !utf8.Valid(data) {
+			invalid = append(invalid, rel)
+		}
+	}
+
+	if len(invalid) > 0 {
+		msg := fmt.Sprintf("Not valid UTF-8: %s", strings.Join(invalid, ", "))
+		if len(bom) > 0 {
+			msg += fmt.Sprintf("; BOM-prefixed: %s", strings.Join(bom, ", "))
+		}
+		return CheckResult{
+			Name:    "File Encoding",
+			Status:  "error",
+			Message: msg,
+			Fix:     "re-save the listed files as UTF-8 (without BOM) in your editor",
+		}
+	}
+	if len(bom) > 0 {
+		return CheckResult{
+			Name:    "File Encoding",
+			Status:  "warning",
+			Message: fmt.Sprintf("UTF-8 BOM at start of: %s (can break YAML parsing)", strings.Join(bom, ", ")),
+			Fix:     "checkpoint doctor --fix",
+			AutoFix: true,
+			Apply: func() (string, error) {
+				return stripBOMs(projectPath, bom)
+			},
+		}
+	}
+	return CheckResult{
+		Name:    "File Encoding",
+		Status:  "ok",
+		Message: "Config files and changelog are UTF-8 without BOM",
+	}
+}
+
+// stripBOMs removes the leading BOM from each file (paths relative to projectPath)
+func stripBOMs(projectPath string, rels []string) (string, error) {
+	var changed []string
+	for _, rel := range rels {
+		path := filepath.Join(projectPath, rel)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return strings.Join(changed, "\n"), fmt.Errorf("read %s: %w", rel, err)
+		}
+		if !bytes.HasPrefix(data, utf8BOM) {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return strings.Join(changed, "\n"), fmt.Errorf("stat %s: %w", rel, err)
+		}
+		if err := os.WriteFile(path, data[len(utf8BOM):], info.Mode().Perm()); err != nil {
+			return strings.Join(changed, "\n"), fmt.Errorf("write %s: %w", rel, err)
+		}
+		changed = append(changed, "stripped BOM from "+rel)
+	}
+	return strings.Join(changed, "\n"), nil
+}
+
+// Default changelog thresholds; override with doctor.changelog_max_mb and
+// doctor.changelog_max_entries in .checkpoint/project.yaml
+const (
+	defaultChangelogMaxMB      = 5
+	defaultChangelogMaxEntries = 2000
+)
+
+// checkChangelogSize reports changelog size and checkpoint count, warning
+// once either passes the configured threshold
+func checkChangelogSize(projectPath string) CheckResult {
+	changelogPath := filepath.Join(projectPath, config.ChangelogFileName)
+	info, err := os.Stat(changelogPath)
+	if err != nil {
+		return CheckResult{
+			Name:    "Changelog Size",
+			Status:  "error",
+			Message: fmt.Sprintf("Cannot read changelog: %v", err),
+		}
+	}
+	entries, err := loadChangelogEntries(changelogPath)
+	if err != nil {
+		return CheckResult{
+			Name:    "Changelog Size",
+			Status:  "error",
+			Message: fmt.Sprintf("Cannot read changelog: %v", err),
+		}
+	}
+
+	settings := loadProjectSettings(projectPath)
+	maxMB := settings.Doctor.ChangelogMaxMB
+	if maxMB <= 0 {
+		maxMB = defaultChangelogMaxMB
+	}
+	maxEntries := settings.Doctor.ChangelogMaxEntries
+	if maxEntries <= 0 {
+		maxEntries = defaultChangelogMaxEntries
+	}
+
+	sizeMB := float64(info.Size()) / (1024 * 1024)
+	summary := fmt.Sprintf("%.1f MB, %d checkpoints", sizeMB, len(entries))
+	var over []string
+	if sizeMB > float64(maxMB) {
+		over = append(over, fmt.Sprintf("over %d MB", maxMB))
+	}
+	if len(entries) > maxEntries {
+		over = append(over, fmt.Sprintf("over %d checkpoints", maxEntries))
+	}
+	if len(over) > 0 {
+		return CheckResult{
+			Name:    "Changelog Size",
+			Status:  "warning",
+			Message: fmt.Sprintf("%s (%s); large changelogs slow every command", summary, strings.Join(over, ", ")),
+			Fix:     fmt.Sprintf("archive older checkpoints out of %s, or raise doctor.changelog_max_mb / changelog_max_entries in project.yaml", config.ChangelogFileName),
+		}
+	}
+	return CheckResult{
+		Name:    "Changelog Size",
+		Status:  "ok",
+		Message: summary,
+	}
+}
+
+// checkPathHash compares the meta document's path_hash with one computed for
+// the project's current location; they differ after the directory is moved
+func checkPathHash(projectPath string) CheckResult {
+	changelogPath := filepath.Join(projectPath, config.ChangelogFileName)
+	meta, err := changelog.ReadMetaDocument(changelogPath)
+	if err != nil {
+		return CheckResult{
+			Name:    "Path Hash",
+			Status:  "warning",
+			Message: fmt.Sprintf("Cannot read changelog meta document: %v", err),
+		}
+	}
+	if meta == nil {
+		return CheckResult{
+			Name:    "Path Hash",
+			Status:  "ok",
+			Message: "No meta document; path_hash is added on the next commit",
+		}
+	}
+	current, err := changelog.ComputePathHash(changelogPath)
+	if err != nil {
+		return CheckResult{
+			Name:    "Path Hash",
+			Status:  "warning",
+			Message: fmt.Sprintf("Cannot compute path hash: %v", err),
+		}
+	}
+	if meta.PathHash == current {
+		return CheckResult{
+			Name:    "Path Hash",
+			Status:  "ok",
+			Message: fmt.Sprintf("path_hash %s matches project location", current),
+		}
+	}
+
+	stored := meta.PathHash
+	if stored == "" {
+		stored = "(none)"
+	}
+	return CheckResult{
+		Name:    "Path Hash",
+		Status:  "warning",
+		Message: fmt.Sprintf("path_hash %s does not match current location (%s); project moved?", stored, current),
+		Fix:     "checkpoint doctor --fix",
+		AutoFix: true,
+		Apply: func() (string, error) {
+			if err := changelog.UpdateMetaPathHash(changelogPath, current); err != nil {
+				return "", err
+			}
+			updated := "changelog meta"
+			statusPath := filepath.Join(projectPath, config.StatusFileName)
+			if ok, err := replaceStatusPathHash(statusPath, current); err != nil {
+				return "", err
+			} else if ok {
+				updated += " and status file"
+			}
+			return fmt.Sprintf("path_hash %s -> %s (%s)", meta.PathHash, current, updated), nil
+		},
+	}
+}
+
+// changelogDoc is one raw changelog document with its parsed timestamp
+type changelogDoc struct {
+	body      string
+	meta      bool
+	timestamp time.Time
+	valid     bool // timestamp parsed
+}
+
+// splitChangelogDocs splits changelog content into raw documents
+func splitChangelogDocs(content string) []changelogDoc {
+	var docs []changelogDoc
+	for _, raw := range splitYAMLDocuments(content) {
+		body := strings.TrimSpace(strings.TrimPrefix(raw, "---"))
+		var head struct {
+			DocumentType string `yaml:"document_type"`
+			Timestamp    string `yaml:"timestamp"`
+		}
+		_ = yaml.Unmarshal([]byte(body), &head)
+		doc := changelogDoc{body: body, meta: head.DocumentType == "meta"}
+		if ts, err := time.Parse(time.RFC3339, head.Timestamp); err == nil {
+			doc.timestamp, doc.valid = ts, true
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+// schemaVersionDetails is the --json distribution of schema_version values
+// per file, keyed by version ("(none)" when the field is missing)
+type schemaVersionDetails struct {
+	Changelog map[string]int `json:"changelog,omitempty"`
+	Context   map[string]int `json:"context,omitempty"`
+	Expected  string         `json:"expected"`
+	Differing []string       `json:"differing,omitempty"`
+}
+
+// checkSchemaVersions warns when changelog and context documents (other than
+// the meta document) carry more than one schema_version value
+func checkSchemaVersions(projectPath string) CheckResult {
+	details := schemaVersionDetails{}
+	type docVersion struct{ label, version string }
+	var docs []docVersion
+	total := map[string]int{}
+
+	for _, src := range []struct {
+		name   string
+		counts *map[string]int
+	}{
+		{config.ChangelogFileName, &details.Changelog},
+		{config.ContextFileName, &details.Context},
+	} {
+		data, err := os.ReadFile(filepath.Join(projectPath, src.name))
+		if err != nil {
+			continue
+		}
+		counts := map[string]int{}
+		pos := 0
+		for _, raw := range splitYAMLDocuments(string(data)) {
+			body := strings.TrimSpace(strings.TrimPrefix(raw, "---"))
+			if body == "" {
+				continue
+			}
+			var head struct {
+				DocumentType  string `yaml:"document_type"`
+				SchemaVersion string `yaml:"schema_version"`
+				Timestamp     string `yaml:"timestamp"`
+			}
+			_ = yaml.Unmarshal([]byte(body), &head)
+			if head.DocumentType == "meta" {
+				continue
+			}
+			pos++
+			version := head.SchemaVersion
+			if version == "" {
+				version = "(none)"
+			}
+			counts[version]++
+			total[version]++
+			label := fmt.Sprintf("%s #%d", src.name, pos)
+			if head.Timestamp != "" {
+				label += " (" + head.Timestamp + ")"
+			}
+			docs = append(docs, docVersion{label: label, version: version})
+		}
+		if len(counts) > 0 {
+			*src.counts = counts
+		}
+	}
+
+	if len(total) == 0 {
+		return CheckResult{
+			Name:    "Schema Versions",
+			Status:  "ok",
+			Message: "No documents to check",
+		}
+	}
+	versions := make([]string, 0, len(total))
+	for v := range total {
+		versions = append(versions, v)
+	}
+	sort.Strings(versions)
+	// The most common version is taken as expected; ties go to the higher version
+	for _, v := range versions {
+		if details.Expected == "" || total[v] >= total[details.Expected] {
+			details.Expected = v
+		}
+	}
+	if len(total) == 1 {
+		return CheckResult{
+			Name:    "Schema Versions",
+			Status:  "ok",
+			Message: fmt.Sprintf("All %d documents use schema_version %s", len(docs), details.Expected),
+			Details: details,
+		}
+	}
+
+	for _, d := range docs {
+		if d.version != details.Expected {
+			details.Differing = append(details.Differing, fmt.Sprintf("%s: %s", d.label, d.version))
+		}
+	}
+	var dist []string
+	for _, v := range versions {
+		dist = append(dist, fmt.Sprintf("%s ×%d", v, total[v]))
+	}
+	shown := details.Differing
+	if len(shown) > 3 {
+		shown = append(shown[:3:3], fmt.Sprintf("and %d more", len(details.Differing)-3))
+	}
+	return CheckResult{
+		Name:   "Schema Versions",
+		Status: "warning",
+		Message: fmt.Sprintf("Mixed schema_version values (%s); differing from %s: %s",
+			strings.Join(dist, ", "), details.Expected, strings.Join(shown, "; ")),
+		Fix:     "review the listed documents and align their schema_version",
+		Details: details,
+	}
+}
+
+// checkChangelogOrder reports checkpoints whose timestamp is earlier than a
+// checkpoint before them; commands assume the newest checkpoint is last
+func checkChangelogOrder(projectPath string) CheckResult {
+	changelogPath := filepath.Join(projectPath, config.ChangelogFileName)
+	data, err := os.ReadFile(changelogPath)
+	if err != nil {
+		return CheckResult{
+			Name:    "Changelog Order",
+			Status:  "error",
+			Message: fmt.Sprintf("Cannot read changelog: %v", err),
+		}
+	}
+
+	docs := splitChangelogDocs(string(data))
+	var outOfOrder, unparsed []string
+	var latest time.Time
+	latestPos := 0
+	pos := 0
+	for _, d := range docs {
+		if d.meta {
+			continue
+		}
+		pos++
+		if !d.valid {
+			unparsed = append(unparsed, fmt.Sprintf("#%d", pos))
+			continue
+		}
+		if d.timestamp.Before(latest) {
+			outOfOrder = append(outOfOrder, fmt.Sprintf("#%d (%s) is older than #%d (%s)",
+				pos, d.timestamp.Format(time.RFC3339), latestPos, latest.Format(time.RFC3339)))
+			continue
+		}
+		latest, latestPos = d.timestamp, pos
+	}
+
+	if len(outOfOrder) == 0 {
+		return CheckResult{
+			Name:    "Changelog Order",
+			Status:  "ok",
+			Message: fmt.Sprintf("%d checkpoints in timestamp order", pos),
+		}
+	}
+
+	shown := outOfOrder
+	if len(shown) > 3 {
+		shown = append(shown[:3:3], fmt.Sprintf("and %d more", len(outOfOrder)-3))
+	}
+	result := CheckResult{
+		Name:    "Changelog Order",
+		Status:  "warning",
+		Message: fmt.Sprintf("%d checkpoint(s) out of timestamp order: %s", len(outOfOrder), strings.Join(shown, "; ")),
+		Fix:     "checkpoint doctor --fix",
+	}
+	if len(unparsed) > 0 {
+		// Sorting would have to guess where these belong
+		result.Message += fmt.Sprintf("; unparseable timestamp in %s", strings.Join(unparsed, ", "))
+		result.Fix = fmt.Sprintf("fix the timestamps in %s, then run checkpoint doctor --fix", config.ChangelogFileName)
+		return result
+	}
+	result.AutoFix = true
+	result.Apply = func() (string, error) {
+		if err := sortChangelogDocs(changelogPath, docs); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("re-sorted %d checkpoints by timestamp", pos), nil
+	}
+	return result
+}
+
+// sortChangelogDocs rewrites the changelog with meta documents first and
+// checkpoints stably sorted by timestamp
+func sortChangelogDocs(changelogPath string, docs []changelogDoc) error {
+	var meta, entries []changelogDoc
+	for _, d := range docs {
+		if d.meta {
+			meta = append(meta, d)
+		} else {
+			entries = append(entries, d)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].timestamp.Before(entries[j].timestamp)
+	})
+
+	var sb strings.Builder
+	for _, d := range append(meta, entries...) {
+		sb.WriteString("---\n")
+		sb.WriteString(d.body)
+		sb.WriteString("\n")
+	}
+
+	tmpPath := changelogPath + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("write changelog: %w", err)
+	}
+	if err := os.Rename(tmpPath, changelogPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("replace changelog: %w", err)
+	}
+	return nil
+}
+
+// replaceStatusPathHash rewrites the status file's path_hash line; reports
+// false when there is no status file or it has no path_hash
+func replaceStatusPathHash(statusPath, pathHash string) (bool, error) {
+	if !file.Exists(statusPath) {
+		return false, nil
+	}
+	content, err := file.ReadFile(statusPath)
+	if err != nil {
+		return false, err
+	}
+	lines := strings.Split(content, "\n")
+	found := false
+	for i, line := range lines {
+		if strings.HasPrefix(line, "path_hash:") {
+			lines[i] = fmt.Sprintf("path_hash: \"%s\"", pathHash)
+			found = true
+		}
+	}
+	if !found {
+		return false, nil
+	}
+	return true, file.WriteFile(statusPath, strings.Join(lines, "\n"))
+}
+
+// checkToolVersion compares the tool_version recorded in the changelog meta
+// document with the running version. Without since, an older major release
+// (or older minor while on 0.x) is flagged as drift.
+func checkToolVersion(projectPath, running, since string) CheckResult {
+	meta, err := changelog.ReadMetaDocument(filepath.Join(projectPath, config.ChangelogFileName))
+	if err != nil {
+		return CheckResult{
+			Name:    "Tool Version",
+			Status:  "warning",
+			Message: fmt.Sprintf("Cannot read changelog meta document: %v", err),
+		}
+	}
+	if meta == nil || meta.ToolVersion == "" {
+		return CheckResult{
+			Name:    "Tool Version",
+			Status:  "ok",
+			Message: fmt.Sprintf("No tool_version recorded in changelog (running %s)", running),
+		}
+	}
+
+	recorded, ok := parseToolVersion(meta.ToolVersion)
+	current, currentOK := parseToolVersion(running)
+	if !ok || !currentOK {
+		return CheckResult{
+			Name:    "Tool Version",
+			Status:  "ok",
+			Message: fmt.Sprintf("Changelog created with %s, running %s (not comparable)", meta.ToolVersion, running),
+		}
+	}
+	summary := fmt.Sprintf("Changelog created with %s, running %s", meta.ToolVersion, running)
+
+	if since != "" {
+		minVersion, ok := parseToolVersion(since)
+		if !ok {
+			return CheckResult{
+				Name:    "Tool Version",
+				Status:  "error",
+				Message: fmt.Sprintf("Invalid --since version '%s' (expected e.g. 0.2.0)", since),
+			}
+		}
+		if compareToolVersions(recorded, minVersion) < 0 {
+			return CheckResult{
+				Name:    "Tool Version",
+				Status:  "warning",
+				Message: fmt.Sprintf("%s; older than %s", summary, since),
+				Fix:     fmt.Sprintf("review checkpoint release notes since %s for schema changes that need migrating", meta.ToolVersion),
+			}
+		}
+		return CheckResult{Name: "Tool Version", Status: "ok", Message: summary}
+	}
+
+	switch {
+	case compareToolVersions(recorded, current) > 0:
+		return CheckResult{
+			Name:    "Tool Version",
+			Status:  "warning",
+			Message: fmt.Sprintf("%s; this binary is older than the project", summary),
+			Fix:     fmt.Sprintf("upgrade checkpoint to %s or later", meta.ToolVersion),
+		}
+	case recorded[0] < current[0] || (current[0] == 0 && recorded[1] < current[1]):
+		return CheckResult{
+			Name:    "Tool Version",
+			Status:  "warning",
+			Message: fmt.Sprintf("%s; project may predate current schema features", summary),
+			Fix:     fmt.Sprintf("review checkpoint release notes since %s for schema changes that need migrating", meta.ToolVersion),
+		}
+	}
+	return CheckResult{Name: "Tool Version", Status: "ok", Message: summary}
+}
+
+// parseToolVersion parses "v1.2.3" or "1.2.3-rc1" into major, minor, patch
+func parseToolVersion(v string) ([3]int, bool) {
+	var parts [3]int
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	fields := strings.Split(v, ".")
+	if len(fields) == 0 || len(fields) > 3 {
+		return parts, false
+	}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
+
+// compareToolVersions returns -1, 0, or 1 as a is older, equal, or newer than b
+func compareToolVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
 func checkSkills(projectPath string) CheckResult {
 	skillsYamlPath := file.FindWithFallback(
 		filepath.Join(projectPath, config.CheckpointDir, config.ExplainSkillsYaml),
@@ -499,3 +1456,144 @@ func checkSkills(projectPath string) CheckResult {
 		Message: "Skills configured",
 	}
 }
+
+// checkOrphanedSkills finds local skill directories with a skill.md that are
+// not listed under local: in skills.yaml, and so never load
+func checkOrphanedSkills(projectPath string) CheckResult {
+	orphans := findOrphanedSkills(projectPath)
+	if len(orphans) == 0 {
+		return CheckResult{
+			Name:    "Skill Config",
+			Status:  "ok",
+			Message: "All local skills are listed in skills.yaml",
+		}
+	}
+
+	return CheckResult{
+		Name:    "Skill Config",
+		Status:  "warning",
+		Message: fmt.Sprintf("%d local skill(s) not listed in skills.yaml and will not load: %s", len(orphans), strings.Join(orphans, ", ")),
+		Fix:     "checkpoint doctor --fix",
+		AutoFix: true,
+		Apply: func() (string, error) {
+			return addLocalSkillsToConfig(projectPath, orphans)
+		},
+	}
+}
+
+// findOrphanedSkills lists skill directories under .checkpoint/skills that have
+// a skill.md but are missing from the local skills config
+func findOrphanedSkills(projectPath string) []string {
+	skillsDir := filepath.Join(projectPath, config.CheckpointDir, config.SkillsDir)
+	entries, err := os.ReadDir(skillsDir)
+	if err != nil {
+		return nil
+	}
+
+	listed := map[string]bool{}
+	skillsPath := file.FindWithFallback(
+		filepath.Join(projectPath, config.CheckpointDir, config.ExplainSkillsYaml),
+		filepath.Join(projectPath, config.CheckpointDir, config.ExplainSkillsYmlLegacy),
+	)
+	if data, err := os.ReadFile(skillsPath); err == nil {
+		var skillsConfig explain.SkillsConfig
+		if err := yaml.Unmarshal(data, &skillsConfig); err == nil {
+			for _, name := range skillsConfig.Local {
+				listed[name] = true
+			}
+		}
+	}
+
+	var orphans []string
+	for _, e := range entries {
+		if !e.IsDir() || listed[e.Name()] {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(skillsDir, e.Name(), "skill.md")); err == nil {
+			orphans = append(orphans, e.Name())
+		}
+	}
+	return orphans
+}
+
+// addLocalSkillsToConfig appends names to local: in skills.yaml, creating the file if needed
+func addLocalSkillsToConfig(projectPath string, names []string) (string, error) {
+	skillsPath := file.FindWithFallback(
+		filepath.Join(projectPath, config.CheckpointDir, config.ExplainSkillsYaml),
+		filepath.Join(projectPath, config.CheckpointDir, config.ExplainSkillsYmlLegacy),
+	)
+
+	var skillsConfig explain.SkillsConfig
+	data, err := os.ReadFile(skillsPath)
+	if err == nil {
+		if err := yaml.Unmarshal(data, &skillsConfig); err != nil {
+			return "", fmt.Errorf("parse %s: %w", filepath.Base(skillsPath), err)
+		}
+	} else if !os.IsNotExist(err) {
+		return "", fmt.Errorf("read %s: %w", filepath.Base(skillsPath), err)
+	}
+	skillsConfig.SchemaVersion = "1"
+
+	var sb strings.Builder
+	for _, name := range names {
+		skillsConfig.Local = append(skillsConfig.Local, name)
+		sb.WriteString(fmt.Sprintf("+ added local skill '%s'\n", name))
+	}
+
+	out, err := yaml.Marshal(&skillsConfig)
+	if err != nil {
+		return "", fmt.Errorf("marshal skills config: %w", err)
+	}
+	content := "schema_version: \"1\"\n\n" + strings.TrimPrefix(string(out), "schema_version: \"1\"\n")
+	if err := os.WriteFile(skillsPath, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("write %s: %w", filepath.Base(skillsPath), err)
+	}
+	rel, _ := filepath.Rel(projectPath, skillsPath)
+	sb.WriteString(fmt.Sprintf("updated %s\n", rel))
+	return sb.String(), nil
+}
+
+// checkSkillContent flags local skills whose skill.md is empty or still the createSkill template
+func checkSkillContent(projectPath string) CheckResult {
+	skillsDir := filepath.Join(projectPath, config.CheckpointDir, config.SkillsDir)
+	entries, err := os.ReadDir(skillsDir)
+	if err != nil {
+		return CheckResult{
+			Name:    "Skill Content",
+			Status:  "ok",
+			Message: "No local skills to check",
+		}
+	}
+
+	var unfinished []string
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		skillPath := filepath.Join(skillsDir, e.Name(), "skill.md")
+		data, err := os.ReadFile(skillPath)
+		if err != nil {
+			continue
+		}
+		content := strings.TrimSpace(string(data))
+		if content == "" || strings.Contains(content, skillPlaceholder) {
+			rel, _ := filepath.Rel(projectPath, skillPath)
+			unfinished = append(unfinished, rel)
+		}
+	}
+
+	if len(unfinished) > 0 {
+		return CheckResult{
+			Name:    "Skill Content",
+			Status:  "warning",
+			Message: fmt.Sprintf("%d skill(s) empty or still template-only: %s", len(unfinished), strings.Join(unfinished, ", ")),
+			Fix:     "edit the listed skill.md files to describe the skill, or remove unused skills",
+		}
+	}
+
+	return CheckResult{
+		Name:    "Skill Content",
+		Status:  "ok",
+		Message: "Local skills have content",
+	}
+}
@@ -0,0 +1,581 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dmoose/checkpoint/pkg/config"
+)
+
+func TestCheckOrphanedSkills(t *testing.T) {
+	tmpDir := t.TempDir()
+	skillsDir := filepath.Join(tmpDir, config.CheckpointDir, config.SkillsDir)
+	for _, name := range []string{"listed", "manual"} {
+		if err := os.MkdirAll(filepath.Join(skillsDir, name), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(skillsDir, name, "skill.md"), []byte("# "+name+"\n"), 0644); err != nil {
+			t.Fatalf("write skill: %v", err)
+		}
+	}
+	// Directory without skill.md is not a skill
+	if err := os.MkdirAll(filepath.Join(skillsDir, "scratch"), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	skillsPath := filepath.Join(tmpDir, config.CheckpointDir, config.ExplainSkillsYaml)
+	if err := os.WriteFile(skillsPath, []byte("schema_version: \"1\"\nlocal:\n  - listed\n"), 0644); err != nil {
+		t.Fatalf("write skills config: %v", err)
+	}
+
+	result := checkOrphanedSkills(tmpDir)
+	if result.Status != "warning" || !result.AutoFix || result.Apply == nil {
+		t.Fatalf("expected fixable warning, got %+v", result)
+	}
+	if !strings.Contains(result.Message, "manual") || strings.Contains(result.Message, "scratch") {
+		t.Errorf("unexpected message: %s", result.Message)
+	}
+
+	changed, err := result.Apply()
+	if err != nil {
+		t.Fatalf("Apply error: %v", err)
+	}
+	if !strings.Contains(changed, "'manual'") {
+		t.Errorf("expected fix to report the added skill, got: %s", changed)
+	}
+
+	data, _ := os.ReadFile(skillsPath)
+	if !strings.Contains(string(data), "- listed") || !strings.Contains(string(data), "- manual") {
+		t.Errorf("skills config missing entries:\n%s", string(data))
+	}
+	if result := checkOrphanedSkills(tmpDir); result.Status != "ok" {
+		t.Errorf("expected ok after fix, got %+v", result)
+	}
+}
+
+func TestCheckChangelogSize(t *testing.T) {
+	tmpDir := t.TempDir()
+	var sb strings.Builder
+	sb.WriteString("---\nschema_version: \"1\"\ndocument_type: meta\n")
+	for i := 0; i < 3; i++ {
+		sb.WriteString("---\nschema_version: \"1\"\ntimestamp: \"2025-01-01T00:00:00Z\"\nchanges:\n  - summary: \"Change\"\n    change_type: feature\n")
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, config.ChangelogFileName), []byte(sb.String()), 0644); err != nil {
+		t.Fatalf("write changelog: %v", err)
+	}
+
+	result := checkChangelogSize(tmpDir)
+	if result.Status != "ok" || !strings.Contains(result.Message, "3 checkpoints") {
+		t.Fatalf("expected ok with 3 checkpoints, got %+v", result)
+	}
+
+	// A lower configured threshold turns it into a warning
+	if err := os.MkdirAll(filepath.Join(tmpDir, config.CheckpointDir), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	settings := "doctor:\n  changelog_max_entries: 2\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, config.CheckpointDir, config.ExplainProjectYaml), []byte(settings), 0644); err != nil {
+		t.Fatalf("write project.yaml: %v", err)
+	}
+	result = checkChangelogSize(tmpDir)
+	if result.Status != "warning" || !strings.Contains(result.Message, "over 2 checkpoints") || result.Fix == "" {
+		t.Errorf("expected warning over entry threshold, got %+v", result)
+	}
+}
+
+func TestCheckToolVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	meta := "---\nschema_version: \"1\"\ndocument_type: meta\ntool_version: \"0.1.4\"\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, config.ChangelogFileName), []byte(meta), 0644); err != nil {
+		t.Fatalf("write changelog: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		running string
+		since   string
+		status  string
+	}{
+		{"same minor", "0.1.9", "", "ok"},
+		{"older 0.x minor", "0.3.0", "", "warning"},
+		{"binary older than project", "0.1.0", "", "warning"},
+		{"dev build", "dev", "", "ok"},
+		{"since satisfied", "2.0.0", "0.1.0", "ok"},
+		{"since not satisfied", "0.1.4", "v0.2", "warning"},
+		{"invalid since", "0.1.4", "latest", "error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkToolVersion(tmpDir, tt.running, tt.since)
+			if result.Status != tt.status {
+				t.Errorf("expected %s, got %+v", tt.status, result)
+			}
+		})
+	}
+}
+
+func TestCheckFileEncoding(t *testing.T) {
+	tmpDir := t.TempDir()
+	checkpointDir := filepath.Join(tmpDir, config.CheckpointDir)
+	if err := os.MkdirAll(checkpointDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	toolsPath := filepath.Join(checkpointDir, config.ExplainToolsYaml)
+	if err := os.WriteFile(toolsPath, []byte("schema_version: \"1\"\n"), 0644); err != nil {
+		t.Fatalf("write tools: %v", err)
+	}
+	if result := checkFileEncoding(tmpDir); result.Status != "ok" {
+		t.Fatalf("expected ok for clean files, got %+v", result)
+	}
+
+	// BOM is a warning with an auto-fix that strips it
+	if err := os.WriteFile(toolsPath, append([]byte{0xEF, 0xBB, 0xBF}, "schema_version: \"1\"\n"...), 0644); err != nil {
+		t.Fatalf("write tools: %v", err)
+	}
+	result := checkFileEncoding(tmpDir)
+	if result.Status != "warning" || !result.AutoFix || !strings.Contains(result.Message, config.ExplainToolsYaml) {
+		t.Fatalf("expected BOM warning, got %+v", result)
+	}
+	if _, err := result.Apply(); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	data, _ := os.ReadFile(toolsPath)
+	if string(data) != "schema_version: \"1\"\n" {
+		t.Errorf("expected BOM stripped, got %q", data)
+	}
+
+	// Invalid UTF-8 in the changelog is an error without auto-fix
+	if err := os.WriteFile(filepath.Join(tmpDir, config.ChangelogFileName), []byte("summary: \"caf\xe9\"\n"), 0644); err != nil {
+		t.Fatalf("write changelog: %v", err)
+	}
+	result = checkFileEncoding(tmpDir)
+	if result.Status != "error" || result.AutoFix || !strings.Contains(result.Message, config.ChangelogFileName) {
+		t.Errorf("expected invalid UTF-8 error, got %+v", result)
+	}
+}
+
+func TestCheckPathHash(t *testing.T) {
+	tmpDir := t.TempDir()
+	changelogPath := filepath.Join(tmpDir, config.ChangelogFileName)
+	meta := "---\nschema_version: \"1\"\ndocument_type: meta\nproject_id: abc\npath_hash: 1111222233334444\n"
+	if err := os.WriteFile(changelogPath, []byte(meta), 0644); err != nil {
+		t.Fatalf("write changelog: %v", err)
+	}
+	statusPath := filepath.Join(tmpDir, config.StatusFileName)
+	if err := os.WriteFile(statusPath, []byte("project_id: \"abc\"\npath_hash: \"1111222233334444\"\n"), 0644); err != nil {
+		t.Fatalf("write status: %v", err)
+	}
+
+	result := checkPathHash(tmpDir)
+	if result.Status != "warning" || !result.AutoFix || !strings.Contains(result.Message, "1111222233334444") {
+		t.Fatalf("expected fixable mismatch warning, got %+v", result)
+	}
+	changed, err := result.Apply()
+	if err != nil {
+		t.Fatalf("Apply error: %v", err)
+	}
+	if !strings.Contains(changed, "1111222233334444 -> ") || !strings.Contains(changed, "status file") {
+		t.Errorf("expected before/after hashes in fix report, got: %s", changed)
+	}
+
+	if result := checkPathHash(tmpDir); result.Status != "ok" {
+		t.Errorf("expected ok after fix, got %+v", result)
+	}
+	status, _ := os.ReadFile(statusPath)
+	if strings.Contains(string(status), "1111222233334444") {
+		t.Errorf("status file path_hash not updated:\n%s", status)
+	}
+}
+
+func TestBuildDoctorReport(t *testing.T) {
+	results := []CheckResult{
+		{Name: "A", Status: "ok", Message: "fine"},
+		{Name: "B", Status: "warning", Message: "meh", Fix: "do b", AutoFix: true,
+			Apply: func() (string, error) { return "fixed b\n", nil }},
+		{Name: "C", Status: "missing", Message: "gone", Fix: "do c"},
+	}
+
+	report := buildDoctorReport(t.TempDir(), results, DoctorOptions{})
+	if report.Summary != (DoctorSummary{OK: 1, Warning: 1, Missing: 1}) {
+		t.Errorf("unexpected summary: %+v", report.Summary)
+	}
+	if report.ExitStatus != 1 || report.Project != nil {
+		t.Errorf("expected exit_status 1 and no project info, got %+v", report)
+	}
+	if report.Checks[1].Fixed != "" {
+		t.Errorf("fix applied without --fix: %+v", report.Checks[1])
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	for _, want := range []string{`"name":"B"`, `"auto_fix":true`, `"fix":"do c"`, `"exit_status":1`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("JSON missing %s: %s", want, data)
+		}
+	}
+
+	report = buildDoctorReport(t.TempDir(), results[:2], DoctorOptions{Fix: true, Verbose: true})
+	if report.ExitStatus != 0 || report.Project == nil {
+		t.Errorf("expected exit_status 0 with project info, got %+v", report)
+	}
+	if report.Checks[1].Fixed != "fixed b" {
+		t.Errorf("expected fix outcome recorded, got %+v", report.Checks[1])
+	}
+}
+
+func TestCheckGitignoreFix(t *testing.T) {
+	tmpDir := t.TempDir()
+	gitignorePath := filepath.Join(tmpDir, ".gitignore")
+	if err := os.WriteFile(gitignorePath, []byte("bin/\n.checkpoint-input\n"), 0644); err != nil {
+		t.Fatalf("write .gitignore: %v", err)
+	}
+
+	result := checkGitignore(tmpDir)
+	if result.Status != "warning" || !result.AutoFix || result.Apply == nil {
+		t.Fatalf("expected fixable warning, got %+v", result)
+	}
+	if _, err := result.Apply(); err != nil {
+		t.Fatalf("Apply error: %v", err)
+	}
+	data, _ := os.ReadFile(gitignorePath)
+	if !strings.HasPrefix(string(data), "bin/\n") || strings.Count(string(data), ".checkpoint-input\n") != 1 {
+		t.Errorf("unexpected .gitignore:\n%s", data)
+	}
+	if result := checkGitignore(tmpDir); result.Status != "ok" {
+		t.Errorf("expected ok after fix, got %+v", result)
+	}
+
+	// A missing .gitignore is created
+	if err := os.Remove(gitignorePath); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if _, err := checkGitignore(tmpDir).Apply(); err != nil {
+		t.Fatalf("Apply error: %v", err)
+	}
+	if result := checkGitignore(tmpDir); result.Status != "ok" {
+		t.Errorf("expected ok after creating .gitignore, got %+v", result)
+	}
+}
+
+func TestCheckGitignoreOldStyle(t *testing.T) {
+	tmpDir := t.TempDir()
+	gitignorePath := filepath.Join(tmpDir, ".gitignore")
+	// Written by an init that predates the JSON input file
+	old := "# Checkpoint artifacts (temporary files, not tracked)\n.checkpoint-input\n.checkpoint-diff\n.checkpoint-lock\n.checkpoint-status.yaml\n"
+	if err := os.WriteFile(gitignorePath, []byte(old), 0644); err != nil {
+		t.Fatalf("write .gitignore: %v", err)
+	}
+
+	result := checkGitignore(tmpDir)
+	if result.Status != "warning" || result.Message != ".gitignore missing: .checkpoint-input.json" {
+		t.Fatalf("expected only the JSON input to be missing, got %+v", result)
+	}
+	if _, err := result.Apply(); err != nil {
+		t.Fatalf("Apply error: %v", err)
+	}
+	if result := checkGitignore(tmpDir); result.Status != "ok" {
+		t.Errorf("expected ok after fix, got %+v", result)
+	}
+
+	// init appends the entries it is missing instead of skipping the file
+	if err := os.WriteFile(gitignorePath, []byte(old), 0644); err != nil {
+		t.Fatalf("write .gitignore: %v", err)
+	}
+	updateProjectGitignore(tmpDir)
+	data, _ := os.ReadFile(gitignorePath)
+	if !strings.HasPrefix(string(data), old) {
+		t.Errorf("expected existing entries to be kept:\n%s", data)
+	}
+	if missing := missingGitignoreEntries(string(data), gitignoreEntries); len(missing) != 0 {
+		t.Errorf("init left entries missing: %v", missing)
+	}
+	updateProjectGitignore(tmpDir)
+	again, _ := os.ReadFile(gitignorePath)
+	if string(again) != string(data) {
+		t.Errorf("expected a second init to leave .gitignore unchanged:\n%s", again)
+	}
+}
+
+func TestCheckToolsYmlFix(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte("module example.com/x\n\ngo 1.21\n"), 0644); err != nil {
+		t.Fatalf("write go.mod: %v", err)
+	}
+	checkpointDir := filepath.Join(tmpDir, config.CheckpointDir)
+	if err := os.MkdirAll(checkpointDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	tools := "schema_version: \"1\"\nbuild:\n  default:\n    command: make\n"
+	if err := os.WriteFile(filepath.Join(checkpointDir, config.ExplainToolsYaml), []byte(tools), 0644); err != nil {
+		t.Fatalf("write tools: %v", err)
+	}
+
+	result := checkToolsYml(tmpDir)
+	if result.Status != "warning" || !result.AutoFix {
+		t.Fatalf("expected fixable warning, got %+v", result)
+	}
+	// The hint must be a learn command that actually runs
+	if want := "checkpoint learn 'go test ./...' --tool-name test --category test"; !strings.Contains(result.Fix, want) {
+		t.Errorf("expected fix %q, got %q", want, result.Fix)
+	}
+	changed, err := result.Apply()
+	if err != nil {
+		t.Fatalf("Apply error: %v", err)
+	}
+	if !strings.Contains(changed, "test: go test") || strings.Contains(changed, "build:") {
+		t.Errorf("expected only detected test/lint added, got: %s", changed)
+	}
+
+	data, _ := os.ReadFile(filepath.Join(checkpointDir, config.ExplainToolsYaml))
+	if !strings.Contains(string(data), "command: make") {
+		t.Errorf("existing build command lost:\n%s", data)
+	}
+	// Lint is only detected for Go projects with a linter config
+	if result := checkToolsYml(tmpDir); strings.Contains(result.Message, "test") {
+		t.Errorf("expected test command fixed, got %+v", result)
+	}
+}
+
+func TestCheckChangelogOrder(t *testing.T) {
+	tmpDir := t.TempDir()
+	changelogPath := filepath.Join(tmpDir, config.ChangelogFileName)
+	entry := func(ts, summary string) string {
+		return "---\nschema_version: \"1\"\ntimestamp: \"" + ts + "\"\nchanges:\n  - summary: \"" + summary + "\"\n    change_type: feature\n"
+	}
+	meta := "---\nschema_version: \"1\"\ndocument_type: meta\nproject_id: abc\n"
+	shuffled := meta +
+		entry("2025-01-02T00:00:00Z", "second") +
+		entry("2025-01-03T00:00:00Z", "third") +
+		entry("2025-01-01T00:00:00Z", "first") +
+		entry("2025-01-04T00:00:00Z", "fourth")
+	if err := os.WriteFile(changelogPath, []byte(shuffled), 0644); err != nil {
+		t.Fatalf("write changelog: %v", err)
+	}
+
+	result := checkChangelogOrder(tmpDir)
+	if result.Status != "warning" || !result.AutoFix {
+		t.Fatalf("expected fixable warning, got %+v", result)
+	}
+	if !strings.Contains(result.Message, "#3 (2025-01-01T00:00:00Z) is older than #2") {
+		t.Errorf("expected out-of-order position in message, got: %s", result.Message)
+	}
+	if _, err := result.Apply(); err != nil {
+		t.Fatalf("Apply error: %v", err)
+	}
+
+	data, _ := os.ReadFile(changelogPath)
+	content := string(data)
+	if !strings.HasPrefix(content, meta) {
+		t.Errorf("meta document not first:\n%s", content)
+	}
+	order := []string{"first", "second", "third", "fourth"}
+	last := -1
+	for _, summary := range order {
+		idx := strings.Index(content, "\""+summary+"\"")
+		if idx < last {
+			t.Errorf("%s out of order after fix:\n%s", summary, content)
+		}
+		last = idx
+	}
+	if result := checkChangelogOrder(tmpDir); result.Status != "ok" {
+		t.Errorf("expected ok after fix, got %+v", result)
+	}
+
+	// An unparseable timestamp blocks the auto-fix
+	if err := os.WriteFile(changelogPath, []byte(shuffled+entry("yesterday", "bad")), 0644); err != nil {
+		t.Fatalf("write changelog: %v", err)
+	}
+	if result := checkChangelogOrder(tmpDir); result.Status != "warning" || result.AutoFix || !strings.Contains(result.Message, "#5") {
+		t.Errorf("expected warning without auto-fix, got %+v", result)
+	}
+}
+
+func TestCheckInputDiffFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	input := "schema_version: \"1\"\ntimestamp: \"2025-01-01T00:00:00Z\"\ndiff_file: \".checkpoint-diff\"\nchanges: []\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, config.InputFileName), []byte(input), 0644); err != nil {
+		t.Fatalf("write input: %v", err)
+	}
+
+	result := checkInputDiffFile(tmpDir)
+	if result.Status != "warning" || !strings.Contains(result.Message, "does not exist") {
+		t.Errorf("expected missing diff warning, got %+v", result)
+	}
+
+	diffPath := filepath.Join(tmpDir, config.DiffFileName)
+	if err := os.WriteFile(diffPath, nil, 0644); err != nil {
+		t.Fatalf("write diff: %v", err)
+	}
+	result = checkInputDiffFile(tmpDir)
+	if result.Status != "warning" || !strings.Contains(result.Message, "empty") {
+		t.Errorf("expected empty diff warning, got %+v", result)
+	}
+
+	if err := os.WriteFile(diffPath, []byte("diff --git a/x b/x\n"), 0644); err != nil {
+		t.Fatalf("write diff: %v", err)
+	}
+	if result = checkInputDiffFile(tmpDir); result.Status != "ok" {
+		t.Errorf("expected ok with diff present, got %+v", result)
+	}
+}
+
+func TestCheckSchemaVersions(t *testing.T) {
+	tmpDir := t.TempDir()
+	changelog := "---\nschema_version: \"2\"\ndocument_type: meta\n" +
+		"---\nschema_version: \"1\"\ntimestamp: \"2025-01-01T00:00:00Z\"\n" +
+		"---\nschema_version: \"1\"\ntimestamp: \"2025-01-02T00:00:00Z\"\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, config.ChangelogFileName), []byte(changelog), 0644); err != nil {
+		t.Fatalf("write changelog: %v", err)
+	}
+
+	// The meta document's own version is not compared
+	result := checkSchemaVersions(tmpDir)
+	if result.Status != "ok" || !strings.Contains(result.Message, "schema_version 1") {
+		t.Fatalf("expected ok, got %+v", result)
+	}
+
+	context := "---\nschema_version: \"2\"\ntimestamp: \"2025-01-03T00:00:00Z\"\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, config.ContextFileName), []byte(context), 0644); err != nil {
+		t.Fatalf("write context: %v", err)
+	}
+	result = checkSchemaVersions(tmpDir)
+	if result.Status != "warning" || !strings.Contains(result.Message, config.ContextFileName+" #1 (2025-01-03T00:00:00Z): 2") {
+		t.Fatalf("expected mixed-version warning, got %+v", result)
+	}
+	details, ok := result.Details.(schemaVersionDetails)
+	if !ok || details.Expected != "1" || details.Changelog["1"] != 2 || details.Context["2"] != 1 || len(details.Differing) != 1 {
+		t.Errorf("unexpected details: %+v", result.Details)
+	}
+}
+
+func TestCheckCheckpointGitignore(t *testing.T) {
+	tmpDir := t.TempDir()
+	checkpointDir := filepath.Join(tmpDir, config.CheckpointDir)
+	if err := os.MkdirAll(checkpointDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	// Written by an init that predates the local state files
+	gitignorePath := filepath.Join(checkpointDir, ".gitignore")
+	if err := os.WriteFile(gitignorePath, []byte("# Checkpoint directory is tracked\n"), 0644); err != nil {
+		t.Fatalf("write .gitignore: %v", err)
+	}
+
+	result := checkCheckpointGitignore(tmpDir)
+	if result.Status != "warning" || result.Apply == nil {
+		t.Fatalf("expected fixable warning, got %+v", result)
+	}
+	for _, name := range []string{config.LearnHistoryFileName, config.SkillUsageFileName} {
+		if !strings.Contains(result.Message, name) {
+			t.Errorf("expected %s to be reported missing, got %q", name, result.Message)
+		}
+	}
+	if _, err := result.Apply(); err != nil {
+		t.Fatalf("Apply error: %v", err)
+	}
+	if result := checkCheckpointGitignore(tmpDir); result.Status != "ok" {
+		t.Errorf("expected ok after fix, got %+v", result)
+	}
+}
+
+func TestCheckSkillContent(t *testing.T) {
+	tmpDir := t.TempDir()
+	if result := checkSkillContent(tmpDir); result.Status != "ok" {
+		t.Errorf("expected ok without a skills directory, got %+v", result)
+	}
+
+	skillsDir := filepath.Join(tmpDir, config.CheckpointDir, config.SkillsDir)
+	writeSkill := func(name, content string) {
+		if err := os.MkdirAll(filepath.Join(skillsDir, name), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(skillsDir, name, "skill.md"), []byte(content), 0644); err != nil {
+			t.Fatalf("write skill: %v", err)
+		}
+	}
+
+	writeSkill("review", "# Review\n\nRun the review checklist before every merge.\n")
+	if result := checkSkillContent(tmpDir); result.Status != "ok" {
+		t.Errorf("expected ok for a real skill, got %+v", result)
+	}
+
+	writeSkill("empty", " \n\n")
+	createSkill(tmpDir, "draft") // the createSkill template, never filled in
+	result := checkSkillContent(tmpDir)
+	if result.Status != "warning" || !strings.HasPrefix(result.Message, "2 skill(s)") {
+		t.Fatalf("expected warning for 2 skills, got %+v", result)
+	}
+	for _, name := range []string{"draft", "empty"} {
+		if !strings.Contains(result.Message, filepath.Join(config.CheckpointDir, config.SkillsDir, name, "skill.md")) {
+			t.Errorf("expected %s in message, got: %s", name, result.Message)
+		}
+	}
+	if strings.Contains(result.Message, "review") {
+		t.Errorf("real skill flagged: %s", result.Message)
+	}
+}
+
+func TestCheckToolPrecedence(t *testing.T) {
+	tests := []struct {
+		name        string
+		tools       string // tools.yaml content; "" makes tools.yaml unreadable
+		wantStatus  string
+		wantMessage string
+	}{
+		{
+			name:        "no overlap",
+			tools:       "check:\n  default:\n    command: make check\nlint:\n  vet:\n    command: go vet ./...\n",
+			wantStatus:  "ok",
+			wantMessage: "No overlapping default commands",
+		},
+		{
+			name:        "same command",
+			tools:       "check:\n  default:\n    command: golangci-lint run\nlint:\n  default:\n    command: \" golangci-lint run \"\n",
+			wantStatus:  "warning",
+			wantMessage: "check.default and lint.default are both 'golangci-lint run'",
+		},
+		{
+			name:        "different commands",
+			tools:       "check:\n  default:\n    command: make check\nlint:\n  default:\n    command: golangci-lint run\n",
+			wantStatus:  "warning",
+			wantMessage: "check.default ('make check') takes precedence over lint.default ('golangci-lint run')",
+		},
+		{
+			name:        "unparseable tools.yaml",
+			tools:       "check: [unclosed\n",
+			wantStatus:  "ok",
+			wantMessage: "Skipped (tools config unavailable)",
+		},
+		{
+			name:        "unreadable tools.yaml",
+			wantStatus:  "ok",
+			wantMessage: "Skipped (tools config unavailable)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			toolsPath := filepath.Join(tmpDir, config.CheckpointDir, config.ExplainToolsYaml)
+			if tt.tools == "" {
+				// A directory in place of the file fails to read, even as root
+				if err := os.MkdirAll(toolsPath, 0755); err != nil {
+					t.Fatal(err)
+				}
+			} else {
+				if err := os.MkdirAll(filepath.Dir(toolsPath), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(toolsPath, []byte(tt.tools), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			result := checkToolPrecedence(tmpDir)
+			if result.Status != tt.wantStatus || !strings.HasPrefix(result.Message, tt.wantMessage) {
+				t.Errorf("checkToolPrecedence() = %s %q, want %s %q", result.Status, result.Message, tt.wantStatus, tt.wantMessage)
+			}
+		})
+	}
+}
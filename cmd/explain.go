@@ -5,16 +5,35 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/dmoose/checkpoint/internal/detect"
 	"github.com/dmoose/checkpoint/internal/explain"
+	"github.com/dmoose/checkpoint/internal/git"
+	"github.com/dmoose/checkpoint/pkg/config"
 
 	"github.com/spf13/cobra"
 )
 
 var explainOpts struct {
-	full     bool
-	markdown bool
-	json     bool
+	full      bool
+	markdown  bool
+	json      bool
+	asRules   bool
+	audience  string
+	cache     bool
+	noCache   bool
+	missing   bool
+	mermaid   bool
+	redact    bool
+	used      bool
+	forLang   string
+	tag       string
+	format    string
+	tokens    bool
+	path      string
+	untracked bool
 }
 
 func init() {
@@ -22,13 +41,85 @@ func init() {
 	explainCmd.Flags().BoolVar(&explainOpts.full, "full", false, "Show complete context dump")
 	explainCmd.Flags().BoolVar(&explainOpts.markdown, "md", false, "Output as markdown")
 	explainCmd.Flags().BoolVar(&explainOpts.json, "json", false, "Output as JSON")
+	explainCmd.Flags().BoolVar(&explainOpts.asRules, "as-rules", false, "Flatten guidelines into a DO/DON'T checklist")
+	explainCmd.Flags().StringVar(&explainOpts.audience, "audience", "", "Tune output for human (default) or llm")
+	explainCmd.Flags().BoolVar(&explainOpts.cache, "cache", false, "Reuse the last render when no source files changed")
+	explainCmd.Flags().BoolVar(&explainOpts.noCache, "no-cache", false, "Always re-render, ignoring --cache")
+	explainCmd.Flags().BoolVar(&explainOpts.mermaid, "mermaid", false, "Output a Mermaid flowchart of key paths and integrations (for project)")
+	explainCmd.Flags().BoolVar(&explainOpts.redact, "redact", false, "Replace secrets, emails and .checkpoint/redact.yml patterns with [REDACTED]")
+	explainCmd.Flags().BoolVar(&explainOpts.used, "used", false, "List skills by view count; never-viewed skills are removal candidates (for skills)")
+	explainCmd.Flags().BoolVar(&explainOpts.missing, "missing", false, "List detected commands not yet in tools.yaml (for tools)")
+	explainCmd.Flags().StringVar(&explainOpts.format, "format", "", "One line per checkpoint from a template or preset (oneline, full) (for history)")
+	explainCmd.Flags().BoolVar(&explainOpts.tokens, "token-estimate", false, "Print approximate token counts per section to stderr")
+	explainCmd.Flags().StringVar(&explainOpts.path, "path", "", "Only show key paths and files under this prefix or glob (for project)")
+	explainCmd.Flags().BoolVar(&explainOpts.untracked, "untracked-only", false, "Only show key files not marked tracked (for project)")
+	explainCmd.Flags().StringVar(&explainOpts.tag, "tag", "", "Only show learnings with this tag (for learnings)")
+	explainCmd.Flags().StringVar(&explainOpts.forLang, "for", "", "Only show rules, avoids and principles for this language plus untagged ones (for guidelines)")
 }
 
 var explainCmd = &cobra.Command{
 	Use:   "explain [topic] [skill-name]",
 	Short: "Get project context for LLMs and developers",
 	Long: `Display project context information.
-Topics: project, tools, guidelines, skills, learnings, skill <name>, history, next`,
+Topics: project, tools, guidelines, skills, learnings, skill <name>, history, next
+
+--audience llm strips decorative formatting (blank lines, hints, emphasis on
+rendered keys) for terse, structured output. Code blocks and text from your
+config and skills are left as written. The default, human, keeps the full
+formatting whether or not output is piped.
+
+--cache stores rendered text in .checkpoint/.explain-cache/ and reuses it
+while the config, skills, learnings, changelog and context files are
+unchanged (by size and mtime). --no-cache forces a fresh render.
+
+--json emits the same data as nested JSON whose keys match the YAML files
+(project, tools, guidelines, skills_config, skills, learnings for the full
+context, or just the topic's section). Nested keys are snake_case as in the
+YAML (schema_version, key_paths, ...); before this they were Go field names
+(SchemaVersion, KeyPaths, ...), so update scripts that read the old keys.
+
+'history --json' exports the full history data (checkpoints, next steps
+with their source, patterns, decisions, failed approaches) with full
+commit hashes and the limit applied.
+
+--token-estimate prints an approximate token count (characters / 4) for
+each top-level section and the total to stderr, e.g. to check that
+'explain --full' fits a model's context window. Output is unchanged.
+
+'project --path <prefix|glob>' limits key paths and key files to matching
+paths (e.g. --path internal/ or --path 'cmd/*.go'); '--untracked-only'
+shows only key files with tracked: false.
+
+'history --format <template>' prints one line per checkpoint, like
+'git log --format'. Placeholders: %h short hash, %t timestamp, %s first
+change summary, %T first change type, %c change count, %a commit author,
+%% a literal %. Presets: oneline ("%h %s") and full
+("%h %t [%T] %s (%c changes) %a").
+
+'project --mermaid' emits a Mermaid flowchart with key paths as components
+and integrations as external systems.
+
+'tools --missing' lists detected build/test/lint/format/dev commands that
+are not yet in tools.yaml, with 'checkpoint learn' commands to add them.
+As in doctor, build, test and lint count once their section has a command.
+
+'skills --used' lists skills by how often 'explain skill' and 'skill show'
+have displayed them (tracked in .checkpoint/.skill-usage.json) and flags
+skills that were never viewed.
+
+'guidelines --for <language>' shows only the rules, avoid entries and
+principles tagged for that language with applies_to (e.g.
+'- text: Wrap errors with %w' / '  applies_to: [go]') plus untagged
+entries, which apply everywhere. Use '--for primary' for the primary
+language in project.yaml.
+
+'learnings --tag <t>' shows only learnings tagged <t> (see 'learn --tags').
+
+--redact replaces API keys, tokens, private key headers, emails and any
+regexes listed under 'patterns:' in .checkpoint/redact.yml with
+[REDACTED] (set 'no_defaults: true' to use only your own patterns). It
+applies to every topic and to --json output; the number of redactions is
+reported on stderr.`,
 	Args: cobra.MaximumNArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		projectPath := "."
@@ -39,9 +130,22 @@ Topics: project, tools, guidelines, skills, learnings, skill <name>, history, ne
 		}
 
 		opts := ExplainOptions{
-			Full:     explainOpts.full,
-			Markdown: explainOpts.markdown,
-			JSON:     explainOpts.json,
+			Full:      explainOpts.full,
+			Markdown:  explainOpts.markdown,
+			JSON:      explainOpts.json,
+			AsRules:   explainOpts.asRules,
+			Audience:  explainOpts.audience,
+			Cache:     explainOpts.cache && !explainOpts.noCache,
+			Missing:   explainOpts.missing,
+			Mermaid:   explainOpts.mermaid,
+			Redact:    explainOpts.redact,
+			Used:      explainOpts.used,
+			For:       explainOpts.forLang,
+			Tag:       explainOpts.tag,
+			Format:    explainOpts.format,
+			Tokens:    explainOpts.tokens,
+			Path:      explainOpts.path,
+			Untracked: explainOpts.untracked,
 		}
 		if len(args) > 0 {
 			opts.Topic = args[0]
@@ -53,6 +157,9 @@ Topics: project, tools, guidelines, skills, learnings, skill <name>, history, ne
 	},
 }
 
+// explainHistoryLimit is how many recent checkpoints 'explain history' covers
+const explainHistoryLimit = 10
+
 // ExplainOptions holds flags for the explain command
 type ExplainOptions struct {
 	Topic     string // project, tools, guidelines, skills, skill, history, or empty for summary
@@ -60,15 +167,137 @@ type ExplainOptions struct {
 	Full      bool   // --full flag
 	Markdown  bool   // --md flag
 	JSON      bool   // --json flag
+	AsRules   bool   // --as-rules flag (guidelines only)
+	Audience  string // --audience: human or llm (empty means human)
+	Cache     bool   // --cache flag (text output only)
+	Missing   bool   // --missing flag (tools only)
+	Mermaid   bool   // --mermaid flag (project only)
+	Redact    bool   // --redact flag
+	Used      bool   // --used flag (skills only)
+	For       string // --for language filter (guidelines only)
+	Tag       string // --tag filter (learnings only)
+	Format    string // --format template or preset (history only)
+	Tokens    bool   // --token-estimate: report approximate token counts to stderr
+	Path      string // --path prefix or glob for key paths/files (project only)
+	Untracked bool   // --untracked-only key files (project only)
 }
 
 // Explain displays project context for LLMs and developers
 func Explain(projectPath string, opts ExplainOptions) {
+	audience, err := resolveAudience(opts.Audience)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "hint: use --audience human or --audience llm\n")
+		os.Exit(1)
+	}
+
+	var redactor *explain.Redactor
+	if opts.Redact {
+		redactor, err = explain.LoadRedactor(projectPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			fmt.Fprintf(os.Stderr, "hint: fix the patterns in .checkpoint/%s\n", config.RedactYaml)
+			os.Exit(1)
+		}
+	}
+	// emitText writes text output, redacting it first when requested
+	emitText := func(output string) {
+		if redactor != nil {
+			var n int
+			output, n = redactor.Redact(output)
+			reportRedactions(n)
+		}
+		fmt.Print(output)
+		if opts.Tokens {
+			reportTokenEstimate(output)
+		}
+	}
+	emitJSON := writeJSON
+	if redactor != nil {
+		emitJSON = func(data interface{}) {
+			redacted, n := redactJSON(redactor, data)
+			reportRedactions(n)
+			writeJSON(redacted)
+		}
+	}
+
+	if opts.Missing {
+		if opts.Topic != "tools" {
+			fmt.Fprintf(os.Stderr, "error: --missing only applies to 'explain tools'\n")
+			os.Exit(1)
+		}
+		explainMissingTools(projectPath, opts.JSON)
+		return
+	}
+
+	if opts.Used && opts.Topic != "skills" {
+		fmt.Fprintf(os.Stderr, "error: --used only applies to 'explain skills'\n")
+		os.Exit(1)
+	}
+	if opts.For != "" && opts.Topic != "guidelines" {
+		fmt.Fprintf(os.Stderr, "error: --for only applies to 'explain guidelines'\n")
+		os.Exit(1)
+	}
+	if opts.Tag != "" && opts.Topic != "learnings" {
+		fmt.Fprintf(os.Stderr, "error: --tag only applies to 'explain learnings'\n")
+		os.Exit(1)
+	}
+	if (opts.Path != "" || opts.Untracked) && opts.Topic != "project" {
+		fmt.Fprintf(os.Stderr, "error: --path and --untracked-only only apply to 'explain project'\n")
+		os.Exit(1)
+	}
+	if opts.Tokens && opts.JSON {
+		fmt.Fprintf(os.Stderr, "error: --token-estimate applies to text output, not --json\n")
+		os.Exit(1)
+	}
+	if opts.Format != "" {
+		if opts.Topic != "history" {
+			fmt.Fprintf(os.Stderr, "error: --format only applies to 'explain history'\n")
+			os.Exit(1)
+		}
+		if opts.JSON {
+			fmt.Fprintf(os.Stderr, "error: --format cannot be combined with --json\n")
+			os.Exit(1)
+		}
+		emitText(formatHistory(projectPath, opts.Format))
+		return
+	}
+
+	// Serve an unchanged render from the cache before loading anything
+	var variant, fingerprint string
+	useCache := opts.Cache && !opts.JSON && !opts.Used
+	if useCache {
+		variant = fmt.Sprintf("topic=%s skill=%s full=%t rules=%t md=%t mermaid=%t audience=%s for=%s tag=%s path=%s untracked=%t",
+			opts.Topic, opts.SkillName, opts.Full, opts.AsRules, opts.Markdown, opts.Mermaid, audience, opts.For, opts.Tag, opts.Path, opts.Untracked)
+		fingerprint = explain.SourceFingerprint(projectPath)
+		if cached, ok := explain.ReadCachedRender(projectPath, variant, fingerprint); ok {
+			if name := explainSkillName(opts); name != "" {
+				recordSkillView(projectPath, name)
+			}
+			emitText(cached)
+			return
+		}
+	}
+
 	ctx, err := explain.LoadExplainContext(projectPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error loading context: %v\n", err)
 		os.Exit(1)
 	}
+	if opts.For != "" {
+		language := opts.For
+		if strings.EqualFold(language, "primary") {
+			if ctx.Project == nil || ctx.Project.Languages.Primary == "" {
+				fmt.Fprintf(os.Stderr, "error: no primary language in project.yaml\n")
+				fmt.Fprintf(os.Stderr, "hint: set languages.primary or pass a language, e.g. --for go\n")
+				os.Exit(1)
+			}
+			language = ctx.Project.Languages.Primary
+		}
+		ctx.FilterGuidelines(language)
+	}
+	ctx.FilterLearnings(opts.Tag)
+	ctx.FilterProjectPaths(opts.Path, opts.Untracked)
 
 	var output string
 
@@ -80,13 +309,34 @@ func Explain(projectPath string, opts ExplainOptions) {
 			output = ctx.RenderSummary()
 		}
 	case "project":
-		output = ctx.RenderProject()
+		if opts.Mermaid {
+			output = ctx.RenderProjectMermaid()
+		} else {
+			output = ctx.RenderProject()
+		}
 	case "tools":
 		output = ctx.RenderTools()
 	case "guidelines":
-		output = ctx.RenderGuidelines()
+		if opts.AsRules {
+			output = ctx.RenderGuidelinesAsRules()
+		} else {
+			output = ctx.RenderGuidelines()
+		}
 	case "skills":
-		output = ctx.RenderSkills()
+		if opts.Used {
+			usage, err := explain.LoadSkillUsage(projectPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+			if opts.JSON {
+				emitJSON(map[string]any{"skills": ctx.SkillUsageReport(usage)})
+				return
+			}
+			output = ctx.RenderSkillUsage(usage)
+		} else {
+			output = ctx.RenderSkills()
+		}
 	case "learnings":
 		output = ctx.RenderLearnings()
 	case "skill":
@@ -96,8 +346,11 @@ func Explain(projectPath string, opts ExplainOptions) {
 			os.Exit(1)
 		}
 		output = ctx.RenderSkill(opts.SkillName)
+		if !isSkillNotFound(output) {
+			recordSkillView(projectPath, opts.SkillName)
+		}
 	case "history":
-		output = explain.RenderHistory(projectPath, 10)
+		output = explain.RenderHistory(projectPath, explainHistoryLimit)
 	case "next":
 		output = explain.RenderNext(projectPath)
 	default:
@@ -105,6 +358,7 @@ func Explain(projectPath string, opts ExplainOptions) {
 		skillOutput := ctx.RenderSkill(opts.Topic)
 		if skillOutput != "" && !isSkillNotFound(skillOutput) {
 			output = skillOutput
+			recordSkillView(projectPath, opts.Topic)
 		} else {
 			fmt.Fprintf(os.Stderr, "unknown topic: %s\n", opts.Topic)
 			fmt.Fprintf(os.Stderr, "available: project, tools, guidelines, skills, learnings, skill <name>, history, next\n")
@@ -114,45 +368,167 @@ func Explain(projectPath string, opts ExplainOptions) {
 
 	// Handle output format
 	if opts.JSON {
-		outputJSON(ctx, opts.Topic)
+		if opts.Topic == "guidelines" && opts.AsRules {
+			emitJSON(map[string]any{"rules": ctx.GuidelinesChecklist()})
+			return
+		}
+		if opts.Topic == "history" {
+			history, err := explain.LoadHistory(projectPath, explainHistoryLimit)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error loading history: %v\n", err)
+				os.Exit(1)
+			}
+			emitJSON(history)
+			return
+		}
+		emitJSON(ctx.JSONView(opts.Topic))
 		return
 	}
 
-	fmt.Print(output)
+	if audience == explain.AudienceLLM && !opts.Markdown {
+		output = explain.CompactForLLM(output)
+	}
+	if useCache {
+		if err := explain.WriteCachedRender(projectPath, variant, fingerprint, output); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: could not write explain cache: %v\n", err)
+		}
+	}
+	emitText(output)
 }
 
-func isSkillNotFound(output string) bool {
-	return len(output) > 0 && output[0:5] == "Skill"
+// reportTokenEstimate writes approximate token counts per top-level section
+// and in total to stderr, leaving stdout untouched
+func reportTokenEstimate(output string) {
+	sections := explain.EstimateSectionTokens(output)
+	width := len("Total")
+	for _, s := range sections {
+		if len(s.Section) > width {
+			width = len(s.Section)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "\nToken estimate (~4 chars/token):\n")
+	for _, s := range sections {
+		fmt.Fprintf(os.Stderr, "  %-*s %7d\n", width, s.Section, s.Tokens)
+	}
+	fmt.Fprintf(os.Stderr, "  %-*s %7d\n", width, "Total", explain.EstimateTokens(output))
 }
 
-func outputJSON(ctx *explain.ExplainOutput, topic string) {
-	var data interface{}
+func reportRedactions(n int) {
+	fmt.Fprintf(os.Stderr, "redacted %d match(es)\n", n)
+}
 
-	switch topic {
-	case "project":
-		data = ctx.Project
-	case "tools":
-		data = ctx.Tools
-	case "guidelines":
-		data = ctx.Guidelines
-	case "skills":
-		data = struct {
-			Config *explain.SkillsConfig `json:"config"`
-			Skills []explain.Skill       `json:"skills"`
-		}{ctx.Skills, ctx.SkillDefs}
-	case "learnings":
-		data = ctx.Learnings
+// redactJSON round-trips data through JSON so every string value can be
+// redacted while the output stays valid JSON
+func redactJSON(r *explain.Redactor, data interface{}) (interface{}, int) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return data, 0
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return data, 0
+	}
+	total := 0
+	var walk func(v interface{}) interface{}
+	walk = func(v interface{}) interface{} {
+		switch t := v.(type) {
+		case string:
+			s, n := r.Redact(t)
+			total += n
+			return s
+		case []interface{}:
+			for i := range t {
+				t[i] = walk(t[i])
+			}
+		case map[string]interface{}:
+			for k := range t {
+				t[k] = walk(t[k])
+			}
+		}
+		return v
+	}
+	return walk(generic), total
+}
+
+// resolveAudience validates --audience, defaulting to human
+func resolveAudience(audience string) (string, error) {
+	switch strings.ToLower(audience) {
+	case "", explain.AudienceHuman:
+		return explain.AudienceHuman, nil
+	case explain.AudienceLLM:
+		return explain.AudienceLLM, nil
+	default:
+		return "", fmt.Errorf("unknown audience '%s'", audience)
+	}
+}
+
+func stdinIsTerminal() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func stdoutIsTerminal() bool {
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// explainSkillName returns the skill an explain invocation displays, if any;
+// unknown topics only render (and so only reach the cache) as skill names
+func explainSkillName(opts ExplainOptions) string {
+	switch opts.Topic {
+	case "skill":
+		return opts.SkillName
+	case "", "project", "tools", "guidelines", "skills", "learnings", "history", "next":
+		return ""
 	default:
-		data = struct {
-			Project    *explain.ProjectConfig    `json:"project"`
-			Tools      *explain.ToolsConfig      `json:"tools"`
-			Guidelines *explain.GuidelinesConfig `json:"guidelines"`
-			Skills     *explain.SkillsConfig     `json:"skills_config"`
-			SkillDefs  []explain.Skill           `json:"skills"`
-			Learnings  []explain.Learning        `json:"learnings"`
-		}{ctx.Project, ctx.Tools, ctx.Guidelines, ctx.Skills, ctx.SkillDefs, ctx.Learnings}
+		return opts.Topic
+	}
+}
+
+// recordSkillView counts a skill display for 'explain skills --used' (best effort)
+func recordSkillView(projectPath, name string) {
+	if err := explain.RecordSkillUsage(projectPath, name, time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not record skill usage: %v\n", err)
+	}
+}
+
+func isSkillNotFound(output string) bool {
+	return len(output) > 0 && output[0:5] == "Skill"
+}
+
+// formatHistory renders recent checkpoints one line each with a --format
+// template or preset, exiting on an invalid template
+func formatHistory(projectPath, format string) string {
+	tmpl, err := explain.ResolveHistoryFormat(format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "hint: presets: oneline, full\n")
+		os.Exit(1)
+	}
+	history, err := explain.LoadHistory(projectPath, explainHistoryLimit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error loading history: %v\n", err)
+		os.Exit(1)
 	}
+	withAuthor := explain.HistoryFormatUsesAuthor(tmpl)
+	var sb strings.Builder
+	for _, cp := range history.RecentCheckpoints {
+		author := ""
+		if withAuthor && cp.CommitHash != "" {
+			author, _ = git.GetCommitAuthor(projectPath, cp.CommitHash)
+		}
+		sb.WriteString(explain.FormatCheckpoint(tmpl, cp, author) + "\n")
+	}
+	return sb.String()
+}
 
+func writeJSON(data interface{}) {
 	enc := json.NewEncoder(os.Stdout)
 	enc.SetIndent("", "  ")
 	if err := enc.Encode(data); err != nil {
@@ -160,3 +536,111 @@ func outputJSON(ctx *explain.ExplainOutput, topic string) {
 		os.Exit(1)
 	}
 }
+
+// missingTool is a detected command that tools.yaml does not configure yet
+type missingTool struct {
+	Name         string `json:"name"`
+	Command      string `json:"command"`
+	LearnCommand string `json:"learn_command"`
+}
+
+// findMissingTools returns detected commands tools.yaml does not configure.
+// build, test and lint count as configured when their tools.yaml section has
+// an entry (lint also accepts check), the same rule doctor applies; format
+// and dev count when their command text appears in any section.
+func findMissingTools(tools *explain.ToolsConfig, info *detect.ProjectInfo) []missingTool {
+	configured := map[string]bool{}
+	if tools != nil {
+		for _, section := range []map[string]explain.ToolCommand{
+			tools.Build, tools.Test, tools.Lint, tools.Check, tools.Run, tools.Checkpoint, tools.Maintenance,
+		} {
+			for _, tc := range section {
+				configured[strings.Join(strings.Fields(tc.Command), " ")] = true
+			}
+		}
+	}
+
+	detected := []struct{ name, category, command string }{
+		{"build", "build", info.BuildCmd},
+		{"test", "test", info.TestCmd},
+		{"lint", "lint", info.LintCmd},
+		{"format", "", info.FormatCmd},
+		{"dev", "", info.DevCmd},
+	}
+
+	var missing []missingTool
+	for _, d := range detected {
+		command := strings.Join(strings.Fields(d.command), " ")
+		if command == "" {
+			continue
+		}
+		learn := fmt.Sprintf("checkpoint learn %s --tool-name %s", shellQuote(command), d.name)
+		if d.category != "" {
+			if hasToolCategory(tools, d.category) {
+				continue
+			}
+			learn += " --category " + d.category
+		} else if configured[command] {
+			continue
+		}
+		missing = append(missing, missingTool{
+			Name:         d.name,
+			Command:      command,
+			LearnCommand: learn,
+		})
+	}
+	return missing
+}
+
+// hasToolCategory reports whether tools.yaml configures any command for an
+// essential category: build, test, or lint (lint or check sections)
+func hasToolCategory(tools *explain.ToolsConfig, category string) bool {
+	if tools == nil {
+		return false
+	}
+	switch category {
+	case "build":
+		return len(tools.Build) > 0
+	case "test":
+		return len(tools.Test) > 0
+	case "lint":
+		return len(tools.Lint) > 0 || len(tools.Check) > 0
+	}
+	return false
+}
+
+// shellQuote wraps s in single quotes for copy-paste into a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func explainMissingTools(projectPath string, jsonOutput bool) {
+	ctx, err := explain.LoadExplainContext(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error loading context: %v\n", err)
+		os.Exit(1)
+	}
+	missing := findMissingTools(ctx.Tools, detect.DetectProject(projectPath))
+
+	if jsonOutput {
+		if missing == nil {
+			missing = []missingTool{}
+		}
+		writeJSON(map[string]any{"missing": missing})
+		return
+	}
+
+	if len(missing) == 0 {
+		fmt.Println("All detected commands are configured in tools.yaml.")
+		return
+	}
+
+	fmt.Printf("Detected commands not in tools.yaml (%d):\n\n", len(missing))
+	for _, m := range missing {
+		fmt.Printf("  %-7s %s\n", m.Name, m.Command)
+	}
+	fmt.Println("\nAdd them with:")
+	for _, m := range missing {
+		fmt.Printf("  %s\n", m.LearnCommand)
+	}
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/dmoose/checkpoint/internal/detect"
+	"github.com/dmoose/checkpoint/internal/explain"
+)
+
+func TestFindMissingTools(t *testing.T) {
+	tools := &explain.ToolsConfig{
+		Build:       map[string]explain.ToolCommand{"all": {Command: "make"}},
+		Maintenance: map[string]explain.ToolCommand{"fmt": {Command: "go  fmt ./..."}, "test": {Command: "go test ./..."}},
+	}
+	info := &detect.ProjectInfo{
+		BuildCmd:  "go build ./...",
+		TestCmd:   "go test ./...",
+		FormatCmd: "go fmt ./...",
+	}
+
+	// A test command filed under maintenance does not satisfy doctor
+	missing := findMissingTools(tools, info)
+	if len(missing) != 1 {
+		t.Fatalf("expected 1 missing tool, got %+v", missing)
+	}
+	if missing[0].Name != "test" || missing[0].LearnCommand != "checkpoint learn 'go test ./...' --tool-name test --category test" {
+		t.Errorf("unexpected missing tool: %+v", missing[0])
+	}
+
+	got := findMissingTools(nil, info)
+	if len(got) != 3 {
+		t.Fatalf("expected all detected commands missing without tools.yaml, got %d", len(got))
+	}
+	if got[2].LearnCommand != "checkpoint learn 'go fmt ./...' --tool-name format" {
+		t.Errorf("expected format to go to the default section, got %q", got[2].LearnCommand)
+	}
+}
+
+func TestResolveAudience(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"", explain.AudienceHuman, false},
+		{"human", explain.AudienceHuman, false},
+		{"LLM", explain.AudienceLLM, false},
+		{"robot", "", true},
+	}
+	for _, tt := range tests {
+		got, err := resolveAudience(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("resolveAudience(%q) = %q, %v", tt.in, got, err)
+		}
+	}
+}
@@ -0,0 +1,401 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dmoose/checkpoint/internal/detect"
+	"github.com/dmoose/checkpoint/internal/file"
+	"github.com/dmoose/checkpoint/internal/schema"
+	"github.com/dmoose/checkpoint/pkg/config"
+
+	"github.com/spf13/cobra"
+)
+
+var exportOpts struct {
+	format  string
+	output  string
+	groupBy string
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVar(&exportOpts.format, "format", "md", "Output format: md or json")
+	exportCmd.Flags().StringVarP(&exportOpts.output, "output", "o", "", "Write to file instead of stdout")
+	exportCmd.Flags().StringVar(&exportOpts.groupBy, "group-by", "date", "Markdown sections: date, scope or type (for md)")
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export [path]",
+	Short: "Render the changelog as release notes",
+	Long: `Renders every checkpoint in the changelog (the meta document is skipped).
+
+--format md (default) produces a human-readable CHANGELOG.md: checkpoints are
+grouped by date, newest first, with their changes bulleted under each
+change_type. When the origin remote is a recognizable git host, commit hashes
+link to the commit page.
+
+--group-by changes the Markdown sections:
+  date   one section per day, newest first (the default; release notes)
+  scope  all changes per scope across the whole history (component summaries)
+  type   all changes per change_type across the whole history
+Identical summaries within a section are listed once. With scope and type,
+each change notes the date and commit of its newest checkpoint.
+
+--format json writes the raw array of checkpoint entries, oldest first.
+
+Unlike 'explain', which describes the project for an LLM, export is for
+people reading the history.
+
+Examples:
+  checkpoint export
+  checkpoint export -o CHANGELOG.md
+  checkpoint export --group-by scope
+  checkpoint export --format json`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		projectPath := "."
+		if len(args) > 0 {
+			projectPath = args[0]
+		}
+		absPath, err := filepath.Abs(projectPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: cannot resolve path: %v\n", err)
+			os.Exit(1)
+		}
+		Export(absPath, ExportOptions{
+			Format:  exportOpts.format,
+			Output:  exportOpts.output,
+			GroupBy: exportOpts.groupBy,
+		})
+	},
+}
+
+// ExportOptions holds flags for the export command
+type ExportOptions struct {
+	Format  string // md or json
+	Output  string // --output file (empty for stdout)
+	GroupBy string // date, scope or type (for md; empty means date)
+}
+
+// Export renders the changelog as Markdown release notes or a JSON array
+func Export(projectPath string, opts ExportOptions) {
+	if opts.Format != "md" && opts.Format != "json" {
+		fmt.Fprintf(os.Stderr, "error: invalid --format '%s' (valid: md, json)\n", opts.Format)
+		os.Exit(1)
+	}
+	if err := validateGroupBy(opts.GroupBy); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	changelogPath := filepath.Join(projectPath, config.ChangelogFileName)
+	if !file.Exists(changelogPath) {
+		fmt.Fprintf(os.Stderr, "error: changelog not found at %s\n", changelogPath)
+		fmt.Fprintf(os.Stderr, "hint: run 'checkpoint init' to initialize\n")
+		os.Exit(1)
+	}
+
+	entries, err := loadChangelogEntries(changelogPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: failed to read changelog: %v\n", err)
+		os.Exit(1)
+	}
+
+	var out string
+	if opts.Format == "json" {
+		if entries == nil {
+			entries = []schema.CheckpointEntry{}
+		}
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error encoding JSON: %v\n", err)
+			os.Exit(1)
+		}
+		out = string(data) + "\n"
+	} else {
+		out = renderChangelogMarkdown(entries, detect.RemoteWebURL(detect.DetectGitRemote(projectPath)), opts.GroupBy)
+	}
+
+	if opts.Output == "" {
+		fmt.Print(out)
+		return
+	}
+	if err := os.WriteFile(opts.Output, []byte(out), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "error: cannot write %s: %v\n", opts.Output, err)
+		os.Exit(1)
+	}
+	fmt.Printf("✓ Exported %d checkpoints to %s\n", len(entries), opts.Output)
+}
+
+// changeTypeHeadings titles the built-in change types; project types from
+// schema.yml use their own name
+var changeTypeHeadings = map[string]string{
+	"feature":  "Features",
+	"fix":      "Fixes",
+	"refactor": "Refactoring",
+	"docs":     "Documentation",
+	"perf":     "Performance",
+	"other":    "Other",
+}
+
+// undatedHeading groups checkpoints whose timestamp does not parse
+const undatedHeading = "Undated"
+
+// unscopedHeading groups changes without a scope for --group-by scope
+const unscopedHeading = "Unscoped"
+
+// exportItem is a checkpoint with its parsed timestamp
+type exportItem struct {
+	entry schema.CheckpointEntry
+	ts    time.Time
+	ok    bool // timestamp parsed
+}
+
+// day is the date heading for the checkpoint
+func (it exportItem) day() string {
+	if !it.ok {
+		return undatedHeading
+	}
+	return it.ts.Format("2006-01-02")
+}
+
+// sortExportItems orders entries newest first; undated checkpoints go last
+// in changelog order
+func sortExportItems(entries []schema.CheckpointEntry) []exportItem {
+	items := make([]exportItem, len(entries))
+	for i, e := range entries {
+		ts, err := time.Parse(time.RFC3339, e.Timestamp)
+		items[i] = exportItem{entry: e, ts: ts, ok: err == nil}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].ok != items[j].ok {
+			return items[i].ok
+		}
+		return items[i].ok && items[i].ts.After(items[j].ts)
+	})
+	return items
+}
+
+// validateGroupBy checks a --group-by value
+func validateGroupBy(groupBy string) error {
+	switch groupBy {
+	case "", "date", "scope", "type":
+		return nil
+	}
+	return fmt.Errorf("invalid --group-by '%s' (valid: date, scope, type)", groupBy)
+}
+
+// renderChangelogMarkdown renders entries newest first. groupBy is date
+// (the default: one section per day, with each checkpoint's changes under
+// their change_type), scope or type (one section per scope or change type
+// across the whole history). Identical summaries within a group are listed
+// once. repoURL is the repository web URL used to link commits ("" for no
+// links).
+func renderChangelogMarkdown(entries []schema.CheckpointEntry, repoURL, groupBy string) string {
+	items := sortExportItems(entries)
+
+	var sb strings.Builder
+	sb.WriteString("# Changelog\n")
+	if len(items) == 0 {
+		sb.WriteString("\nNo checkpoints yet.\n")
+		return sb.String()
+	}
+
+	switch groupBy {
+	case "scope":
+		renderExportGroups(&sb, items, repoURL, func(c schema.Change) string {
+			if scope := strings.TrimSpace(c.Scope); scope != "" {
+				return scope
+			}
+			return unscopedHeading
+		}, func(c schema.Change) string {
+			return changeTypeTitle(c.ChangeType) + ": "
+		}, func(a, b string) bool {
+			if (a == unscopedHeading) != (b == unscopedHeading) {
+				return b == unscopedHeading
+			}
+			return a < b
+		})
+	case "type":
+		renderExportGroups(&sb, items, repoURL, func(c schema.Change) string {
+			return changeTypeTitle(c.ChangeType)
+		}, func(c schema.Change) string {
+			if c.Scope != "" {
+				return fmt.Sprintf("**%s:** ", c.Scope)
+			}
+			return ""
+		}, func(a, b string) bool {
+			return changeTypeRank(a) < changeTypeRank(b) || (changeTypeRank(a) == changeTypeRank(b) && a < b)
+		})
+	default:
+		renderExportByDate(&sb, items, repoURL)
+	}
+	return sb.String()
+}
+
+// renderExportByDate writes one section per day and one subsection per
+// checkpoint, with changes bulleted under their change_type
+func renderExportByDate(sb *strings.Builder, items []exportItem, repoURL string) {
+	date := ""
+	var seen map[string]bool
+	for _, it := range items {
+		if day := it.day(); day != date {
+			date = day
+			seen = make(map[string]bool)
+			fmt.Fprintf(sb, "\n## %s\n", day)
+		}
+
+		heading := "Checkpoint"
+		if it.ok {
+			heading = it.ts.Format("15:04")
+		}
+		if hash := it.entry.CommitHash; hash != "" {
+			heading += " — " + commitLink(hash, repoURL)
+		}
+		if it.entry.Tag != "" {
+			heading += fmt.Sprintf(" (%s)", it.entry.Tag)
+		}
+		fmt.Fprintf(sb, "\n### %s\n", heading)
+
+		for _, changeType := range exportChangeTypes(it.entry.Changes) {
+			var bullets []string
+			for _, c := range it.entry.Changes {
+				if c.ChangeType != changeType {
+					continue
+				}
+				key := changeType + "\x00" + summaryKey(c.Summary)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				prefix := ""
+				if c.Scope != "" {
+					prefix = fmt.Sprintf("**%s:** ", c.Scope)
+				}
+				bullets = append(bullets, exportBullet(c, prefix))
+			}
+			if len(bullets) == 0 {
+				continue
+			}
+			fmt.Fprintf(sb, "\n**%s**\n\n", changeTypeTitle(changeType))
+			for _, b := range bullets {
+				sb.WriteString(b + "\n")
+			}
+		}
+	}
+}
+
+// renderExportGroups writes one section per group across all checkpoints,
+// ordered by less. Each bullet notes the date and commit of the newest
+// checkpoint with that summary.
+func renderExportGroups(sb *strings.Builder, items []exportItem, repoURL string, groupOf, prefixOf func(schema.Change) string, less func(a, b string) bool) {
+	groups := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, it := range items {
+		for _, c := range it.entry.Changes {
+			group := groupOf(c)
+			key := group + "\x00" + summaryKey(c.Summary)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			bullet := exportBullet(c, prefixOf(c)) + " — " + it.day()
+			if it.entry.CommitHash != "" {
+				bullet += " " + commitLink(it.entry.CommitHash, repoURL)
+			}
+			groups[group] = append(groups[group], bullet)
+		}
+	}
+
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return less(names[i], names[j]) })
+	for _, name := range names {
+		fmt.Fprintf(sb, "\n## %s\n\n", name)
+		for _, b := range groups[name] {
+			sb.WriteString(b + "\n")
+		}
+	}
+}
+
+// exportBullet formats one change as a Markdown list item
+func exportBullet(c schema.Change, prefix string) string {
+	bullet := "- " + prefix + c.Summary
+	if c.Breaking {
+		bullet += " (BREAKING)"
+	}
+	if len(c.Refs) > 0 {
+		bullet += fmt.Sprintf(" [%s]", strings.Join(c.Refs, ", "))
+	}
+	return bullet
+}
+
+// summaryKey normalizes a summary for duplicate detection
+func summaryKey(summary string) string {
+	return strings.ToLower(strings.Join(strings.Fields(summary), " "))
+}
+
+// changeTypeRank orders change type headings: built-in types first in their
+// usual order, then the rest
+func changeTypeRank(title string) int {
+	for i, t := range schema.DefaultRules().ChangeTypes {
+		if changeTypeHeadings[t] == title {
+			return i
+		}
+	}
+	return len(changeTypeHeadings)
+}
+
+// changeTypeTitle is the heading for a change type
+func changeTypeTitle(changeType string) string {
+	if title := changeTypeHeadings[changeType]; title != "" {
+		return title
+	}
+	if changeType != "" {
+		return changeType
+	}
+	return "Unspecified"
+}
+
+// exportChangeTypes lists the change types present in changes: built-in
+// types in their usual order, then project types alphabetically
+func exportChangeTypes(changes []schema.Change) []string {
+	present := make(map[string]bool)
+	for _, c := range changes {
+		present[c.ChangeType] = true
+	}
+	var types []string
+	for _, t := range schema.DefaultRules().ChangeTypes {
+		if present[t] {
+			types = append(types, t)
+			delete(present, t)
+		}
+	}
+	var extra []string
+	for t := range present {
+		extra = append(extra, t)
+	}
+	sort.Strings(extra)
+	return append(types, extra...)
+}
+
+// commitLink shortens hash and links it to the commit page when the
+// repository URL is known
+func commitLink(hash, repoURL string) string {
+	short := hash
+	if len(short) > 7 {
+		short = short[:7]
+	}
+	if repoURL == "" {
+		return "`" + short + "`"
+	}
+	return fmt.Sprintf("[`%s`](%s/commit/%s)", short, repoURL, hash)
+}
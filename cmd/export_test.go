@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dmoose/checkpoint/internal/schema"
+)
+
+func TestRenderChangelogMarkdown(t *testing.T) {
+	entries := []schema.CheckpointEntry{
+		{
+			Timestamp:  "2025-01-01T09:30:00Z",
+			CommitHash: "0123456789abcdef",
+			Changes: []schema.Change{
+				{Summary: "Fix crash on empty input", ChangeType: "fix", Scope: "cli"},
+				{Summary: "Add export command", ChangeType: "feature", Refs: []string{"#12"}},
+				{Summary: "Wire up CI", ChangeType: "ci"},
+			},
+		},
+		{
+			Timestamp: "2025-01-03T14:00:00Z",
+			Tag:       "v1.0.0",
+			Changes:   []schema.Change{{Summary: "Drop old flag", ChangeType: "refactor", Breaking: true}},
+		},
+		{Timestamp: "yesterday", Changes: []schema.Change{{Summary: "Unknown time", ChangeType: "docs"}}},
+	}
+
+	out := renderChangelogMarkdown(entries, "https://github.com/o/r", "")
+	for _, want := range []string{
+		"# Changelog\n",
+		"## 2025-01-03\n\n### 14:00 (v1.0.0)\n\n**Refactoring**\n\n- Drop old flag (BREAKING)\n",
+		"### 09:30 — [`0123456`](https://github.com/o/r/commit/0123456789abcdef)\n",
+		"**Features**\n\n- Add export command [#12]\n\n**Fixes**\n\n- **cli:** Fix crash on empty input\n\n**ci**\n\n- Wire up CI\n",
+		"## Undated\n\n### Checkpoint\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("markdown missing %q\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "2025-01-03") > strings.Index(out, "2025-01-01") {
+		t.Error("expected newest date first")
+	}
+	if !strings.HasSuffix(out, "- Unknown time\n") {
+		t.Error("expected undated checkpoints last")
+	}
+
+	if out := renderChangelogMarkdown(entries[:1], "", "date"); !strings.Contains(out, "### 09:30 — `0123456`\n") {
+		t.Errorf("expected unlinked hash without a remote\n%s", out)
+	}
+	if out := renderChangelogMarkdown(nil, "", ""); !strings.Contains(out, "No checkpoints yet.") {
+		t.Errorf("expected empty notice, got %q", out)
+	}
+}
+
+func TestRenderChangelogMarkdownGroupBy(t *testing.T) {
+	entries := []schema.CheckpointEntry{
+		{
+			Timestamp:  "2025-01-01T09:00:00Z",
+			CommitHash: "aaaaaaa111",
+			Changes: []schema.Change{
+				{Summary: "Fix flaky test", ChangeType: "fix", Scope: "cli"},
+				{Summary: "Add docs page", ChangeType: "docs"},
+			},
+		},
+		{
+			Timestamp:  "2025-01-02T09:00:00Z",
+			CommitHash: "bbbbbbb222",
+			Changes: []schema.Change{
+				{Summary: "fix  flaky test", ChangeType: "fix", Scope: "cli"},
+				{Summary: "Add export", ChangeType: "feature", Scope: "cli"},
+				{Summary: "Speed up search", ChangeType: "perf", Scope: "api"},
+			},
+		},
+		{
+			Timestamp:  "2025-01-02T08:00:00Z",
+			CommitHash: "ccccccc333",
+			Changes:    []schema.Change{{Summary: "Fix flaky test", ChangeType: "fix", Scope: "cli"}},
+		},
+	}
+
+	byScope := renderChangelogMarkdown(entries, "", "scope")
+	want := "## api\n\n- Performance: Speed up search — 2025-01-02 `bbbbbbb`\n\n" +
+		"## cli\n\n- Fixes: fix  flaky test — 2025-01-02 `bbbbbbb`\n- Features: Add export — 2025-01-02 `bbbbbbb`\n\n" +
+		"## Unscoped\n\n- Documentation: Add docs page — 2025-01-01 `aaaaaaa`\n"
+	if !strings.HasSuffix(byScope, want) {
+		t.Errorf("scope grouping:\n%s\nwant suffix:\n%s", byScope, want)
+	}
+
+	byType := renderChangelogMarkdown(entries, "", "type")
+	for _, want := range []string{"## Features\n", "## Fixes\n\n- **cli:** fix  flaky test — 2025-01-02 `bbbbbbb`\n\n## Documentation", "## Performance\n"} {
+		if !strings.Contains(byType, want) {
+			t.Errorf("type grouping missing %q\n%s", want, byType)
+		}
+	}
+	if strings.Index(byType, "## Features") > strings.Index(byType, "## Performance") {
+		t.Errorf("expected built-in type order\n%s", byType)
+	}
+
+	// The same summary twice on one day is listed once under the date
+	byDate := renderChangelogMarkdown(entries, "", "date")
+	if n := strings.Count(strings.ToLower(byDate), "flaky test"); n != 2 {
+		t.Errorf("expected the duplicate on 2025-01-02 to be dropped, found %d\n%s", n, byDate)
+	}
+	if strings.Contains(byDate, "### 08:00 — `ccccccc`\n\n**Fixes**") {
+		t.Errorf("expected no empty type block for a fully deduplicated checkpoint\n%s", byDate)
+	}
+
+	if err := validateGroupBy("author"); err == nil {
+		t.Error("expected invalid --group-by to be rejected")
+	}
+}
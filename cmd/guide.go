@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/dmoose/checkpoint/internal/file"
@@ -11,15 +12,23 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var guideOpts struct {
+	llmJSON bool
+}
+
 func init() {
 	rootCmd.AddCommand(guideCmd)
+	guideCmd.Flags().BoolVar(&guideOpts.llmJSON, "llm-json", false, "Output the guide as structured JSON steps (llm-workflow)")
 }
 
 var guideCmd = &cobra.Command{
 	Use:   "guide [topic]",
 	Short: "Show detailed guides and documentation",
 	Long: `Displays guide documents from .checkpoint/guides/.
-Topics: first-time-user, llm-workflow, best-practices`,
+Topics: first-time-user, llm-workflow, best-practices
+
+Use --llm-json to get a guide as ordered {step, command, description}
+objects that an agent can follow directly. Available for: llm-workflow`,
 	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		projectPath := "."
@@ -32,10 +41,60 @@ Topics: first-time-user, llm-workflow, best-practices`,
 			fmt.Fprintf(os.Stderr, "error: cannot resolve path: %v\n", err)
 			os.Exit(1)
 		}
+		if guideOpts.llmJSON {
+			GuideJSON(topic)
+			return
+		}
 		Guide(absPath, topic)
 	},
 }
 
+// GuideStep is one machine-actionable step of a structured guide
+type GuideStep struct {
+	Step        int    `json:"step"`
+	Command     string `json:"command,omitempty"` // empty for steps without a checkpoint command
+	Description string `json:"description"`
+}
+
+// structuredGuides holds the guides available as --llm-json steps. They are
+// built in so agents get them even when .checkpoint/guides/ is missing.
+var structuredGuides = map[string][]GuideStep{
+	"llm-workflow": {
+		{Command: "checkpoint start", Description: "Show project status and outstanding next steps; pick the task to work on"},
+		{Command: "checkpoint explain", Description: "Load project context: tools, guidelines, and established patterns"},
+		{Description: "Make the code changes for the task, following project guidelines"},
+		{Command: "checkpoint check", Description: "Generate .checkpoint-input (template) and .checkpoint-diff (full diff)"},
+		{Description: "Fill .checkpoint-input: changes[] with specific summaries, context with the why (decisions, insights, failed approaches), and next_steps[]"},
+		{Command: "checkpoint lint", Description: "Validate the input file and fix any reported issues"},
+		{Command: "checkpoint commit", Description: "After human review, commit the code with the structured checkpoint entry"},
+	},
+}
+
+// GuideJSON prints a structured guide as JSON steps
+func GuideJSON(topic string) {
+	if topic == "" {
+		topic = "llm-workflow"
+	}
+	steps, ok := structuredGuides[topic]
+	if !ok {
+		var names []string
+		for name := range structuredGuides {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(os.Stderr, "error: no structured steps for guide '%s'\n", topic)
+		fmt.Fprintf(os.Stderr, "hint: --llm-json supports: %s\n", strings.Join(names, ", "))
+		os.Exit(1)
+	}
+
+	numbered := make([]GuideStep, len(steps))
+	for i, s := range steps {
+		s.Step = i + 1
+		numbered[i] = s
+	}
+	writeJSON(map[string]any{"guide": topic, "steps": numbered})
+}
+
 // Guide displays guide documents from .checkpoint/guides/
 func Guide(projectPath string, topic string) {
 	guidesDir := filepath.Join(projectPath, ".checkpoint", "guides")
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+// Structured guide commands must stay in sync with the real command tree
+func TestStructuredGuideCommandsExist(t *testing.T) {
+	for guide, steps := range structuredGuides {
+		for i, s := range steps {
+			if s.Description == "" {
+				t.Errorf("%s step %d: description required", guide, i+1)
+			}
+			if s.Command == "" {
+				continue
+			}
+			args := strings.Fields(s.Command)
+			if args[0] != "checkpoint" {
+				t.Errorf("%s step %d: command %q should start with checkpoint", guide, i+1, s.Command)
+				continue
+			}
+			if c, _, err := rootCmd.Find(args[1:]); err != nil || c == rootCmd {
+				t.Errorf("%s step %d: unknown command %q", guide, i+1, s.Command)
+			}
+		}
+	}
+}
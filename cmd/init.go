@@ -9,6 +9,7 @@ import (
 	"github.com/dmoose/checkpoint/internal/changelog"
 	"github.com/dmoose/checkpoint/internal/detect"
 	"github.com/dmoose/checkpoint/internal/file"
+	"github.com/dmoose/checkpoint/internal/git"
 	"github.com/dmoose/checkpoint/internal/project"
 	"github.com/dmoose/checkpoint/internal/templates"
 	"github.com/dmoose/checkpoint/pkg/config"
@@ -19,19 +20,41 @@ import (
 var initOpts struct {
 	template      string
 	listTemplates bool
+	bare          bool
+	gitHook       bool
+	remove        bool
 }
 
 func init() {
 	rootCmd.AddCommand(initCmd)
 	initCmd.Flags().StringVar(&initOpts.template, "template", "", "Use a specific template")
 	initCmd.Flags().BoolVar(&initOpts.listTemplates, "list-templates", false, "List available templates")
+	initCmd.Flags().BoolVar(&initOpts.gitHook, "git-hook", false, "Install a pre-push hook reminding to checkpoint new commits")
+	initCmd.Flags().BoolVar(&initOpts.remove, "remove", false, "Uninstall the pre-push hook (with --git-hook)")
+	initCmd.Flags().BoolVar(&initOpts.bare, "bare", false, "Only create the changelog and .gitignore entries (no .checkpoint/ config)")
 }
 
 var initCmd = &cobra.Command{
 	Use:   "init [path]",
 	Short: "Initialize checkpoint in a project",
 	Long: `Creates .checkpoint/ directory structure and CHECKPOINT.md.
-Auto-detects project language and sets up config files.`,
+Auto-detects project language and sets up config files.
+
+With --bare, only the changelog (with its meta document) and .gitignore
+entries are created, for projects that just want history tracking.
+
+With --git-hook, only a git pre-push hook is installed (nothing else is
+initialized). Before each push it compares HEAD with last_commit_hash in
+.checkpoint-status.yaml and, if there are commits since the last
+checkpoint, reminds you to run 'checkpoint commit'. The reminder does not
+stop the push unless .checkpoint/project.yaml sets:
+
+  hooks:
+    pre_push: block
+
+(re-run 'init --git-hook' after changing it). An existing pre-push shell
+hook is kept and the checkpoint block is appended to it; other hooks are
+left alone with a warning. 'init --git-hook --remove' uninstalls it.`,
 	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		projectPath := "."
@@ -43,7 +66,13 @@ Auto-detects project language and sets up config files.`,
 			fmt.Fprintf(os.Stderr, "error: cannot resolve path: %v\n", err)
 			os.Exit(1)
 		}
-		InitWithOptions(absPath, Version, InitOptions{Template: initOpts.template, ListTemplates: initOpts.listTemplates})
+		InitWithOptions(absPath, Version, InitOptions{
+			Template:      initOpts.template,
+			ListTemplates: initOpts.listTemplates,
+			Bare:          initOpts.bare,
+			GitHook:       initOpts.gitHook,
+			Remove:        initOpts.remove,
+		})
 	},
 }
 
@@ -51,13 +80,33 @@ Auto-detects project language and sets up config files.`,
 type InitOptions struct {
 	Template      string // template name to use
 	ListTemplates bool   // list available templates
+	Bare          bool   // changelog and .gitignore only
+	GitHook       bool   // install (or with Remove, uninstall) the pre-push hook only
+	Remove        bool   // uninstall the pre-push hook
 }
 
 // createDefaultPrompts creates the default prompts.yaml and prompt template files
 // Only creates files that don't already exist
 func createDefaultPrompts(promptsDir string, projectName string) error {
-	created := 0
-	skipped := 0
+	created, skipped, err := writeDefaultPrompts(promptsDir, projectName)
+	if err != nil {
+		return err
+	}
+
+	// Report what was done
+	if created > 0 {
+		fmt.Printf("✓ Created %d prompt file(s)\n", created)
+	}
+	if skipped > 0 {
+		fmt.Printf("  Skipped %d existing prompt file(s)\n", skipped)
+	}
+
+	return nil
+}
+
+// writeDefaultPrompts writes the built-in prompt library into promptsDir,
+// skipping files that exist, and returns how many were created and skipped
+func writeDefaultPrompts(promptsDir string, projectName string) (created, skipped int, err error) {
 
 	// Create prompts.yaml
 	promptsYaml := filepath.Join(promptsDir, "prompts.yaml")
@@ -110,7 +159,7 @@ prompts:
     file: code-review.md
 `
 		if err := file.WriteFile(promptsYaml, promptsContent); err != nil {
-			return fmt.Errorf("failed to create prompts.yaml: %w", err)
+			return created, skipped, fmt.Errorf("failed to create prompts.yaml: %w", err)
 		}
 		created++
 	}
@@ -145,7 +194,7 @@ Let's work on: {{task_description}}
 Check ` + "`.checkpoint-project.yml`" + ` for established patterns and conventions.
 `
 		if err := file.WriteFile(sessionStartPath, sessionStartContent); err != nil {
-			return fmt.Errorf("failed to create session-start.md: %w", err)
+			return created, skipped, fmt.Errorf("failed to create session-start.md: %w", err)
 		}
 		created++
 	}
@@ -228,7 +277,7 @@ Capture **reasoning and decisions**, not just descriptions.
 The "why" is more valuable than the "what".
 `
 		if err := file.WriteFile(fillCheckpointPath, fillCheckpointContent); err != nil {
-			return fmt.Errorf("failed to create fill-checkpoint.md: %w", err)
+			return created, skipped, fmt.Errorf("failed to create fill-checkpoint.md: %w", err)
 		}
 		created++
 	}
@@ -287,7 +336,7 @@ I'll run ` + "`checkpoint check`" + ` and you'll create the checkpoint entry exp
 - What's next
 `
 		if err := file.WriteFile(implementFeaturePath, implementFeatureContent); err != nil {
-			return fmt.Errorf("failed to create implement-feature.md: %w", err)
+			return created, skipped, fmt.Errorf("failed to create implement-feature.md: %w", err)
 		}
 		created++
 	}
@@ -342,7 +391,7 @@ I'll run ` + "`checkpoint check`" + ` and you'll create checkpoint entry explain
 - How to prevent similar bugs
 `
 		if err := file.WriteFile(fixBugPath, fixBugContent); err != nil {
-			return fmt.Errorf("failed to create fix-bug.md: %w", err)
+			return created, skipped, fmt.Errorf("failed to create fix-bug.md: %w", err)
 		}
 		created++
 	}
@@ -400,60 +449,66 @@ Provide feedback as:
 Be specific and constructive.
 `
 		if err := file.WriteFile(codeReviewPath, codeReviewContent); err != nil {
-			return fmt.Errorf("failed to create code-review.md: %w", err)
+			return created, skipped, fmt.Errorf("failed to create code-review.md: %w", err)
 		}
 		created++
 	}
 
-	// Report what was done
-	if created > 0 {
-		fmt.Printf("✓ Created %d prompt file(s)\n", created)
-	}
-	if skipped > 0 {
-		fmt.Printf("  Skipped %d existing prompt file(s)\n", skipped)
+	return created, skipped, nil
+}
+
+// initBare creates only the changelog and project .gitignore entries
+func initBare(projectPath string, version string) {
+	updateProjectGitignore(projectPath)
+
+	changelogPath := filepath.Join(projectPath, config.ChangelogFileName)
+	if !file.Exists(changelogPath) {
+		if err := changelog.InitializeChangelog(changelogPath, version); err != nil {
+			fmt.Fprintf(os.Stderr, "error initializing changelog: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✓ Created %s\n", config.ChangelogFileName)
+	} else {
+		fmt.Printf("  %s already exists (skipped)\n", config.ChangelogFileName)
 	}
 
-	return nil
+	fmt.Printf("\n✓ Bare checkpoint initialization complete\n")
+	fmt.Printf("  Run 'checkpoint init' later to add .checkpoint/ config\n")
+	fmt.Printf("\nNext: Run 'checkpoint check' to create your first checkpoint\n")
+}
+
+// gitignoreEntries are the checkpoint artifacts init keeps out of git
+var gitignoreEntries = []string{
+	".checkpoint-input",
+	".checkpoint-input.json",
+	".checkpoint-diff",
+	".checkpoint-lock",
+	".checkpoint-status.yaml",
+	".checkpoint-session.yaml",
 }
 
-// updateProjectGitignore adds checkpoint artifact entries to the project's .gitignore
+// checkpointDirIgnores are local state files under .checkpoint/ that
+// .checkpoint/.gitignore keeps out of git
+var checkpointDirIgnores = []string{
+	config.LearnHistoryFileName,
+	config.SkillUsageFileName,
+}
+
+// updateProjectGitignore adds any missing checkpoint artifact entries to the
+// project's .gitignore, creating it when absent
 func updateProjectGitignore(projectPath string) {
 	gitignorePath := filepath.Join(projectPath, ".gitignore")
 
-	checkpointEntries := `
-# Checkpoint artifacts (temporary files, not tracked)
-.checkpoint-input
-.checkpoint-diff
-.checkpoint-lock
-.checkpoint-status.yaml
-.checkpoint-session.yaml
-`
-
-	// Check if .gitignore exists
 	existingContent := ""
 	if data, err := os.ReadFile(gitignorePath); err == nil {
 		existingContent = string(data)
 	}
 
-	// Check if checkpoint entries already exist
-	if strings.Contains(existingContent, ".checkpoint-input") {
-		// Already has checkpoint entries
+	missing := missingGitignoreEntries(existingContent, gitignoreEntries)
+	if len(missing) == 0 {
 		return
 	}
-
-	// Append checkpoint entries
-	var newContent string
-	if existingContent == "" {
-		newContent = strings.TrimPrefix(checkpointEntries, "\n")
-	} else {
-		// Ensure there's a newline before our entries
-		if !strings.HasSuffix(existingContent, "\n") {
-			existingContent += "\n"
-		}
-		newContent = existingContent + checkpointEntries
-	}
-
-	if err := os.WriteFile(gitignorePath, []byte(newContent), 0644); err != nil {
+	if _, err := appendGitignoreEntries(gitignorePath, missing); err != nil {
 		fmt.Fprintf(os.Stderr, "warning: could not update .gitignore: %v\n", err)
 		return
 	}
@@ -461,7 +516,7 @@ func updateProjectGitignore(projectPath string) {
 	if existingContent == "" {
 		fmt.Println("✓ Created .gitignore with checkpoint artifacts")
 	} else {
-		fmt.Println("✓ Updated .gitignore with checkpoint artifacts")
+		fmt.Printf("✓ Updated .gitignore with checkpoint artifacts: %s\n", strings.Join(missing, ", "))
 	}
 }
 
@@ -502,9 +557,17 @@ func createAutoDetectedConfigs(checkpointDir string, projectPath string) {
 		sb.WriteString("\narchitecture:\n")
 		sb.WriteString("  # TODO: Describe high-level architecture\n")
 		sb.WriteString("  # pattern: MVC|microservices|monolith|cli|library\n")
-		sb.WriteString("  # key_directories:\n")
-		sb.WriteString("  #   - path: src/\n")
-		sb.WriteString("  #     purpose: Source code\n")
+		if len(info.Workspaces) > 0 {
+			sb.WriteString("  # Workspaces detected in this monorepo\n")
+			sb.WriteString("  key_paths:\n")
+			for _, ws := range info.Workspaces {
+				sb.WriteString(fmt.Sprintf("    %s: \"\" # TODO: Describe this workspace\n", ws))
+			}
+		} else {
+			sb.WriteString("  # key_directories:\n")
+			sb.WriteString("  #   - path: src/\n")
+			sb.WriteString("  #     purpose: Source code\n")
+		}
 
 		if err := file.WriteFile(projectYamlPath, sb.String()); err != nil {
 			fmt.Fprintf(os.Stderr, "warning: could not create project.yaml: %v\n", err)
@@ -617,6 +680,8 @@ func createAutoDetectedConfigs(checkpointDir string, projectPath string) {
 		sb.WriteString("rules:\n")
 		sb.WriteString("  # - Run tests before committing\n")
 		sb.WriteString("  # - All errors need user-friendly messages\n")
+		sb.WriteString("  # - text: Wrap errors with %w   # limit an entry to some languages\n")
+		sb.WriteString("  #   applies_to: [go]            # (see: explain guidelines --for go)\n")
 		sb.WriteString("\n")
 		sb.WriteString("# Anti-patterns to avoid\n")
 		sb.WriteString("avoid:\n")
@@ -709,6 +774,26 @@ func InitWithOptions(projectPath string, version string, opts InitOptions) {
 		ListTemplates()
 		return
 	}
+	if opts.Remove && !opts.GitHook {
+		fmt.Fprintf(os.Stderr, "error: --remove only applies with --git-hook\n")
+		os.Exit(1)
+	}
+	if opts.GitHook {
+		if opts.Bare || opts.Template != "" {
+			fmt.Fprintf(os.Stderr, "error: --git-hook cannot be combined with --bare or --template\n")
+			os.Exit(1)
+		}
+		initGitHook(projectPath, opts.Remove)
+		return
+	}
+	if opts.Bare {
+		if opts.Template != "" {
+			fmt.Fprintf(os.Stderr, "error: --bare cannot be combined with --template\n")
+			os.Exit(1)
+		}
+		initBare(projectPath, version)
+		return
+	}
 	// Create .checkpoint/ directory structure
 	checkpointDir := filepath.Join(projectPath, ".checkpoint")
 	if err := os.MkdirAll(checkpointDir, 0755); err != nil {
@@ -752,7 +837,9 @@ func InitWithOptions(projectPath string, version string, opts InitOptions) {
 	gitignorePath := filepath.Join(checkpointDir, ".gitignore")
 	gitignoreContent := `# Checkpoint directory is tracked
 # This file ensures the directory structure is preserved in git
-`
+
+# Local state (not tracked)
+` + strings.Join(checkpointDirIgnores, "\n") + "\n"
 	if err := file.WriteFile(gitignorePath, gitignoreContent); err != nil {
 		fmt.Fprintf(os.Stderr, "error creating .checkpoint/.gitignore: %v\n", err)
 		os.Exit(1)
@@ -987,3 +1074,135 @@ Customize prompts by editing files in ` + "`.checkpoint/prompts/`" + `.
 	fmt.Printf("  .checkpoint/ directory structure is ready\n")
 	fmt.Printf("\nNext: Run 'checkpoint start' to begin\n")
 }
+
+// Markers delimiting the checkpoint block in a pre-push hook, so it can be
+// appended to an existing hook and removed again without touching the rest
+const (
+	prePushBeginMarker = "# >>> checkpoint pre-push >>>"
+	prePushEndMarker   = "# <<< checkpoint pre-push <<<"
+)
+
+func initGitHook(projectPath string, remove bool) {
+	if ok, _ := git.IsGitRepository(projectPath); !ok {
+		fmt.Fprintf(os.Stderr, "error: %s is not a git repository\n", projectPath)
+		os.Exit(1)
+	}
+	hooksDir, err := git.HooksDir(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	hookPath := filepath.Join(hooksDir, "pre-push")
+
+	var existing string
+	if data, err := os.ReadFile(hookPath); err == nil {
+		existing = string(data)
+	} else if !os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "error reading %s: %v\n", hookPath, err)
+		os.Exit(1)
+	}
+
+	if remove {
+		if !strings.Contains(existing, prePushBeginMarker) {
+			fmt.Println("No checkpoint pre-push hook installed.")
+			return
+		}
+		rest := removePrePushBlock(existing)
+		if strings.TrimSpace(strings.TrimPrefix(rest, "#!/bin/sh")) == "" {
+			err = os.Remove(hookPath)
+		} else {
+			err = os.WriteFile(hookPath, []byte(rest), 0755)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error removing hook: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✓ Removed checkpoint pre-push hook from %s\n", hookPath)
+		return
+	}
+
+	block := strings.EqualFold(strings.TrimSpace(loadProjectSettings(projectPath).Hooks.PrePush), "block")
+	content, err := installPrePushBlock(existing, block)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v; not modifying %s\n", err, hookPath)
+		fmt.Fprintf(os.Stderr, "hint: add the checkpoint reminder to that hook by hand\n")
+		os.Exit(1)
+	}
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "error creating hooks directory: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(hookPath, []byte(content), 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing hook: %v\n", err)
+		os.Exit(1)
+	}
+	mode := "print a reminder"
+	if block {
+		mode = "are blocked"
+	}
+	fmt.Printf("✓ Installed checkpoint pre-push hook in %s\n", hookPath)
+	fmt.Printf("  Pushes with commits since the last checkpoint %s\n", mode)
+}
+
+// installPrePushBlock returns the hook with the checkpoint block added, or
+// replaced when already present. Hooks that are not sh/bash scripts are
+// refused rather than clobbered.
+func installPrePushBlock(existing string, block bool) (string, error) {
+	snippet := prePushSnippet(block)
+	if strings.Contains(existing, prePushBeginMarker) {
+		existing = removePrePushBlock(existing)
+	}
+	if strings.TrimSpace(existing) == "" {
+		return "#!/bin/sh\n" + snippet, nil
+	}
+	firstLine, _, _ := strings.Cut(existing, "\n")
+	if !strings.HasPrefix(firstLine, "#!") || !strings.Contains(firstLine, "sh") {
+		return "", fmt.Errorf("existing pre-push hook is not a shell script")
+	}
+	if !strings.HasSuffix(existing, "\n") {
+		existing += "\n"
+	}
+	return existing + snippet, nil
+}
+
+// removePrePushBlock strips the checkpoint block (and the blank line before it)
+func removePrePushBlock(content string) string {
+	start := strings.Index(content, prePushBeginMarker)
+	end := strings.Index(content, prePushEndMarker)
+	if start < 0 || end < start {
+		return content
+	}
+	end += len(prePushEndMarker)
+	if end < len(content) && content[end] == '\n' {
+		end++
+	}
+	before := strings.TrimRight(content[:start], "\n")
+	if before != "" {
+		before += "\n"
+	}
+	return before + content[end:]
+}
+
+// prePushSnippet is the hook block comparing HEAD with the last checkpoint commit
+func prePushSnippet(block bool) string {
+	action := "echo \"checkpoint: run 'checkpoint check' and 'checkpoint commit' to record them\" >&2"
+	if block {
+		action = "echo \"checkpoint: push blocked (hooks.pre_push: block); checkpoint first or push with --no-verify\" >&2\n\t\t\texit 1"
+	}
+	return fmt.Sprintf(`
+%s
+# Installed by 'checkpoint init --git-hook'; remove with 'checkpoint init --git-hook --remove'
+checkpoint_status="$(git rev-parse --show-toplevel)/%s"
+if [ -f "$checkpoint_status" ]; then
+	checkpoint_last=$(sed -n 's/^last_commit_hash: *"\{0,1\}\([0-9a-f]*\).*/\1/p' "$checkpoint_status" | head -n 1)
+	if [ -n "$checkpoint_last" ] && git cat-file -e "$checkpoint_last^{commit}" 2>/dev/null; then
+		checkpoint_new=$(git rev-list --count "$checkpoint_last..HEAD")
+		if [ "$checkpoint_new" -gt 0 ]; then
+			echo "checkpoint: $checkpoint_new commit(s) since the last checkpoint" >&2
+			%s
+		fi
+	fi
+fi
+%s
+`, prePushBeginMarker, config.StatusFileName, action, prePushEndMarker)
+}
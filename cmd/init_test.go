@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInstallPrePushBlock(t *testing.T) {
+	fresh, err := installPrePushBlock("", false)
+	if err != nil {
+		t.Fatalf("install into empty hook: %v", err)
+	}
+	if !strings.HasPrefix(fresh, "#!/bin/sh\n") || !strings.Contains(fresh, prePushBeginMarker) || strings.Contains(fresh, "exit 1") {
+		t.Errorf("unexpected fresh hook:\n%s", fresh)
+	}
+	if rest := removePrePushBlock(fresh); rest != "#!/bin/sh\n" {
+		t.Errorf("remove from fresh hook left %q", rest)
+	}
+
+	existing := "#!/bin/bash\necho existing"
+	appended, err := installPrePushBlock(existing, false)
+	if err != nil {
+		t.Fatalf("append to shell hook: %v", err)
+	}
+	// Re-installing replaces the block instead of adding a second one
+	blocking, err := installPrePushBlock(appended, true)
+	if err != nil {
+		t.Fatalf("reinstall: %v", err)
+	}
+	if strings.Count(blocking, prePushBeginMarker) != 1 || !strings.Contains(blocking, "exit 1") {
+		t.Errorf("expected a single blocking block:\n%s", blocking)
+	}
+	if rest := removePrePushBlock(blocking); rest != existing+"\n" {
+		t.Errorf("remove left %q, want original hook", rest)
+	}
+
+	if _, err := installPrePushBlock("#!/usr/bin/env python3\nprint('hi')\n", false); err == nil {
+		t.Error("expected a non-shell hook to be refused")
+	}
+}
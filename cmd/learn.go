@@ -8,8 +8,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/dmoose/checkpoint/internal/context"
 	"github.com/dmoose/checkpoint/internal/explain"
 	"github.com/dmoose/checkpoint/internal/file"
+	"github.com/dmoose/checkpoint/internal/project"
 	"github.com/dmoose/checkpoint/pkg/config"
 
 	"github.com/spf13/cobra"
@@ -23,27 +25,92 @@ var learnOpts struct {
 	principle bool
 	pattern   bool
 	toolName  string
+	category  string
 	list      bool
 	json      bool
+	promote   string
+	importMD  string
+	dryRun    bool
+	remove    string
+	removeIdx int
+	editIdx   int
+	tags      []string
+	scope     string
+	ref       string
+}
+
+var learnRemoveOpts struct {
+	guideline bool
+	tool      bool
+	avoid     bool
+	principle bool
+	pattern   bool
 }
 
 func init() {
 	rootCmd.AddCommand(learnCmd)
+	learnCmd.AddCommand(learnUndoCmd)
+	learnCmd.AddCommand(learnRemoveCmd)
 	learnCmd.Flags().BoolVar(&learnOpts.guideline, "guideline", false, "Add as a rule to follow")
 	learnCmd.Flags().BoolVar(&learnOpts.tool, "tool", false, "Add as a tool command")
 	learnCmd.Flags().BoolVar(&learnOpts.avoid, "avoid", false, "Add as an anti-pattern to avoid")
 	learnCmd.Flags().BoolVar(&learnOpts.principle, "principle", false, "Add as a design principle")
 	learnCmd.Flags().BoolVar(&learnOpts.pattern, "pattern", false, "Add as an established pattern")
 	learnCmd.Flags().StringVar(&learnOpts.toolName, "tool-name", "", "Tool name when adding a tool")
+	learnCmd.Flags().StringVar(&learnOpts.category, "category", "", "Tools section for --tool: build, test, lint, check, run, or maintenance (default)")
 	learnCmd.Flags().BoolVar(&learnOpts.list, "list", false, "List all learnings")
 	learnCmd.Flags().BoolVar(&learnOpts.json, "json", false, "Output as JSON (with --list)")
+	learnCmd.Flags().StringVar(&learnOpts.promote, "promote", "", "Promote a checkpoint-scoped context item into project memory (as insight, or --pattern/--principle)")
+	learnCmd.Flags().StringVar(&learnOpts.importMD, "import", "", "Import bulleted rules, avoids, principles and patterns from a markdown file")
+	learnCmd.Flags().BoolVar(&learnOpts.dryRun, "dry-run", false, "Preview what --import would add without writing")
+	learnCmd.Flags().StringVar(&learnOpts.remove, "remove", "", "Remove the guideline entry with this exact text (narrow with a type flag)")
+	learnCmd.Flags().IntVar(&learnOpts.removeIdx, "remove-index", 0, "Remove the Nth entry (1-based) of the section chosen by a type flag")
+	learnCmd.Flags().StringSliceVar(&learnOpts.tags, "tags", nil, "Tags for a learning, comma-separated (e.g. --tags db,perf)")
+	learnCmd.Flags().StringVar(&learnOpts.scope, "scope", "", "Component or area a learning applies to")
+	learnCmd.Flags().StringVar(&learnOpts.ref, "ref", "", "Commit a learning relates to")
+	learnCmd.Flags().IntVar(&learnOpts.editIdx, "edit-index", 0, "Replace the text of the Nth entry (1-based) of the section chosen by a type flag")
+	learnRemoveCmd.Flags().BoolVar(&learnRemoveOpts.guideline, "guideline", false, "Remove a rule")
+	learnRemoveCmd.Flags().BoolVar(&learnRemoveOpts.tool, "tool", false, "Remove a tool by name")
+	learnRemoveCmd.Flags().BoolVar(&learnRemoveOpts.avoid, "avoid", false, "Remove an anti-pattern")
+	learnRemoveCmd.Flags().BoolVar(&learnRemoveOpts.principle, "principle", false, "Remove a design principle")
+	learnRemoveCmd.Flags().BoolVar(&learnRemoveOpts.pattern, "pattern", false, "Remove an established pattern")
 }
 
 var learnCmd = &cobra.Command{
 	Use:   "learn [content]",
 	Short: "Capture knowledge during development",
 	Long: `Add learnings, guidelines, patterns, or tools to project knowledge base.
-Use --list to view all captured learnings.`,
+Use --list to view all captured learnings.
+
+Plain learnings accept --tags a,b, --scope <area> and --ref <commit>,
+stored with the entry in learnings.yml. Filter by tag with
+'checkpoint explain learnings --tag <t>'.
+Use 'learn undo' to remove the most recent entry, or 'learn remove' for a specific one.
+
+Use --promote "<text>" to move a checkpoint-scoped item from recent context
+into the project document. It lands as a key insight by default, or as an
+established pattern or design principle with --pattern or --principle.
+
+Use --import <file.md> to seed guidelines from an existing document such as
+CONTRIBUTING.md. Top-level bullets under headings mentioning rules or
+guidelines, avoid (or don't / anti-patterns), principles, or patterns are
+added to the matching section of guidelines.yaml; entries already present
+are skipped. Add --dry-run to preview.
+
+Tools land in the maintenance section of tools.yaml unless --category
+names another: build, test, lint, check, or run. Doctor and
+'explain tools' read those sections, so a test command belongs under
+--category test.
+
+Fix entries without editing YAML by hand:
+  --remove "<exact text>"     remove a rule, avoid, principle or pattern;
+                              a type flag limits the search to that section
+  --remove-index N            remove the Nth entry of the section chosen by
+                              --guideline, --avoid, --principle or --pattern
+  --edit-index N "<new text>" replace the Nth entry's text (language tags
+                              are kept)
+Indexes follow the order shown by 'checkpoint explain guidelines'; patterns
+are stored among the design principles and share their numbering.`,
 	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		projectPath := "."
@@ -60,8 +127,18 @@ Use --list to view all captured learnings.`,
 			Principle: learnOpts.principle,
 			Pattern:   learnOpts.pattern,
 			ToolName:  learnOpts.toolName,
+			Category:  learnOpts.category,
 			List:      learnOpts.list,
 			JSON:      learnOpts.json,
+			Promote:   learnOpts.promote,
+			Import:    learnOpts.importMD,
+			DryRun:    learnOpts.dryRun,
+			Remove:    learnOpts.remove,
+			RemoveIdx: learnOpts.removeIdx,
+			EditIdx:   learnOpts.editIdx,
+			Tags:      learnOpts.tags,
+			Scope:     learnOpts.scope,
+			Ref:       learnOpts.ref,
 		}
 		if len(args) > 0 {
 			opts.Content = args[0]
@@ -70,17 +147,77 @@ Use --list to view all captured learnings.`,
 	},
 }
 
+var learnUndoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Remove the most recently added learning, guideline, or tool",
+	Long: `Removes the last entry added via 'checkpoint learn' from whichever file it went to.
+Actions are tracked in .checkpoint/.learn-history.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		absPath, err := filepath.Abs(".")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: cannot resolve path: %v\n", err)
+			os.Exit(1)
+		}
+		LearnUndo(absPath)
+	},
+}
+
+var learnRemoveCmd = &cobra.Command{
+	Use:   "remove <content>",
+	Short: "Remove a specific learning, guideline, or tool",
+	Long: `Removes an entry by exact text. Use a type flag to select the store;
+without one, the learnings log is searched.
+
+Examples:
+  checkpoint learn remove --guideline "Always validate input"
+  checkpoint learn remove --avoid "Global mutable state"
+  checkpoint learn remove --tool race`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		absPath, err := filepath.Abs(".")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: cannot resolve path: %v\n", err)
+			os.Exit(1)
+		}
+		kind := learnKindLearning
+		switch {
+		case learnRemoveOpts.guideline:
+			kind = learnKindGuideline
+		case learnRemoveOpts.avoid:
+			kind = learnKindAvoid
+		case learnRemoveOpts.principle:
+			kind = learnKindPrinciple
+		case learnRemoveOpts.pattern:
+			kind = learnKindPattern
+		case learnRemoveOpts.tool:
+			kind = learnKindTool
+		}
+		LearnRemove(absPath, kind, args[0])
+	},
+}
+
 // LearnOptions holds flags for the learn command
 type LearnOptions struct {
-	Content   string // The content to learn
-	Guideline bool   // Add as a guideline rule
-	Tool      bool   // Add as a tool
-	Avoid     bool   // Add as an anti-pattern
-	Principle bool   // Add as a design principle
-	Pattern   bool   // Add as a pattern
-	ToolName  string // Tool name when adding a tool
-	List      bool   // List all learnings
-	JSON      bool   // Output as JSON
+	Content   string   // The content to learn
+	Guideline bool     // Add as a guideline rule
+	Tool      bool     // Add as a tool
+	Avoid     bool     // Add as an anti-pattern
+	Principle bool     // Add as a design principle
+	Pattern   bool     // Add as a pattern
+	ToolName  string   // Tool name when adding a tool
+	Category  string   // tools.yaml section for a tool (default maintenance)
+	List      bool     // List all learnings
+	JSON      bool     // Output as JSON
+	Promote   string   // Context item text to promote into project memory
+	Import    string   // Markdown file to import guidelines from
+	DryRun    bool     // Preview --import without writing
+	Remove    string   // Exact text of a guideline entry to remove
+	RemoveIdx int      // 1-based index of a guideline entry to remove
+	EditIdx   int      // 1-based index of a guideline entry to replace with Content
+	Tags      []string // tags for a plain learning
+	Scope     string   // scope for a plain learning
+	Ref       string   // related commit for a plain learning
 }
 
 // Learn captures knowledge during development
@@ -90,6 +227,18 @@ func Learn(projectPath string, opts LearnOptions) {
 		listLearnings(projectPath, opts.JSON)
 		return
 	}
+	if opts.Promote != "" {
+		promoteLearning(projectPath, opts)
+		return
+	}
+	if opts.Import != "" {
+		importGuidelines(projectPath, opts.Import, opts.DryRun)
+		return
+	}
+	if opts.Remove != "" || opts.RemoveIdx != 0 || opts.EditIdx != 0 {
+		editGuidelines(projectPath, opts)
+		return
+	}
 	if opts.Content == "" {
 		fmt.Fprintf(os.Stderr, "error: content required\n")
 		fmt.Fprintf(os.Stderr, "usage: checkpoint learn <content> [flags]\n")
@@ -98,11 +247,13 @@ func Learn(projectPath string, opts LearnOptions) {
 		fmt.Fprintf(os.Stderr, "  --avoid         Add as an anti-pattern to avoid\n")
 		fmt.Fprintf(os.Stderr, "  --principle     Add as a design principle\n")
 		fmt.Fprintf(os.Stderr, "  --pattern       Add as an established pattern\n")
-		fmt.Fprintf(os.Stderr, "  --tool <name>   Add as a tool command\n")
+		fmt.Fprintf(os.Stderr, "  --tool          Add as a tool command\n")
+		fmt.Fprintf(os.Stderr, "  --tool-name <n> Tool name (implies --tool)\n")
+		fmt.Fprintf(os.Stderr, "  --category <c>  Tools section: %s\n", strings.Join(toolCategories, ", "))
 		fmt.Fprintf(os.Stderr, "\nExamples:\n")
 		fmt.Fprintf(os.Stderr, "  checkpoint learn \"Always validate input at API boundaries\" --guideline\n")
 		fmt.Fprintf(os.Stderr, "  checkpoint learn \"Don't use global mutable state\" --avoid\n")
-		fmt.Fprintf(os.Stderr, "  checkpoint learn \"make test-race\" --tool race\n")
+		fmt.Fprintf(os.Stderr, "  checkpoint learn \"make test-race\" --tool-name race --category test\n")
 		os.Exit(1)
 	}
 
@@ -115,6 +266,24 @@ func Learn(projectPath string, opts LearnOptions) {
 		os.Exit(1)
 	}
 
+	isTool := opts.Tool || opts.ToolName != ""
+	if (len(opts.Tags) > 0 || opts.Scope != "" || opts.Ref != "") && learnKindFromOptions(opts) != "" {
+		fmt.Fprintf(os.Stderr, "error: --tags, --scope and --ref only apply to plain learnings\n")
+		fmt.Fprintf(os.Stderr, "hint: drop --guideline/--avoid/--principle/--pattern/--tool to capture a learning\n")
+		os.Exit(1)
+	}
+	if opts.Category != "" {
+		if !isTool {
+			fmt.Fprintf(os.Stderr, "error: --category only applies to tools\n")
+			fmt.Fprintf(os.Stderr, "hint: add --tool, e.g. checkpoint learn \"go test -race ./...\" --tool --tool-name race --category test\n")
+			os.Exit(1)
+		}
+		if !isToolCategory(opts.Category) {
+			fmt.Fprintf(os.Stderr, "error: unknown tool category '%s' (valid: %s)\n", opts.Category, strings.Join(toolCategories, ", "))
+			os.Exit(1)
+		}
+	}
+
 	var err error
 	switch {
 	case opts.Guideline:
@@ -125,11 +294,16 @@ func Learn(projectPath string, opts LearnOptions) {
 		err = addPrinciple(checkpointDir, opts.Content)
 	case opts.Pattern:
 		err = addPattern(checkpointDir, opts.Content)
-	case opts.Tool || opts.ToolName != "":
-		err = addTool(checkpointDir, opts.ToolName, opts.Content)
+	case isTool:
+		err = addTool(checkpointDir, opts.Category, opts.ToolName, opts.Content)
 	default:
 		// Default: add to learnings log
-		err = addLearning(checkpointDir, opts.Content)
+		err = addLearningEntry(checkpointDir, explain.Learning{
+			Learning: opts.Content,
+			Tags:     opts.Tags,
+			Scope:    strings.TrimSpace(opts.Scope),
+			Ref:      strings.TrimSpace(opts.Ref),
+		})
 	}
 
 	if err != nil {
@@ -151,19 +325,18 @@ func addGuideline(checkpointDir, content string) error {
 	guidelines.SchemaVersion = "1"
 
 	// Check if already exists
-	for _, rule := range guidelines.Rules {
-		if rule == content {
-			fmt.Printf("Rule already exists: %s\n", content)
-			return nil
-		}
+	if guidelines.Rules.Contains(content) {
+		fmt.Printf("Rule already exists: %s\n", content)
+		return nil
 	}
 
-	guidelines.Rules = append(guidelines.Rules, content)
+	guidelines.Rules = append(guidelines.Rules, explain.GuidelineItem{Text: content})
 
 	if err := writeGuidelinesFile(guidelinesPath, &guidelines); err != nil {
 		return err
 	}
 
+	recordLearnAction(checkpointDir, learnAction{Kind: learnKindGuideline, Content: content})
 	fmt.Printf("✓ Added rule: %s\n", content)
 	return nil
 }
@@ -181,19 +354,18 @@ func addAvoid(checkpointDir, content string) error {
 	guidelines.SchemaVersion = "1"
 
 	// Check if already exists
-	for _, item := range guidelines.Avoid {
-		if item == content {
-			fmt.Printf("Anti-pattern already exists: %s\n", content)
-			return nil
-		}
+	if guidelines.Avoid.Contains(content) {
+		fmt.Printf("Anti-pattern already exists: %s\n", content)
+		return nil
 	}
 
-	guidelines.Avoid = append(guidelines.Avoid, content)
+	guidelines.Avoid = append(guidelines.Avoid, explain.GuidelineItem{Text: content})
 
 	if err := writeGuidelinesFile(guidelinesPath, &guidelines); err != nil {
 		return err
 	}
 
+	recordLearnAction(checkpointDir, learnAction{Kind: learnKindAvoid, Content: content})
 	fmt.Printf("✓ Added anti-pattern: %s\n", content)
 	return nil
 }
@@ -211,19 +383,18 @@ func addPrinciple(checkpointDir, content string) error {
 	guidelines.SchemaVersion = "1"
 
 	// Check if already exists
-	for _, p := range guidelines.Principles {
-		if p == content {
-			fmt.Printf("Principle already exists: %s\n", content)
-			return nil
-		}
+	if guidelines.Principles.Contains(content) {
+		fmt.Printf("Principle already exists: %s\n", content)
+		return nil
 	}
 
-	guidelines.Principles = append(guidelines.Principles, content)
+	guidelines.Principles = append(guidelines.Principles, explain.GuidelineItem{Text: content})
 
 	if err := writeGuidelinesFile(guidelinesPath, &guidelines); err != nil {
 		return err
 	}
 
+	recordLearnAction(checkpointDir, learnAction{Kind: learnKindPrinciple, Content: content})
 	fmt.Printf("✓ Added principle: %s\n", content)
 	return nil
 }
@@ -246,24 +417,63 @@ func addPattern(checkpointDir, content string) error {
 
 	// For simplicity, add patterns to principles with a prefix
 	patternContent := fmt.Sprintf("Pattern: %s", content)
-	for _, p := range guidelines.Principles {
-		if p == patternContent || p == content {
-			fmt.Printf("Pattern already exists: %s\n", content)
-			return nil
-		}
+	if guidelines.Principles.Contains(patternContent) || guidelines.Principles.Contains(content) {
+		fmt.Printf("Pattern already exists: %s\n", content)
+		return nil
 	}
 
-	guidelines.Principles = append(guidelines.Principles, patternContent)
+	guidelines.Principles = append(guidelines.Principles, explain.GuidelineItem{Text: patternContent})
 
 	if err := writeGuidelinesFile(guidelinesPath, &guidelines); err != nil {
 		return err
 	}
 
+	recordLearnAction(checkpointDir, learnAction{Kind: learnKindPattern, Content: content})
 	fmt.Printf("✓ Added pattern: %s\n", content)
 	return nil
 }
 
-func addTool(checkpointDir, name, command string) error {
+// toolCategories lists the tools.yaml sections 'learn --tool' can write to
+var toolCategories = []string{"build", "test", "lint", "check", "run", "maintenance"}
+
+// defaultToolCategory is where tools land without --category
+const defaultToolCategory = "maintenance"
+
+func isToolCategory(category string) bool {
+	for _, c := range toolCategories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// toolCategoryMap returns the tools.yaml section for category, or nil when unknown
+func toolCategoryMap(tools *explain.ToolsConfig, category string) *map[string]explain.ToolCommand {
+	switch category {
+	case "build":
+		return &tools.Build
+	case "test":
+		return &tools.Test
+	case "lint":
+		return &tools.Lint
+	case "check":
+		return &tools.Check
+	case "run":
+		return &tools.Run
+	case "maintenance":
+		return &tools.Maintenance
+	}
+	return nil
+}
+
+func addTool(checkpointDir, category, name, command string) error {
+	if category == "" {
+		category = defaultToolCategory
+	}
+	if !isToolCategory(category) {
+		return fmt.Errorf("unknown tool category '%s' (valid: %s)", category, strings.Join(toolCategories, ", "))
+	}
 	if name == "" {
 		// Try to extract name from command
 		parts := strings.Fields(command)
@@ -285,16 +495,18 @@ func addTool(checkpointDir, name, command string) error {
 	}
 	tools.SchemaVersion = "1"
 
-	// Add to maintenance section (most likely place for custom tools)
-	if tools.Maintenance == nil {
-		tools.Maintenance = make(map[string]explain.ToolCommand)
+	section := toolCategoryMap(&tools, category)
+	if *section == nil {
+		*section = make(map[string]explain.ToolCommand)
 	}
 
-	if _, exists := tools.Maintenance[name]; exists {
-		fmt.Printf("Tool '%s' already exists, updating...\n", name)
+	action := learnAction{Kind: learnKindTool, Name: name, Content: command, Category: category}
+	if existing, exists := (*section)[name]; exists {
+		fmt.Printf("Tool '%s' already exists in %s, updating...\n", name, category)
+		action.Previous = existing.Command
 	}
 
-	tools.Maintenance[name] = explain.ToolCommand{
+	(*section)[name] = explain.ToolCommand{
 		Command: command,
 		Notes:   fmt.Sprintf("Added via 'checkpoint learn' on %s", time.Now().Format("2006-01-02")),
 	}
@@ -303,17 +515,26 @@ func addTool(checkpointDir, name, command string) error {
 		return err
 	}
 
-	fmt.Printf("✓ Added tool '%s': %s\n", name, command)
+	recordLearnAction(checkpointDir, action)
+	fmt.Printf("✓ Added %s tool '%s': %s\n", category, name, command)
 	return nil
 }
 
 func addLearning(checkpointDir, content string) error {
+	return addLearningEntry(checkpointDir, explain.Learning{Learning: content})
+}
+
+// addLearningEntry appends a learning with optional tags, scope and ref
+func addLearningEntry(checkpointDir string, l explain.Learning) error {
 	// Add to a learnings.yml file (append-only log)
-	learningsPath := filepath.Join(checkpointDir, "learnings.yml")
+	learningsPath := filepath.Join(checkpointDir, config.LearningsFileName)
 
-	entry := fmt.Sprintf("---\ntimestamp: %s\nlearning: %s\n",
-		time.Now().Format(time.RFC3339),
-		content)
+	l.Timestamp = time.Now().Format(time.RFC3339)
+	l.Tags = explain.NormalizeTags(l.Tags)
+	data, err := yaml.Marshal(&l)
+	if err != nil {
+		return fmt.Errorf("marshal learning: %w", err)
+	}
 
 	f, err := os.OpenFile(learningsPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
@@ -321,15 +542,266 @@ func addLearning(checkpointDir, content string) error {
 	}
 	defer func() { _ = f.Close() }()
 
-	if _, err := f.WriteString(entry); err != nil {
+	if _, err := f.WriteString("---\n" + string(data)); err != nil {
 		return fmt.Errorf("write learning: %w", err)
 	}
 
+	content := l.Learning
+	recordLearnAction(checkpointDir, learnAction{Kind: learnKindLearning, Content: content})
 	fmt.Printf("✓ Captured learning: %s\n", content)
+	if len(l.Tags) > 0 {
+		fmt.Printf("  tags: %s\n", strings.Join(l.Tags, ", "))
+	}
 	fmt.Printf("  (saved to .checkpoint/learnings.yml)\n")
 	return nil
 }
 
+// promoteLearning moves a matching checkpoint-scoped context item into the
+// project document and marks it project-scoped in the context file
+func promoteLearning(projectPath string, opts LearnOptions) {
+	projectFilePath := filepath.Join(projectPath, config.ProjectFileName)
+	if _, err := os.Stat(projectFilePath); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "error: %s not found\n", config.ProjectFileName)
+		fmt.Fprintf(os.Stderr, "hint: Run 'checkpoint init' first\n")
+		os.Exit(1)
+	}
+
+	contextPath := filepath.Join(projectPath, config.ContextFileName)
+	item, err := context.PromoteToProjectScope(contextPath, opts.Promote)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	if item == nil {
+		fmt.Fprintf(os.Stderr, "error: no checkpoint-scoped context item matches: %s\n", opts.Promote)
+		fmt.Fprintf(os.Stderr, "hint: Run 'checkpoint search' to find the exact wording\n")
+		os.Exit(1)
+	}
+
+	section := "key insight"
+	err = project.UpdateProjectDocument(projectFilePath, func(doc *project.ProjectDocument) {
+		switch {
+		case opts.Pattern:
+			section = "established pattern"
+			for _, p := range doc.EstablishedPatterns {
+				if p.Pattern == item.Text {
+					return
+				}
+			}
+			doc.EstablishedPatterns = append(doc.EstablishedPatterns, project.Pattern{
+				Pattern:   item.Text,
+				Rationale: item.Rationale,
+				Examples:  item.Examples,
+			})
+		case opts.Principle:
+			section = "design principle"
+			for _, p := range doc.DesignPrinciples {
+				if p.Principle == item.Text {
+					return
+				}
+			}
+			doc.DesignPrinciples = append(doc.DesignPrinciples, project.Principle{
+				Principle: item.Text,
+				Rationale: item.Rationale,
+			})
+		default:
+			for _, i := range doc.KeyInsights {
+				if i.Insight == item.Text {
+					return
+				}
+			}
+			doc.KeyInsights = append(doc.KeyInsights, project.Insight{
+				Insight:   item.Text,
+				Rationale: item.Rationale,
+			})
+		}
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✓ Promoted %s to project %s: %s\n", strings.ReplaceAll(item.Kind, "_", " "), section, item.Text)
+	fmt.Printf("  (from checkpoint %s; scope set to project)\n", item.Timestamp)
+}
+
+// importedGuideline is a bullet found under a recognized markdown heading
+type importedGuideline struct {
+	Kind    string // learnKindGuideline, learnKindAvoid, learnKindPrinciple, or learnKindPattern
+	Content string
+}
+
+// importHeadingKind maps a markdown heading to a guideline kind, or "" when
+// the heading is not one import understands
+func importHeadingKind(heading string) string {
+	h := strings.ToLower(heading)
+	has := func(words ...string) bool {
+		for _, w := range words {
+			if strings.Contains(h, w) {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case has("avoid", "don't", "do not", "anti-pattern", "antipattern"):
+		return learnKindAvoid
+	case has("principle"):
+		return learnKindPrinciple
+	case has("pattern"):
+		return learnKindPattern
+	case has("rule", "guideline"):
+		return learnKindGuideline
+	}
+	return ""
+}
+
+// parseGuidelineImport collects top-level bullets under recognized headings.
+// Indented continuation lines are joined onto their bullet; nested bullets
+// and fenced code blocks are ignored.
+func parseGuidelineImport(content string) []importedGuideline {
+	var items []importedGuideline
+	kind := ""
+	inFence := false
+	continuing := false
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continuing = false
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "#") {
+			kind = importHeadingKind(strings.TrimLeft(trimmed, "# "))
+			continuing = false
+			continue
+		}
+		if kind == "" || trimmed == "" {
+			continuing = false
+			continue
+		}
+
+		indented := line[0] == ' ' || line[0] == '\t'
+		text, isBullet := markdownBullet(trimmed)
+		switch {
+		case isBullet && !indented:
+			if text != "" {
+				items = append(items, importedGuideline{Kind: kind, Content: text})
+				continuing = true
+			}
+		case indented && !isBullet && continuing:
+			items[len(items)-1].Content += " " + trimmed
+		default:
+			continuing = false
+		}
+	}
+	return items
+}
+
+// markdownBullet returns the text of a "-", "*", "+" or "1." list item
+func markdownBullet(line string) (string, bool) {
+	for _, marker := range []string{"- ", "* ", "+ "} {
+		if strings.HasPrefix(line, marker) {
+			return strings.TrimSpace(line[len(marker):]), true
+		}
+	}
+	i := 0
+	for i < len(line) && line[i] >= '0' && line[i] <= '9' {
+		i++
+	}
+	if i > 0 && i+1 < len(line) && (line[i] == '.' || line[i] == ')') && line[i+1] == ' ' {
+		return strings.TrimSpace(line[i+2:]), true
+	}
+	return "", false
+}
+
+// importGuidelines adds bullets parsed from a markdown file to guidelines.yaml,
+// skipping entries that already exist (case-insensitively)
+func importGuidelines(projectPath, importPath string, dryRun bool) {
+	checkpointDir := filepath.Join(projectPath, config.CheckpointDir)
+	if _, err := os.Stat(checkpointDir); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "error: checkpoint not initialized\n")
+		fmt.Fprintf(os.Stderr, "hint: Run 'checkpoint init' first\n")
+		os.Exit(1)
+	}
+	data, err := os.ReadFile(importPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	items := parseGuidelineImport(string(data))
+	if len(items) == 0 {
+		fmt.Fprintf(os.Stderr, "error: no importable bullets found in %s\n", importPath)
+		fmt.Fprintf(os.Stderr, "hint: list items must sit under a heading naming rules, avoid, principles, or patterns\n")
+		os.Exit(1)
+	}
+
+	guidelinesPath := file.FindWithFallback(
+		filepath.Join(checkpointDir, config.ExplainGuidelinesYaml),
+		filepath.Join(checkpointDir, config.ExplainGuidelinesYmlLegacy),
+	)
+	var guidelines explain.GuidelinesConfig
+	if data, err := os.ReadFile(guidelinesPath); err == nil {
+		_ = yaml.Unmarshal(data, &guidelines)
+	}
+	guidelines.SchemaVersion = "1"
+
+	seen := map[string]bool{}
+	for _, list := range []explain.GuidelineList{guidelines.Rules, guidelines.Avoid, guidelines.Principles} {
+		for _, existing := range list {
+			seen[strings.ToLower(existing.Text)] = true
+		}
+	}
+
+	var added []importedGuideline
+	skipped := 0
+	for _, item := range items {
+		stored := item.Content
+		if item.Kind == learnKindPattern {
+			// Patterns are stored as prefixed principles (see addPattern)
+			stored = "Pattern: " + item.Content
+		}
+		if seen[strings.ToLower(stored)] || seen[strings.ToLower(item.Content)] {
+			skipped++
+			continue
+		}
+		seen[strings.ToLower(stored)] = true
+		entry := explain.GuidelineItem{Text: stored}
+		switch item.Kind {
+		case learnKindGuideline:
+			guidelines.Rules = append(guidelines.Rules, entry)
+		case learnKindAvoid:
+			guidelines.Avoid = append(guidelines.Avoid, entry)
+		default:
+			guidelines.Principles = append(guidelines.Principles, entry)
+		}
+		added = append(added, item)
+	}
+
+	prefix := "✓ Imported"
+	if dryRun {
+		prefix = "[dry-run] Would import"
+	}
+	fmt.Printf("%s %d item(s) from %s (%d already present)\n", prefix, len(added), importPath, skipped)
+	for _, item := range added {
+		fmt.Printf("  + [%s] %s\n", item.Kind, item.Content)
+	}
+	if dryRun || len(added) == 0 {
+		return
+	}
+
+	if err := writeGuidelinesFile(guidelinesPath, &guidelines); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	for _, item := range added {
+		recordLearnAction(checkpointDir, learnAction{Kind: item.Kind, Content: item.Content})
+	}
+}
+
 func writeGuidelinesFile(path string, g *explain.GuidelinesConfig) error {
 	data, err := yaml.Marshal(g)
 	if err != nil {
@@ -368,7 +840,7 @@ func writeToolsFile(path string, t *explain.ToolsConfig) error {
 
 // listLearnings lists all captured learnings
 func listLearnings(projectPath string, jsonOutput bool) {
-	learningsPath := filepath.Join(projectPath, config.CheckpointDir, "learnings.yml")
+	learningsPath := filepath.Join(projectPath, config.CheckpointDir, config.LearningsFileName)
 
 	data, err := os.ReadFile(learningsPath)
 	if err != nil {
@@ -386,25 +858,24 @@ func listLearnings(projectPath string, jsonOutput bool) {
 	}
 
 	// Parse multi-document YAML
-	var learnings []struct {
-		Timestamp string `yaml:"timestamp" json:"timestamp"`
-		Learning  string `yaml:"learning" json:"learning"`
+	type listedLearning struct {
+		Timestamp string   `yaml:"timestamp" json:"timestamp"`
+		Learning  string   `yaml:"learning" json:"learning"`
+		Tags      []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+		Scope     string   `yaml:"scope,omitempty" json:"scope,omitempty"`
+		Ref       string   `yaml:"ref,omitempty" json:"ref,omitempty"`
 	}
+	var learnings []listedLearning
 
 	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
 	for {
-		var entry struct {
-			Timestamp string `yaml:"timestamp"`
-			Learning  string `yaml:"learning"`
-		}
+		var entry listedLearning
 		if err := decoder.Decode(&entry); err != nil {
 			break
 		}
 		if entry.Learning != "" {
-			learnings = append(learnings, struct {
-				Timestamp string `yaml:"timestamp" json:"timestamp"`
-				Learning  string `yaml:"learning" json:"learning"`
-			}{entry.Timestamp, entry.Learning})
+			entry.Tags = explain.NormalizeTags(entry.Tags)
+			learnings = append(learnings, entry)
 		}
 	}
 
@@ -426,6 +897,481 @@ func listLearnings(projectPath string, jsonOutput bool) {
 		if l.Timestamp != "" {
 			fmt.Printf("  [%s]\n", l.Timestamp)
 		}
+		if len(l.Tags) > 0 {
+			fmt.Printf("  tags: %s\n", strings.Join(l.Tags, ", "))
+		}
+		if l.Scope != "" {
+			fmt.Printf("  scope: %s\n", l.Scope)
+		}
+		if l.Ref != "" {
+			fmt.Printf("  ref: %s\n", l.Ref)
+		}
 		fmt.Println()
 	}
 }
+
+// Kinds of learn actions recorded in .learn-history
+const (
+	learnKindLearning  = "learning"
+	learnKindGuideline = "guideline"
+	learnKindAvoid     = "avoid"
+	learnKindPrinciple = "principle"
+	learnKindPattern   = "pattern"
+	learnKindTool      = "tool"
+)
+
+// learnAction is a single entry in .checkpoint/.learn-history
+type learnAction struct {
+	Timestamp string `yaml:"timestamp"`
+	Kind      string `yaml:"kind"`
+	Content   string `yaml:"content"`
+	Name      string `yaml:"name,omitempty"`     // tool name
+	Category  string `yaml:"category,omitempty"` // tools.yaml section; empty means maintenance
+	Previous  string `yaml:"previous,omitempty"` // tool command before an update
+}
+
+// recordLearnAction appends an action to the learn history (best effort)
+func recordLearnAction(checkpointDir string, action learnAction) {
+	action.Timestamp = time.Now().Format(time.RFC3339)
+	data, err := yaml.Marshal(&action)
+	if err != nil {
+		return
+	}
+
+	historyPath := filepath.Join(checkpointDir, config.LearnHistoryFileName)
+	f, err := os.OpenFile(historyPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to record learn history: %v\n", err)
+		return
+	}
+	defer func() { _ = f.Close() }()
+	_, _ = f.WriteString("---\n" + string(data))
+}
+
+// readLearnHistory returns all recorded learn actions, oldest first
+func readLearnHistory(checkpointDir string) ([]learnAction, error) {
+	data, err := os.ReadFile(filepath.Join(checkpointDir, config.LearnHistoryFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read learn history: %w", err)
+	}
+
+	var actions []learnAction
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	for {
+		var a learnAction
+		if err := decoder.Decode(&a); err != nil {
+			break
+		}
+		if a.Kind != "" {
+			actions = append(actions, a)
+		}
+	}
+	return actions, nil
+}
+
+// writeLearnHistory rewrites the learn history with the given actions
+func writeLearnHistory(checkpointDir string, actions []learnAction) error {
+	historyPath := filepath.Join(checkpointDir, config.LearnHistoryFileName)
+	if len(actions) == 0 {
+		if err := os.Remove(historyPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove learn history: %w", err)
+		}
+		return nil
+	}
+
+	var b strings.Builder
+	for i := range actions {
+		data, err := yaml.Marshal(&actions[i])
+		if err != nil {
+			return fmt.Errorf("marshal learn history: %w", err)
+		}
+		b.WriteString("---\n")
+		b.Write(data)
+	}
+	if err := os.WriteFile(historyPath, []byte(b.String()), 0644); err != nil {
+		return fmt.Errorf("write learn history: %w", err)
+	}
+	return nil
+}
+
+// LearnUndo removes the most recently added learn entry
+func LearnUndo(projectPath string) {
+	checkpointDir := filepath.Join(projectPath, config.CheckpointDir)
+
+	actions, err := readLearnHistory(checkpointDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(actions) == 0 {
+		fmt.Println("Nothing to undo.")
+		fmt.Println("hint: only entries added with 'checkpoint learn' can be undone")
+		return
+	}
+
+	last := actions[len(actions)-1]
+	removed, err := undoLearnAction(checkpointDir, last)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := writeLearnHistory(checkpointDir, actions[:len(actions)-1]); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+
+	if !removed {
+		fmt.Printf("Last %s was already removed: %s\n", last.Kind, describeLearnAction(last))
+		return
+	}
+	if last.Kind == learnKindTool && last.Previous != "" {
+		fmt.Printf("✓ Restored tool '%s': %s\n", last.Name, last.Previous)
+		return
+	}
+	fmt.Printf("✓ Removed %s: %s\n", last.Kind, describeLearnAction(last))
+}
+
+// LearnRemove removes a specific entry from the store selected by kind
+func LearnRemove(projectPath, kind, content string) {
+	checkpointDir := filepath.Join(projectPath, config.CheckpointDir)
+	if _, err := os.Stat(checkpointDir); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "error: checkpoint not initialized\n")
+		fmt.Fprintf(os.Stderr, "hint: Run 'checkpoint init' first\n")
+		os.Exit(1)
+	}
+
+	action := learnAction{Kind: kind, Content: content}
+	if kind == learnKindTool {
+		action.Name = content
+	}
+
+	removed, err := removeLearnEntry(checkpointDir, action)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	if !removed {
+		fmt.Fprintf(os.Stderr, "error: %s not found: %s\n", kind, content)
+		fmt.Fprintf(os.Stderr, "hint: Run 'checkpoint explain guidelines' or 'checkpoint learn --list' to see existing entries\n")
+		os.Exit(1)
+	}
+
+	fmt.Printf("✓ Removed %s: %s\n", kind, content)
+}
+
+// learnKindFromOptions returns the kind selected by type flags, or "" for none
+func learnKindFromOptions(opts LearnOptions) string {
+	switch {
+	case opts.Guideline:
+		return learnKindGuideline
+	case opts.Avoid:
+		return learnKindAvoid
+	case opts.Principle:
+		return learnKindPrinciple
+	case opts.Pattern:
+		return learnKindPattern
+	case opts.Tool || opts.ToolName != "":
+		return learnKindTool
+	}
+	return ""
+}
+
+// editGuidelines handles --remove, --remove-index and --edit-index
+func editGuidelines(projectPath string, opts LearnOptions) {
+	checkpointDir := filepath.Join(projectPath, config.CheckpointDir)
+	if _, err := os.Stat(checkpointDir); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "error: checkpoint not initialized\n")
+		fmt.Fprintf(os.Stderr, "hint: Run 'checkpoint init' first\n")
+		os.Exit(1)
+	}
+
+	kind := learnKindFromOptions(opts)
+	if opts.Remove != "" {
+		if kind != "" {
+			LearnRemove(projectPath, kind, opts.Remove)
+			return
+		}
+		for _, k := range []string{learnKindGuideline, learnKindAvoid, learnKindPrinciple, learnKindPattern} {
+			removed, err := removeGuidelineEntry(checkpointDir, k, opts.Remove)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+			if removed {
+				fmt.Printf("✓ Removed %s: %s\n", k, opts.Remove)
+				return
+			}
+		}
+		fmt.Fprintf(os.Stderr, "error: no rule, avoid, principle or pattern matches: %s\n", opts.Remove)
+		fmt.Fprintf(os.Stderr, "hint: the text must match exactly; see 'checkpoint explain guidelines'\n")
+		os.Exit(1)
+	}
+
+	if opts.RemoveIdx != 0 && opts.EditIdx != 0 {
+		fmt.Fprintf(os.Stderr, "error: use either --remove-index or --edit-index, not both\n")
+		os.Exit(1)
+	}
+	if kind == "" || kind == learnKindTool {
+		fmt.Fprintf(os.Stderr, "error: --remove-index and --edit-index need a section\n")
+		fmt.Fprintf(os.Stderr, "hint: add --guideline, --avoid, --principle or --pattern\n")
+		os.Exit(1)
+	}
+
+	index, newText := opts.RemoveIdx, ""
+	if opts.EditIdx != 0 {
+		index, newText = opts.EditIdx, strings.TrimSpace(opts.Content)
+		if newText == "" {
+			fmt.Fprintf(os.Stderr, "error: --edit-index requires the new text\n")
+			fmt.Fprintf(os.Stderr, "usage: checkpoint learn --edit-index N \"<new text>\" --guideline\n")
+			os.Exit(1)
+		}
+	}
+	old, err := editGuidelineAt(checkpointDir, kind, index, newText)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	if newText == "" {
+		fmt.Printf("✓ Removed %s %d: %s\n", kind, index, old)
+	} else {
+		fmt.Printf("✓ Updated %s %d: %s -> %s\n", kind, index, old, newText)
+	}
+}
+
+// guidelineSection returns the list kind is stored in; patterns live in principles
+func guidelineSection(g *explain.GuidelinesConfig, kind string) *explain.GuidelineList {
+	switch kind {
+	case learnKindGuideline:
+		return &g.Rules
+	case learnKindAvoid:
+		return &g.Avoid
+	case learnKindPrinciple, learnKindPattern:
+		return &g.Principles
+	}
+	return nil
+}
+
+// editGuidelineAt removes the 1-based index entry of kind's section, or
+// replaces its text when newText is set, and returns the previous text
+func editGuidelineAt(checkpointDir, kind string, index int, newText string) (string, error) {
+	guidelinesPath := file.FindWithFallback(
+		filepath.Join(checkpointDir, config.ExplainGuidelinesYaml),
+		filepath.Join(checkpointDir, config.ExplainGuidelinesYmlLegacy),
+	)
+	var guidelines explain.GuidelinesConfig
+	data, err := os.ReadFile(guidelinesPath)
+	if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("read guidelines: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &guidelines); err != nil {
+		return "", fmt.Errorf("parse guidelines: %w", err)
+	}
+	guidelines.SchemaVersion = "1"
+
+	section := guidelineSection(&guidelines, kind)
+	if section == nil {
+		return "", fmt.Errorf("unknown guideline kind: %s", kind)
+	}
+	if index < 1 || index > len(*section) {
+		return "", fmt.Errorf("%s index %d out of range (have %d)", kind, index, len(*section))
+	}
+
+	i := index - 1
+	old := (*section)[i].Text
+	if newText == "" {
+		*section = append((*section)[:i], (*section)[i+1:]...)
+	} else {
+		if kind == learnKindPattern && !strings.HasPrefix(newText, "Pattern: ") {
+			// Keep patterns recognisable among principles (see addPattern)
+			newText = "Pattern: " + newText
+		}
+		if newText != old && section.Contains(newText) {
+			return "", fmt.Errorf("%s already exists: %s", kind, newText)
+		}
+		(*section)[i].Text = newText
+	}
+
+	if err := writeGuidelinesFile(guidelinesPath, &guidelines); err != nil {
+		return "", err
+	}
+	return old, nil
+}
+
+func describeLearnAction(a learnAction) string {
+	if a.Kind == learnKindTool {
+		return fmt.Sprintf("'%s' (%s)", a.Name, a.Content)
+	}
+	return a.Content
+}
+
+// undoLearnAction reverses a recorded action. Tool updates restore the previous command.
+func undoLearnAction(checkpointDir string, a learnAction) (bool, error) {
+	if a.Kind == learnKindTool && a.Category == "" {
+		// Recorded before --category existed, when tools always went to maintenance
+		a.Category = defaultToolCategory
+	}
+	if a.Kind == learnKindTool && a.Previous != "" {
+		return restoreTool(checkpointDir, a.Category, a.Name, a.Content, a.Previous)
+	}
+	return removeLearnEntry(checkpointDir, a)
+}
+
+// removeLearnEntry deletes an entry from its store; returns false if it was not present
+func removeLearnEntry(checkpointDir string, a learnAction) (bool, error) {
+	switch a.Kind {
+	case learnKindGuideline, learnKindAvoid, learnKindPrinciple, learnKindPattern:
+		return removeGuidelineEntry(checkpointDir, a.Kind, a.Content)
+	case learnKindTool:
+		return removeTool(checkpointDir, a.Category, a.Name)
+	case learnKindLearning:
+		return removeLearning(checkpointDir, a.Content)
+	default:
+		return false, fmt.Errorf("unknown learn kind: %s", a.Kind)
+	}
+}
+
+func removeGuidelineEntry(checkpointDir, kind, content string) (bool, error) {
+	guidelinesPath := file.FindWithFallback(
+		filepath.Join(checkpointDir, config.ExplainGuidelinesYaml),
+		filepath.Join(checkpointDir, config.ExplainGuidelinesYmlLegacy),
+	)
+
+	data, err := os.ReadFile(guidelinesPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("read guidelines: %w", err)
+	}
+	var guidelines explain.GuidelinesConfig
+	if err := yaml.Unmarshal(data, &guidelines); err != nil {
+		return false, fmt.Errorf("parse guidelines: %w", err)
+	}
+	guidelines.SchemaVersion = "1"
+
+	var removed bool
+	switch kind {
+	case learnKindGuideline:
+		guidelines.Rules, removed = guidelines.Rules.RemoveLast(content)
+	case learnKindAvoid:
+		guidelines.Avoid, removed = guidelines.Avoid.RemoveLast(content)
+	case learnKindPrinciple:
+		guidelines.Principles, removed = guidelines.Principles.RemoveLast(content)
+	case learnKindPattern:
+		// Patterns are stored as prefixed principles (see addPattern)
+		guidelines.Principles, removed = guidelines.Principles.RemoveLast(fmt.Sprintf("Pattern: %s", content))
+		if !removed {
+			guidelines.Principles, removed = guidelines.Principles.RemoveLast(content)
+		}
+	}
+	if !removed {
+		return false, nil
+	}
+
+	if err := writeGuidelinesFile(guidelinesPath, &guidelines); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// loadToolsForEdit reads tools.yaml (with .yml fallback) for modification
+func loadToolsForEdit(checkpointDir string) (string, *explain.ToolsConfig, error) {
+	toolsPath := file.FindWithFallback(
+		filepath.Join(checkpointDir, config.ExplainToolsYaml),
+		filepath.Join(checkpointDir, config.ExplainToolsYmlLegacy),
+	)
+
+	var tools explain.ToolsConfig
+	data, err := os.ReadFile(toolsPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return toolsPath, nil, nil
+		}
+		return "", nil, fmt.Errorf("read tools: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &tools); err != nil {
+		return "", nil, fmt.Errorf("parse tools: %w", err)
+	}
+	tools.SchemaVersion = "1"
+	return toolsPath, &tools, nil
+}
+
+// removeTool deletes a tool from category, or from the first section that
+// has it (maintenance first) when category is empty
+func removeTool(checkpointDir, category, name string) (bool, error) {
+	toolsPath, tools, err := loadToolsForEdit(checkpointDir)
+	if err != nil || tools == nil {
+		return false, err
+	}
+	categories := []string{category}
+	if category == "" {
+		categories = append([]string{defaultToolCategory}, toolCategories...)
+	}
+	for _, c := range categories {
+		section := toolCategoryMap(tools, c)
+		if section == nil {
+			continue
+		}
+		if _, ok := (*section)[name]; !ok {
+			continue
+		}
+		delete(*section, name)
+		if err := writeToolsFile(toolsPath, tools); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func restoreTool(checkpointDir, category, name, current, previous string) (bool, error) {
+	toolsPath, tools, err := loadToolsForEdit(checkpointDir)
+	if err != nil || tools == nil {
+		return false, err
+	}
+	section := toolCategoryMap(tools, category)
+	if section == nil {
+		return false, nil
+	}
+	existing, ok := (*section)[name]
+	if !ok || existing.Command != current {
+		return false, nil
+	}
+	existing.Command = previous
+	(*section)[name] = existing
+	if err := writeToolsFile(toolsPath, tools); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// removeLearning drops the last learnings.yml document matching content,
+// leaving all other documents byte-for-byte intact
+func removeLearning(checkpointDir, content string) (bool, error) {
+	learningsPath := filepath.Join(checkpointDir, config.LearningsFileName)
+	data, err := os.ReadFile(learningsPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("read learnings: %w", err)
+	}
+
+	docs := strings.Split(string(data), "---\n")
+	for i := len(docs) - 1; i >= 0; i-- {
+		var l explain.Learning
+		if err := yaml.Unmarshal([]byte(docs[i]), &l); err != nil || l.Learning != content {
+			continue
+		}
+		docs = append(docs[:i], docs[i+1:]...)
+		newContent := strings.Join(docs, "---\n")
+		if err := os.WriteFile(learningsPath, []byte(newContent), 0644); err != nil {
+			return false, fmt.Errorf("write learnings: %w", err)
+		}
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dmoose/checkpoint/internal/file"
+	"github.com/dmoose/checkpoint/pkg/config"
+)
+
+func TestLearnUndo(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "checkpoint-learn-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	checkpointDir := filepath.Join(tmpDir, config.CheckpointDir)
+	if err := os.MkdirAll(checkpointDir, 0755); err != nil {
+		t.Fatalf("failed to create checkpoint dir: %v", err)
+	}
+
+	if err := addLearning(checkpointDir, "keep this"); err != nil {
+		t.Fatalf("addLearning failed: %v", err)
+	}
+	if err := addLearning(checkpointDir, "typo here"); err != nil {
+		t.Fatalf("addLearning failed: %v", err)
+	}
+	if err := addGuideline(checkpointDir, "Validate input"); err != nil {
+		t.Fatalf("addGuideline failed: %v", err)
+	}
+
+	// Undo the guideline
+	LearnUndo(tmpDir)
+	guidelines, _ := file.ReadFile(filepath.Join(checkpointDir, config.ExplainGuidelinesYaml))
+	if strings.Contains(guidelines, "Validate input") {
+		t.Errorf("guideline should be removed after undo, got:\n%s", guidelines)
+	}
+
+	// Undo the second learning, first should remain
+	LearnUndo(tmpDir)
+	learnings, _ := file.ReadFile(filepath.Join(checkpointDir, config.LearningsFileName))
+	if strings.Contains(learnings, "typo here") {
+		t.Errorf("learning should be removed after undo, got:\n%s", learnings)
+	}
+	if !strings.Contains(learnings, "keep this") {
+		t.Errorf("earlier learning should be preserved, got:\n%s", learnings)
+	}
+
+	// Entry deleted manually: undo reports it and still pops history
+	if err := os.Remove(filepath.Join(checkpointDir, config.LearningsFileName)); err != nil {
+		t.Fatalf("failed to remove learnings: %v", err)
+	}
+	LearnUndo(tmpDir)
+	if file.Exists(filepath.Join(checkpointDir, config.LearnHistoryFileName)) {
+		t.Errorf("learn history should be empty after undoing all actions")
+	}
+}
+
+func TestRemoveGuidelineEntryPattern(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "checkpoint-learn-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	if err := addPattern(tmpDir, "Table-driven tests"); err != nil {
+		t.Fatalf("addPattern failed: %v", err)
+	}
+
+	removed, err := removeGuidelineEntry(tmpDir, learnKindPattern, "Table-driven tests")
+	if err != nil {
+		t.Fatalf("removeGuidelineEntry failed: %v", err)
+	}
+	if !removed {
+		t.Errorf("expected pattern to be removed")
+	}
+
+	removed, _ = removeGuidelineEntry(tmpDir, learnKindPattern, "Table-driven tests")
+	if removed {
+		t.Errorf("expected second removal to report not found")
+	}
+}
+
+func TestParseGuidelineImport(t *testing.T) {
+	md := "# Contributing\n\n- Fork the repo\n\n" +
+		"## Rules\n\n- Run tests before pushing\n  and before tagging\n  - nested detail\n1. Keep PRs small\n\n" +
+		"## Things to Avoid\n\n* Global mutable state\n\n" +
+		"## Design Principles\n\n+ Prefer composition\n\n" +
+		"```\n# Patterns\n- not a pattern\n```\n\n" +
+		"## Patterns\n\n- Table-driven tests\n"
+
+	got := parseGuidelineImport(md)
+	want := []importedGuideline{
+		{learnKindGuideline, "Run tests before pushing and before tagging"},
+		{learnKindGuideline, "Keep PRs small"},
+		{learnKindAvoid, "Global mutable state"},
+		{learnKindPrinciple, "Prefer composition"},
+		{learnKindPattern, "Table-driven tests"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d items, got %d: %+v", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("item %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestImportGuidelines(t *testing.T) {
+	tmpDir := t.TempDir()
+	checkpointDir := filepath.Join(tmpDir, config.CheckpointDir)
+	if err := os.MkdirAll(checkpointDir, 0755); err != nil {
+		t.Fatalf("failed to create checkpoint dir: %v", err)
+	}
+	if err := addGuideline(checkpointDir, "Keep PRs small"); err != nil {
+		t.Fatalf("addGuideline failed: %v", err)
+	}
+	importPath := filepath.Join(tmpDir, "CONTRIBUTING.md")
+	md := "## Rules\n- keep prs small\n- Write docs\n## Patterns\n- Table-driven tests\n"
+	if err := os.WriteFile(importPath, []byte(md), 0644); err != nil {
+		t.Fatalf("failed to write import file: %v", err)
+	}
+	guidelinesPath := filepath.Join(checkpointDir, config.ExplainGuidelinesYaml)
+
+	importGuidelines(tmpDir, importPath, true)
+	guidelines, _ := file.ReadFile(guidelinesPath)
+	if strings.Contains(guidelines, "Write docs") {
+		t.Errorf("dry run should not write, got:\n%s", guidelines)
+	}
+
+	importGuidelines(tmpDir, importPath, false)
+	guidelines, _ = file.ReadFile(guidelinesPath)
+	for _, want := range []string{"Keep PRs small", "Write docs", "Pattern: Table-driven tests"} {
+		if !strings.Contains(guidelines, want) {
+			t.Errorf("expected %q in guidelines, got:\n%s", want, guidelines)
+		}
+	}
+	if strings.Contains(guidelines, "keep prs small") {
+		t.Errorf("duplicate rule should be skipped, got:\n%s", guidelines)
+	}
+
+	// Imported entries can be undone one at a time
+	LearnUndo(tmpDir)
+	guidelines, _ = file.ReadFile(guidelinesPath)
+	if strings.Contains(guidelines, "Table-driven tests") || !strings.Contains(guidelines, "Write docs") {
+		t.Errorf("undo should remove only the last imported entry, got:\n%s", guidelines)
+	}
+}
+
+func TestAddToolCategory(t *testing.T) {
+	tmpDir := t.TempDir()
+	checkpointDir := filepath.Join(tmpDir, config.CheckpointDir)
+	if err := os.MkdirAll(checkpointDir, 0755); err != nil {
+		t.Fatalf("failed to create checkpoint dir: %v", err)
+	}
+
+	if err := addTool(checkpointDir, "test", "race", "go test -race ./..."); err != nil {
+		t.Fatalf("addTool failed: %v", err)
+	}
+	if err := addTool(checkpointDir, "", "tidy", "go mod tidy"); err != nil {
+		t.Fatalf("addTool failed: %v", err)
+	}
+	if err := addTool(checkpointDir, "deploy", "ship", "make ship"); err == nil {
+		t.Error("expected an error for an unknown category")
+	}
+
+	_, tools, err := loadToolsForEdit(checkpointDir)
+	if err != nil || tools == nil {
+		t.Fatalf("loadToolsForEdit: %v", err)
+	}
+	if tools.Test["race"].Command != "go test -race ./..." {
+		t.Errorf("expected race in test section, got %+v", tools.Test)
+	}
+	if _, ok := tools.Maintenance["tidy"]; !ok {
+		t.Errorf("expected tidy in maintenance by default, got %+v", tools.Maintenance)
+	}
+
+	// Undo removes the tool from the section it was added to
+	LearnUndo(tmpDir)
+	LearnUndo(tmpDir)
+	_, tools, _ = loadToolsForEdit(checkpointDir)
+	if len(tools.Test) != 0 || len(tools.Maintenance) != 0 {
+		t.Errorf("expected both tools undone, got test=%+v maintenance=%+v", tools.Test, tools.Maintenance)
+	}
+
+	// Remove without a category finds the tool in any section
+	if err := addTool(checkpointDir, "lint", "vet", "go vet ./..."); err != nil {
+		t.Fatalf("addTool failed: %v", err)
+	}
+	if removed, err := removeTool(checkpointDir, "", "vet"); err != nil || !removed {
+		t.Errorf("expected vet removed from lint, got removed=%v err=%v", removed, err)
+	}
+}
+
+func TestEditGuidelineAt(t *testing.T) {
+	checkpointDir := filepath.Join(t.TempDir(), config.CheckpointDir)
+	if err := os.MkdirAll(checkpointDir, 0755); err != nil {
+		t.Fatalf("failed to create checkpoint dir: %v", err)
+	}
+	guidelinesPath := filepath.Join(checkpointDir, config.ExplainGuidelinesYaml)
+	content := "schema_version: \"1\"\nrules:\n  - Run tests\n  - text: Wrap erors\n    applies_to: [go]\n  - Keep it small\n"
+	if err := os.WriteFile(guidelinesPath, []byte(content), 0644); err != nil {
+		t.Fatalf("write guidelines: %v", err)
+	}
+
+	old, err := editGuidelineAt(checkpointDir, learnKindGuideline, 2, "Wrap errors")
+	if err != nil || old != "Wrap erors" {
+		t.Fatalf("edit: old=%q err=%v", old, err)
+	}
+	if _, err := editGuidelineAt(checkpointDir, learnKindGuideline, 1, "Keep it small"); err == nil {
+		t.Error("expected an error when the new text duplicates another entry")
+	}
+	if _, err := editGuidelineAt(checkpointDir, learnKindGuideline, 4, ""); err == nil {
+		t.Error("expected an out of range error")
+	}
+	if _, err := editGuidelineAt(checkpointDir, learnKindGuideline, 1, ""); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+
+	got, _ := file.ReadFile(guidelinesPath)
+	if strings.Contains(got, "Run tests") || !strings.Contains(got, "text: Wrap errors") || !strings.Contains(got, "applies_to:") {
+		t.Errorf("unexpected guidelines after edits:\n%s", got)
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,15 +13,37 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var lintOpts struct {
+	porcelain bool
+}
+
 func init() {
 	rootCmd.AddCommand(lintCmd)
+	lintCmd.Flags().BoolVar(&lintOpts.porcelain, "porcelain", false, "Emit one JSON issue per line for editor integration")
 }
 
 var lintCmd = &cobra.Command{
 	Use:   "lint [path]",
 	Short: "Check checkpoint input for obvious mistakes and issues",
 	Long: `Validates input file and suggests improvements before commit.
-Catches placeholder text, vague summaries, and common errors.`,
+Catches placeholder text, vague summaries, and common errors. A perf
+change whose details carry no measurement (40ms, 2.5x, 30%) is flagged.
+
+The context section is checked too: an empty or placeholder
+problem_statement and a decision without a rationale are warnings, while
+a filled-in established_patterns item without a scope is an error (the
+template marks it REQUIRED) and blocks commit.
+
+Change types and the summary length limit can be set per project in
+.checkpoint/schema.yml (lint, commit and 'check' follow it):
+  max_summary_length: 100
+  change_types: [feature, fix, docs]     # replaces the defaults
+  extra_change_types: [build, ci, chore] # added to them
+
+Use --porcelain to emit each issue as a JSON object per line:
+  {"change_index": 0, "field": "summary", "severity": "warning", "message": "..."}
+Severity "error" marks validation failures that would block commit;
+"warning" marks suggestions. change_index is -1 for issues not tied to a change.`,
 	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		projectPath := "."
@@ -32,6 +55,10 @@ Catches placeholder text, vague summaries, and common errors.`,
 			fmt.Fprintf(os.Stderr, "error: cannot resolve path: %v\n", err)
 			os.Exit(1)
 		}
+		if lintOpts.porcelain {
+			LintPorcelain(absPath)
+			return
+		}
 		Lint(absPath)
 	},
 }
@@ -39,8 +66,8 @@ Catches placeholder text, vague summaries, and common errors.`,
 // Lint checks the checkpoint input for obvious mistakes and issues
 func Lint(projectPath string) {
 	// Check if input file exists
-	inputPath := filepath.Join(projectPath, config.InputFileName)
-	if !file.Exists(inputPath) {
+	inputPath, found := findInputFile(projectPath)
+	if !found {
 		fmt.Fprintf(os.Stderr, "error: input file not found at %s\n", inputPath)
 		fmt.Fprintf(os.Stderr, "hint: run 'checkpoint check %s' to generate the input file\n", projectPath)
 		os.Exit(1)
@@ -56,12 +83,19 @@ func Lint(projectPath string) {
 	entry, err := schema.ParseInputFile(inputContent)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: failed to parse input file: %v\n", err)
-		fmt.Fprintf(os.Stderr, "hint: check YAML syntax in %s\n", inputPath)
+		fmt.Fprintf(os.Stderr, "hint: check YAML/JSON syntax in %s\n", inputPath)
+		os.Exit(1)
+	}
+
+	rules, err := schema.LoadRules(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "hint: fix .checkpoint/%s or remove it to use the defaults\n", config.SchemaYaml)
 		os.Exit(1)
 	}
 
 	// Run basic validation first
-	if err := schema.ValidateEntry(entry); err != nil {
+	if err := schema.ValidateEntryWithRules(entry, rules); err != nil {
 		fmt.Printf("❌ Validation errors found:\n")
 		fmt.Printf("   %v\n", err)
 		fmt.Printf("\n")
@@ -86,3 +120,44 @@ func Lint(projectPath string) {
 	fmt.Printf("\nTotal issues: %d\n", len(issues))
 	fmt.Printf("\nThese are suggestions - you can still commit if the issues are intentional.\n")
 }
+
+// LintPorcelain prints validation errors and lint warnings as JSON lines
+func LintPorcelain(projectPath string) {
+	inputPath, found := findInputFile(projectPath)
+	if !found {
+		fmt.Fprintf(os.Stderr, "error: input file not found at %s\n", inputPath)
+		os.Exit(1)
+	}
+
+	inputContent, err := file.ReadFile(inputPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: failed to read input file: %v\n", err)
+		os.Exit(1)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	entry, err := schema.ParseInputFile(inputContent)
+	if err != nil {
+		_ = enc.Encode(schema.ParseLintIssue(fmt.Sprintf("failed to parse input file: %v", err), schema.LintSeverityError))
+		os.Exit(1)
+	}
+
+	rules, err := schema.LoadRules(projectPath)
+	if err != nil {
+		_ = enc.Encode(schema.ParseLintIssue(err.Error(), schema.LintSeverityError))
+		os.Exit(1)
+	}
+
+	var validationErr string
+	if err := schema.ValidateEntryWithRules(entry, rules); err != nil {
+		validationErr = err.Error()
+		_ = enc.Encode(schema.ParseLintIssue(validationErr, schema.LintSeverityError))
+	}
+	for _, issue := range schema.LintEntry(entry) {
+		// Skip warnings already reported as the blocking error
+		if issue == validationErr {
+			continue
+		}
+		_ = enc.Encode(schema.ParseLintIssue(issue, schema.LintSeverityWarning))
+	}
+}
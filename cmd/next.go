@@ -0,0 +1,304 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/dmoose/checkpoint/internal/file"
+	"github.com/dmoose/checkpoint/internal/schema"
+	"github.com/dmoose/checkpoint/pkg/config"
+
+	"github.com/spf13/cobra"
+)
+
+var nextOpts struct {
+	set    []string
+	format string
+}
+
+func init() {
+	rootCmd.AddCommand(nextCmd)
+	nextCmd.Flags().StringArrayVar(&nextOpts.set, "set", nil, "Set a priority by index, e.g. --set 3=high (repeatable; for reprioritize)")
+	nextCmd.Flags().StringVar(&nextOpts.format, "format", "json", "Export format: github, gitlab, or json (for export)")
+}
+
+var nextCmd = &cobra.Command{
+	Use:   "next [action]",
+	Short: "List and reprioritize outstanding next steps",
+	Long: `Lists the outstanding next steps from the last checkpoint, deduplicated
+and numbered. Actions: list (default), reprioritize, export
+
+'reprioritize' sets new priorities (high, med, low, or none to clear),
+either with --set INDEX=PRIORITY or interactively when --set is omitted.
+Changelog history is never rewritten; the new priorities are stored in the
+status file's next_steps, which 'start', 'summary', and 'check' read.
+
+'export' prints each step as an issue: the summary is the title, details
+the body, and scope/priority become labels (scope:X, priority:Y).
+--format github emits 'gh issue create' commands, gitlab emits
+'glab issue create' commands, and json (default) emits an array of
+{title, body, labels} objects for API import.
+
+Examples:
+  checkpoint next
+  checkpoint next reprioritize --set 3=high --set 1=low
+  checkpoint next reprioritize
+  checkpoint next export --format github | sh`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		projectPath := "."
+		absPath, err := filepath.Abs(projectPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: cannot resolve path: %v\n", err)
+			os.Exit(1)
+		}
+
+		opts := NextOptions{Set: nextOpts.set, Format: nextOpts.format}
+		if len(args) > 0 {
+			opts.Action = args[0]
+		}
+		Next(absPath, opts)
+	},
+}
+
+// NextOptions holds flags for the next command
+type NextOptions struct {
+	Action string   // list, reprioritize
+	Set    []string // INDEX=PRIORITY assignments for reprioritize
+	Format string   // github, gitlab, json for export
+}
+
+// Next lists or reprioritizes outstanding next steps
+func Next(projectPath string, opts NextOptions) {
+	statusPath := filepath.Join(projectPath, config.StatusFileName)
+	var content string
+	if file.Exists(statusPath) {
+		var err error
+		content, err = file.ReadFile(statusPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: failed to read status file: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	steps := dedupeNextSteps(schema.ExtractNextStepsFromStatus(content))
+
+	switch opts.Action {
+	case "", "list":
+		if len(steps) == 0 {
+			fmt.Println("No outstanding next steps.")
+			return
+		}
+		printNextSteps(os.Stdout, steps)
+	case "reprioritize":
+		if len(steps) == 0 {
+			fmt.Fprintf(os.Stderr, "error: no outstanding next steps to reprioritize\n")
+			fmt.Fprintf(os.Stderr, "hint: next steps come from the last 'checkpoint commit'\n")
+			os.Exit(1)
+		}
+		var changes map[int]string
+		var err error
+		if len(opts.Set) > 0 {
+			changes, err = parsePriorityAssignments(opts.Set, len(steps))
+		} else {
+			printNextSteps(os.Stdout, steps)
+			changes, err = promptPriorities(os.Stdin, os.Stdout, steps)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		if len(changes) == 0 {
+			fmt.Println("No priorities changed.")
+			return
+		}
+		for i, p := range changes {
+			steps[i].Priority = p
+		}
+		if err := file.WriteFile(statusPath, replaceStatusNextSteps(content, steps)); err != nil {
+			fmt.Fprintf(os.Stderr, "error: failed to write status file: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✓ Updated priority of %d next step(s)\n\n", len(changes))
+		printNextSteps(os.Stdout, steps)
+	case "export":
+		if err := exportNextSteps(os.Stdout, steps, opts.Format); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "error: unknown action '%s'\n", opts.Action)
+		fmt.Fprintf(os.Stderr, "usage: checkpoint next [list|reprioritize|export]\n")
+		os.Exit(1)
+	}
+}
+
+// dedupeNextSteps drops repeated summaries (case-insensitive), keeping the first
+func dedupeNextSteps(steps []schema.NextStep) []schema.NextStep {
+	seen := map[string]bool{}
+	var out []schema.NextStep
+	for _, s := range steps {
+		key := strings.ToLower(strings.TrimSpace(s.Summary))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, s)
+	}
+	return out
+}
+
+func printNextSteps(out io.Writer, steps []schema.NextStep) {
+	for i, s := range steps {
+		priority := s.Priority
+		if priority == "" {
+			priority = "-"
+		}
+		_, _ = fmt.Fprintf(out, "  %2d. [%-4s] %s\n", i+1, priority, s.Summary)
+	}
+}
+
+// nextStepIssue is a next step shaped as an issue-tracker item
+type nextStepIssue struct {
+	Title  string   `json:"title"`
+	Body   string   `json:"body"`
+	Labels []string `json:"labels"`
+}
+
+// nextStepIssues converts steps to issues, labelling scope and priority
+func nextStepIssues(steps []schema.NextStep) []nextStepIssue {
+	issues := make([]nextStepIssue, 0, len(steps))
+	for _, s := range steps {
+		labels := []string{}
+		if s.Scope != "" {
+			labels = append(labels, "scope:"+s.Scope)
+		}
+		if s.Priority != "" {
+			labels = append(labels, "priority:"+s.Priority)
+		}
+		issues = append(issues, nextStepIssue{
+			Title:  strings.TrimSpace(s.Summary),
+			Body:   strings.TrimSpace(s.Details),
+			Labels: labels,
+		})
+	}
+	return issues
+}
+
+// exportNextSteps writes steps as issues in the given format
+func exportNextSteps(out io.Writer, steps []schema.NextStep, format string) error {
+	issues := nextStepIssues(steps)
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal issues: %w", err)
+		}
+		_, _ = fmt.Fprintln(out, string(data))
+	case "github":
+		for _, is := range issues {
+			line := "gh issue create --title " + shellQuote(is.Title) + " --body " + shellQuote(is.Body)
+			for _, l := range is.Labels {
+				line += " --label " + shellQuote(l)
+			}
+			_, _ = fmt.Fprintln(out, line)
+		}
+	case "gitlab":
+		for _, is := range issues {
+			line := "glab issue create --title " + shellQuote(is.Title) + " --description " + shellQuote(is.Body)
+			if len(is.Labels) > 0 {
+				line += " --label " + shellQuote(strings.Join(is.Labels, ","))
+			}
+			_, _ = fmt.Fprintln(out, line)
+		}
+	default:
+		return fmt.Errorf("unknown --format '%s' (valid: github, gitlab, json)", format)
+	}
+	return nil
+}
+
+// normalizePriority validates a priority; "none" (or "-") clears it
+func normalizePriority(p string) (string, error) {
+	switch p = strings.ToLower(strings.TrimSpace(p)); p {
+	case "high", "med", "low":
+		return p, nil
+	case "none", "-":
+		return "", nil
+	default:
+		return "", fmt.Errorf("invalid priority '%s' (valid: high, med, low, none)", p)
+	}
+}
+
+// parsePriorityAssignments parses INDEX=PRIORITY values into 0-based index -> priority
+func parsePriorityAssignments(sets []string, count int) (map[int]string, error) {
+	changes := map[int]string{}
+	for _, set := range sets {
+		idx, prio, ok := strings.Cut(set, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --set '%s' (expected INDEX=PRIORITY)", set)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(idx))
+		if err != nil || n < 1 || n > count {
+			return nil, fmt.Errorf("invalid index '%s' in --set (expected 1-%d)", idx, count)
+		}
+		p, err := normalizePriority(prio)
+		if err != nil {
+			return nil, err
+		}
+		changes[n-1] = p
+	}
+	return changes, nil
+}
+
+// promptPriorities asks for a new priority per step; blank keeps the current one
+func promptPriorities(in io.Reader, out io.Writer, steps []schema.NextStep) (map[int]string, error) {
+	r := bufio.NewReader(in)
+	changes := map[int]string{}
+	_, _ = fmt.Fprintln(out, "\nNew priority (high/med/low/none, blank to keep):")
+	for i := 0; i < len(steps); i++ {
+		answer, err := promptLine(r, out, fmt.Sprintf("  %d. %s: ", i+1, steps[i].Summary))
+		if err != nil {
+			return nil, err
+		}
+		if answer == "" {
+			continue
+		}
+		p, err := normalizePriority(answer)
+		if err != nil {
+			_, _ = fmt.Fprintf(out, "  %v\n", err)
+			i--
+			continue
+		}
+		if p != steps[i].Priority {
+			changes[i] = p
+		}
+	}
+	return changes, nil
+}
+
+// replaceStatusNextSteps swaps the top-level next_steps block of a status
+// file for steps, leaving every other field untouched
+func replaceStatusNextSteps(content string, steps []schema.NextStep) string {
+	var kept []string
+	inBlock := false
+	for _, line := range strings.Split(strings.TrimRight(content, "\n"), "\n") {
+		topLevel := line != "" && line[0] != ' ' && line[0] != '\t' && line[0] != '-'
+		if topLevel {
+			inBlock = strings.HasPrefix(line, "next_steps:")
+		}
+		if inBlock || line == "" {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	result := strings.Join(kept, "\n")
+	if result != "" {
+		result += "\n"
+	}
+	return result + renderStatusNextSteps(steps)
+}
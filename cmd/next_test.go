@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/dmoose/checkpoint/internal/schema"
+)
+
+func TestParsePriorityAssignments(t *testing.T) {
+	changes, err := parsePriorityAssignments([]string{"3=HIGH", "1=none"}, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if changes[2] != "high" || changes[0] != "" || len(changes) != 2 {
+		t.Errorf("unexpected changes: %v", changes)
+	}
+
+	for _, bad := range []string{"4=high", "0=low", "x=low", "2", "2=urgent"} {
+		if _, err := parsePriorityAssignments([]string{bad}, 3); err == nil {
+			t.Errorf("expected error for --set %q", bad)
+		}
+	}
+}
+
+func TestPromptPriorities(t *testing.T) {
+	steps := []schema.NextStep{{Summary: "A", Priority: "low"}, {Summary: "B"}, {Summary: "C", Priority: "med"}}
+	// Blank keeps, invalid answers are asked again, unchanged values are not recorded
+	changes, err := promptPriorities(strings.NewReader("\nurgent\nhigh\nmed\n"), io.Discard, steps)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changes) != 1 || changes[1] != "high" {
+		t.Errorf("unexpected changes: %v", changes)
+	}
+}
+
+func TestReplaceStatusNextSteps(t *testing.T) {
+	content := `last_commit_hash: "abc123"
+next_steps:
+  - summary: "Write docs"
+    priority: "low"
+  - summary: "write docs"
+  - summary: "Add tests"
+status: "success"
+changes_count: 2
+`
+	steps := dedupeNextSteps(schema.ExtractNextStepsFromStatus(content))
+	if len(steps) != 2 {
+		t.Fatalf("expected 2 deduped steps, got %+v", steps)
+	}
+	steps[1].Priority = "high"
+
+	updated := replaceStatusNextSteps(content, steps)
+	for _, want := range []string{`last_commit_hash: "abc123"`, `status: "success"`, "changes_count: 2"} {
+		if !strings.Contains(updated, want) {
+			t.Errorf("expected %q to be kept:\n%s", want, updated)
+		}
+	}
+	got := schema.ExtractNextStepsFromStatus(updated)
+	if len(got) != 2 || got[0].Priority != "low" || got[1].Summary != "Add tests" || got[1].Priority != "high" {
+		t.Errorf("unexpected next steps after update: %+v", got)
+	}
+}
+
+func TestExportNextSteps(t *testing.T) {
+	steps := []schema.NextStep{
+		{Summary: "Add retries", Details: "Don't retry 4xx", Priority: "high", Scope: "http"},
+		{Summary: "Write docs"},
+	}
+
+	var sb strings.Builder
+	if err := exportNextSteps(&sb, steps, "github"); err != nil {
+		t.Fatalf("github: %v", err)
+	}
+	want := "gh issue create --title 'Add retries' --body 'Don'\\''t retry 4xx' --label 'scope:http' --label 'priority:high'\n" +
+		"gh issue create --title 'Write docs' --body ''\n"
+	if sb.String() != want {
+		t.Errorf("github output:\n%s\nwant:\n%s", sb.String(), want)
+	}
+
+	sb.Reset()
+	if err := exportNextSteps(&sb, steps, "gitlab"); err != nil {
+		t.Fatalf("gitlab: %v", err)
+	}
+	if !strings.Contains(sb.String(), "--label 'scope:http,priority:high'") {
+		t.Errorf("gitlab output missing labels:\n%s", sb.String())
+	}
+
+	sb.Reset()
+	if err := exportNextSteps(&sb, steps, "json"); err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	if !strings.Contains(sb.String(), `"title": "Write docs"`) || !strings.Contains(sb.String(), `"labels": []`) {
+		t.Errorf("json output:\n%s", sb.String())
+	}
+
+	if err := exportNextSteps(&sb, steps, "jira"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
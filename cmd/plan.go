@@ -55,7 +55,7 @@ func Plan(projectPath string, fresh bool) {
 		fmt.Println()
 		fmt.Println("Current session:")
 		fmt.Println()
-		showSession(projectPath, false)
+		showSession(projectPath, false, false)
 		return
 	}
 
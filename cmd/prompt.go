@@ -18,10 +18,56 @@ var promptOpts struct {
 	json bool
 }
 
+var promptValidateOpts struct {
+	json bool
+}
+
 func init() {
 	rootCmd.AddCommand(promptCmd)
 	promptCmd.Flags().StringArrayVar(&promptOpts.vars, "var", nil, "Variable substitution (format: name=value)")
 	promptCmd.Flags().BoolVar(&promptOpts.json, "json", false, "Output as JSON (for list)")
+
+	promptCmd.AddCommand(promptValidateCmd)
+	promptValidateCmd.Flags().BoolVar(&promptValidateOpts.json, "json", false, "Output as JSON")
+
+	promptCmd.AddCommand(promptDiffCmd)
+}
+
+var promptDiffCmd = &cobra.Command{
+	Use:   "diff <id>",
+	Short: "Compare a project prompt with the built-in default",
+	Long: `Shows a unified diff from the built-in default of a prompt (as written by
+'checkpoint init') to the project's copy in .checkpoint/prompts/. Use it to
+review your customizations, or to see what changed in the defaults after
+upgrading checkpoint.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completePromptIDs,
+	Run: func(cmd *cobra.Command, args []string) {
+		absPath, err := filepath.Abs(".")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: cannot resolve path: %v\n", err)
+			os.Exit(1)
+		}
+		PromptDiff(absPath, args[0])
+	},
+}
+
+var promptValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the prompt library for errors",
+	Long: `Validates .checkpoint/prompts/prompts.yaml: unique non-empty ids, readable
+non-empty template files, and that every {{variable}} used in a template is
+declared (per prompt, in global variables, or automatic) and every declared
+variable is used. Exits non-zero when problems are found.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		absPath, err := filepath.Abs(".")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: cannot resolve path: %v\n", err)
+			os.Exit(1)
+		}
+		PromptValidate(absPath, promptValidateOpts.json)
+	},
 }
 
 var promptCmd = &cobra.Command{
@@ -29,8 +75,9 @@ var promptCmd = &cobra.Command{
 	Short: "Display LLM prompts from project",
 	Long: `Display and use LLM prompts from .checkpoint/prompts/.
 Without arguments, lists all available prompts.`,
-	Aliases: []string{"prompts"},
-	Args:    cobra.MaximumNArgs(1),
+	Aliases:           []string{"prompts"},
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completePromptIDs,
 	Run: func(cmd *cobra.Command, args []string) {
 		projectPath := "."
 		absPath, err := filepath.Abs(projectPath)
@@ -86,6 +133,108 @@ func Prompt(projectPath string, promptID string, vars map[string]string, jsonOut
 	showPrompt(config, promptsDir, projectPath, promptID, vars)
 }
 
+// PromptValidate reports problems in the prompt library, grouped by prompt id
+func PromptValidate(projectPath string, jsonOutput bool) {
+	promptsDir := filepath.Join(projectPath, ".checkpoint", "prompts")
+	config, err := prompts.LoadPromptsConfig(promptsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "hint: Check that .checkpoint/prompts/prompts.yaml exists and is valid\n")
+		os.Exit(1)
+	}
+
+	issues := prompts.ValidatePrompts(config, promptsDir)
+
+	if jsonOutput {
+		if issues == nil {
+			issues = []prompts.ValidationIssue{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(map[string]any{
+			"valid":   len(issues) == 0,
+			"prompts": len(config.Prompts),
+			"issues":  issues,
+		})
+	} else if len(issues) == 0 {
+		fmt.Printf("✓ %d prompt(s) valid\n", len(config.Prompts))
+	} else {
+		fmt.Printf("❌ %d problem(s) found:\n", len(issues))
+		current := ""
+		for _, issue := range issues {
+			if issue.PromptID != current {
+				current = issue.PromptID
+				fmt.Printf("\n%s:\n", current)
+			}
+			fmt.Printf("  - %s\n", issue.Message)
+		}
+	}
+
+	if len(issues) > 0 {
+		os.Exit(1)
+	}
+}
+
+// PromptDiff prints how a project prompt differs from the built-in default
+func PromptDiff(projectPath string, promptID string) {
+	promptsDir := filepath.Join(projectPath, ".checkpoint", "prompts")
+	config, err := prompts.LoadPromptsConfig(promptsDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "hint: Check that .checkpoint/prompts/prompts.yaml exists and is valid\n")
+		os.Exit(1)
+	}
+	local, err := prompts.GetPrompt(config, promptsDir, promptID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
+	def, err := defaultPrompt(promptID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	if def == nil {
+		fmt.Fprintf(os.Stderr, "error: '%s' is a project prompt with no built-in default\n", promptID)
+		os.Exit(1)
+	}
+
+	diff := prompts.UnifiedDiff(
+		"default/"+def.Definition.File,
+		filepath.ToSlash(filepath.Join(".checkpoint", "prompts", local.Definition.File)),
+		def.Template, local.Template, 3)
+	if diff == "" {
+		fmt.Printf("✓ Prompt '%s' matches the built-in default\n", promptID)
+		return
+	}
+	fmt.Print(diff)
+}
+
+// defaultPrompt loads the built-in version of a prompt by writing the default
+// library to a scratch directory; nil when there is no built-in prompt with id
+func defaultPrompt(promptID string) (*prompts.Prompt, error) {
+	dir, err := os.MkdirTemp("", "checkpoint-prompts-")
+	if err != nil {
+		return nil, fmt.Errorf("create temp dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	if _, _, err := writeDefaultPrompts(dir, ""); err != nil {
+		return nil, err
+	}
+	config, err := prompts.LoadPromptsConfig(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range config.Prompts {
+		if p.ID == promptID {
+			return prompts.GetPrompt(config, dir, promptID)
+		}
+	}
+	return nil, nil
+}
+
 // listPrompts displays all available prompts grouped by category
 func listPrompts(config *prompts.PromptsConfig, jsonOutput bool) {
 	// Get all prompts
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefaultPrompt(t *testing.T) {
+	p, err := defaultPrompt("fix-bug")
+	if err != nil {
+		t.Fatalf("defaultPrompt: %v", err)
+	}
+	if p == nil || p.Definition.File != "fix-bug.md" || !strings.Contains(p.Template, "{{bug_description}}") {
+		t.Fatalf("unexpected built-in prompt: %+v", p)
+	}
+
+	if p, err := defaultPrompt("team-custom"); err != nil || p != nil {
+		t.Errorf("expected no built-in prompt, got %+v, %v", p, err)
+	}
+}
@@ -6,7 +6,10 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/dmoose/checkpoint/pkg/config"
 
@@ -19,9 +22,20 @@ var searchOpts struct {
 	pattern  bool
 	decision bool
 	scope    string
+	ref      string
 	recent   int
 	context  bool
 	json     bool
+	truncate int
+	full     bool
+	across   string
+	fuzzy    bool
+	fuzzyMax int
+	regex    bool
+	since    string
+	until    string
+	sort     string
+	reverse  bool
 }
 
 func init() {
@@ -30,16 +44,55 @@ func init() {
 	searchCmd.Flags().BoolVar(&searchOpts.pattern, "pattern", false, "Search established patterns")
 	searchCmd.Flags().BoolVar(&searchOpts.decision, "decision", false, "Search decisions made")
 	searchCmd.Flags().StringVar(&searchOpts.scope, "scope", "", "Filter by scope")
+	_ = searchCmd.RegisterFlagCompletionFunc("scope", completeSearchScope)
+	searchCmd.Flags().StringVar(&searchOpts.ref, "ref", "", "Only changes referencing this issue/ticket ID (e.g. JIRA-123)")
 	searchCmd.Flags().IntVar(&searchOpts.recent, "recent", 0, "Limit to recent N checkpoints")
 	searchCmd.Flags().BoolVar(&searchOpts.context, "context", false, "Search context file")
 	searchCmd.Flags().BoolVar(&searchOpts.json, "json", false, "Output as JSON")
+	searchCmd.Flags().IntVar(&searchOpts.truncate, "truncate", defaultSearchTruncate, "Truncate each field to N characters in human output (0 = no limit)")
+	searchCmd.Flags().BoolVar(&searchOpts.full, "full", false, "Show full field values (disable truncation)")
+	searchCmd.Flags().StringVar(&searchOpts.since, "since", "", "Only search entries at or after this time (YYYY-MM-DD, RFC3339, or 2w/3d ago)")
+	searchCmd.Flags().StringVar(&searchOpts.until, "until", "", "Only search entries at or before this time (YYYY-MM-DD includes the whole day)")
+	searchCmd.Flags().BoolVar(&searchOpts.regex, "regex", false, "Treat the query as a case-insensitive regular expression")
+	searchCmd.Flags().BoolVar(&searchOpts.fuzzy, "fuzzy", false, "Tolerate typos: also match words within --fuzzy-distance edits")
+	searchCmd.Flags().IntVar(&searchOpts.fuzzyMax, "fuzzy-distance", defaultFuzzyDistance, "Max edit distance per word for --fuzzy")
+	searchCmd.Flags().StringVar(&searchOpts.sort, "sort", defaultSearchSort, "Order results by timestamp, score or scope")
+	searchCmd.Flags().BoolVar(&searchOpts.reverse, "reverse", false, "Reverse the --sort order (e.g. oldest first)")
+	searchCmd.Flags().StringVar(&searchOpts.across, "across", defaultSearchSources, "Comma-separated sources to search: changelog, context, session, learnings")
 }
 
 var searchCmd = &cobra.Command{
 	Use:   "search <query>",
 	Short: "Search checkpoint history",
-	Long:  `Search changelog and context files for patterns, decisions, and failures.`,
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Search changelog and context files for patterns, decisions, and failures.
+
+Use --across to choose sources, e.g. --across changelog,context,session,learnings
+to also search the current session (goals, decisions, learnings) and the
+learnings log. The default is changelog,context.
+
+Use --fuzzy to tolerate typos: each query word may be up to
+--fuzzy-distance edits (default 2) from a word in the text; shorter words
+allow fewer edits. Fuzzy-only matches are listed after exact matches.
+
+Use --regex to match the query as a case-insensitive regular expression
+against every field of every source. Without it (or --fuzzy) the query is
+a plain case-insensitive substring.
+
+Use --since and/or --until to limit changelog, context and learnings
+entries to a time window (e.g. --since 2025-01-01 --until 2025-03-31) when
+bisecting when a decision or pattern appeared. Entries without a parseable
+timestamp are skipped while a window is set.
+
+Use --ref to find the checkpoint changes that reference an issue or ticket
+(e.g. --ref JIRA-123 or --ref '#456'); the ID is matched case-insensitively
+against each change's refs and a query is optional. Only the changelog
+records refs, so other sources are not searched with --ref.
+
+Use --sort to order results: timestamp (default, newest first), score
+(relevance: the order entries were found in) or scope (alphabetical, results
+without a scope last). --reverse flips the order, e.g. oldest first. Exact
+matches always come before fuzzy-only matches.`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		projectPath := "."
 		absPath, err := filepath.Abs(projectPath)
@@ -48,14 +101,38 @@ var searchCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		sources, err := parseSearchSources(searchOpts.across)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+
+		since, until, err := parseSearchWindow(searchOpts.since, searchOpts.until, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			fmt.Fprintf(os.Stderr, "hint: use YYYY-MM-DD, RFC3339, or a relative time like 2w\n")
+			os.Exit(1)
+		}
+
 		opts := SearchOptions{
 			Failed:   searchOpts.failed,
 			Pattern:  searchOpts.pattern,
 			Decision: searchOpts.decision,
 			Scope:    searchOpts.scope,
+			Ref:      searchOpts.ref,
 			Recent:   searchOpts.recent,
 			Context:  searchOpts.context,
 			JSON:     searchOpts.json,
+			Truncate: searchOpts.truncate,
+			Full:     searchOpts.full,
+			Across:   sources,
+			Fuzzy:    searchOpts.fuzzy,
+			FuzzyMax: searchOpts.fuzzyMax,
+			Regex:    searchOpts.regex,
+			Sort:     searchOpts.sort,
+			Reverse:  searchOpts.reverse,
+			Since:    since,
+			Until:    until,
 		}
 		if len(args) > 0 {
 			opts.Query = args[0]
@@ -67,29 +144,221 @@ var searchCmd = &cobra.Command{
 // SearchOptions holds flags for the search command
 type SearchOptions struct {
 	Query    string
-	Failed   bool   // Search failed approaches
-	Pattern  bool   // Search established patterns
-	Decision bool   // Search decisions
-	Scope    string // Filter by scope
-	Recent   int    // Limit to recent N entries
-	Context  bool   // Search context file instead of changelog
-	JSON     bool   // Output as JSON
+	Failed   bool      // Search failed approaches
+	Pattern  bool      // Search established patterns
+	Decision bool      // Search decisions
+	Scope    string    // Filter by scope
+	Ref      string    // Only changes whose refs include this ID (changelog only)
+	Recent   int       // Limit to recent N entries
+	Context  bool      // Search context file instead of changelog
+	JSON     bool      // Output as JSON
+	Truncate int       // Max characters per field in human output (0 = no limit)
+	Full     bool      // Disable truncation
+	Across   []string  // Sources to search; empty means changelog and context
+	Fuzzy    bool      // Also match words within FuzzyMax edits
+	FuzzyMax int       // Max edit distance per word (0 uses defaultFuzzyDistance)
+	Regex    bool      // Match the query as a case-insensitive regular expression
+	Since    time.Time // Skip entries before this time (zero = unbounded)
+	Until    time.Time // Skip entries after this time (zero = unbounded)
+	Sort     string    // timestamp, score or scope (empty uses defaultSearchSort)
+	Reverse  bool      // Reverse the sort order
+}
+
+// defaultFuzzyDistance catches single typos and most transpositions
+const defaultFuzzyDistance = 2
+
+// fuzzyDistance returns the max edit distance for fuzzy matching, 0 when disabled
+func (o SearchOptions) fuzzyDistance() int {
+	if !o.Fuzzy {
+		return 0
+	}
+	if o.FuzzyMax <= 0 {
+		return defaultFuzzyDistance
+	}
+	return o.FuzzyMax
+}
+
+// parseSearchWindow parses --since/--until; a date-only --until covers the whole day
+func parseSearchWindow(sinceStr, untilStr string, now time.Time) (time.Time, time.Time, error) {
+	var since, until time.Time
+	var err error
+	if sinceStr != "" {
+		if since, err = parseSinceTime(sinceStr, now); err != nil {
+			return since, until, fmt.Errorf("invalid --since: %w", err)
+		}
+	}
+	if untilStr != "" {
+		if until, err = parseSinceTime(untilStr, now); err != nil {
+			return since, until, fmt.Errorf("invalid --until: %w", err)
+		}
+		if _, dateErr := time.ParseInLocation("2006-01-02", strings.TrimSpace(untilStr), now.Location()); dateErr == nil {
+			until = until.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+	}
+	if !since.IsZero() && !until.IsZero() && until.Before(since) {
+		return since, until, fmt.Errorf("--until is before --since")
+	}
+	return since, until, nil
+}
+
+// inWindow reports whether an entry timestamp falls within --since/--until;
+// without a window everything matches, with one unparseable times never do
+func (o SearchOptions) inWindow(timestamp interface{}) bool {
+	if o.Since.IsZero() && o.Until.IsZero() {
+		return true
+	}
+	var t time.Time
+	switch v := timestamp.(type) {
+	case time.Time:
+		t = v
+	case string:
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return false
+		}
+		t = parsed
+	default:
+		return false
+	}
+	if !o.Since.IsZero() && t.Before(o.Since) {
+		return false
+	}
+	return o.Until.IsZero() || !t.After(o.Until)
+}
+
+// defaultSearchSort lists the newest matches first
+const defaultSearchSort = "timestamp"
+
+// validateSearchSort checks a --sort key; empty means the default
+func validateSearchSort(key string) error {
+	switch key {
+	case "", "timestamp", "score", "scope":
+		return nil
+	}
+	return fmt.Errorf("invalid --sort '%s' (valid: timestamp, score, scope)", key)
+}
+
+// sortSearchResults orders results by key as the final step of a search.
+// Exact matches stay ahead of fuzzy-only ones; within each group timestamp
+// sorts newest first (unparseable timestamps last), score keeps the order
+// entries were found in, and scope sorts alphabetically with unscoped
+// results last. reverse flips the order within each group.
+func sortSearchResults(results []SearchResult, key string, reverse bool) {
+	if key == "" {
+		key = defaultSearchSort
+	}
+	less := func(a, b SearchResult) bool { return false }
+	switch key {
+	case "timestamp":
+		less = func(a, b SearchResult) bool {
+			ta, errA := time.Parse(time.RFC3339, a.Timestamp)
+			tb, errB := time.Parse(time.RFC3339, b.Timestamp)
+			if errA != nil || errB != nil {
+				return errA == nil && errB != nil
+			}
+			if reverse {
+				return ta.Before(tb)
+			}
+			return ta.After(tb)
+		}
+	case "scope":
+		less = func(a, b SearchResult) bool {
+			if a.Scope == "" || b.Scope == "" {
+				return a.Scope != "" && b.Scope == ""
+			}
+			if reverse {
+				return a.Scope > b.Scope
+			}
+			return a.Scope < b.Scope
+		}
+	}
+	if key == "score" && reverse {
+		for i, j := 0, len(results)-1; i < j; i, j = i+1, j-1 {
+			results[i], results[j] = results[j], results[i]
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Fuzzy != results[j].Fuzzy {
+			return !results[i].Fuzzy
+		}
+		return less(results[i], results[j])
+	})
+}
+
+// defaultSearchSources matches the sources searched before --across existed
+const defaultSearchSources = "changelog,context"
+
+// searchSources lists the valid --across values
+var searchSources = []string{"changelog", "context", "session", "learnings"}
+
+// parseSearchSources splits and validates a comma-separated --across value
+func parseSearchSources(s string) ([]string, error) {
+	var sources []string
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" || seen[part] {
+			continue
+		}
+		valid := false
+		for _, name := range searchSources {
+			if part == name {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("unknown search source '%s' (valid: %s)", part, strings.Join(searchSources, ", "))
+		}
+		seen[part] = true
+		sources = append(sources, part)
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("--across requires at least one source (valid: %s)", strings.Join(searchSources, ", "))
+	}
+	return sources, nil
+}
+
+// searches reports whether source is selected; no selection means the defaults
+func (o SearchOptions) searches(source string) bool {
+	if len(o.Across) == 0 {
+		return source == "changelog" || source == "context"
+	}
+	for _, s := range o.Across {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultSearchTruncate keeps terminal search results scannable
+const defaultSearchTruncate = 200
+
+// fieldLimit returns the per-field truncation limit; JSON output is never truncated
+func (o SearchOptions) fieldLimit() int {
+	if o.JSON || o.Full || o.Truncate < 0 {
+		return 0
+	}
+	return o.Truncate
 }
 
 // SearchResult represents a search match
 type SearchResult struct {
-	Source     string `json:"source"`      // "changelog" or "context"
-	Timestamp  string `json:"timestamp"`   // Timestamp of the entry
-	CommitHash string `json:"commit_hash"` // Commit hash if available
-	Section    string `json:"section"`     // "changes", "context", "next_steps", etc.
-	Field      string `json:"field"`       // Specific field that matched
-	Content    string `json:"content"`     // Matched content
-	MatchLine  string `json:"match_line"`  // Line containing match
+	Source     string `json:"source"`          // "changelog", "context", "session", or "learnings"
+	Timestamp  string `json:"timestamp"`       // Timestamp of the entry
+	CommitHash string `json:"commit_hash"`     // Commit hash if available
+	Section    string `json:"section"`         // "changes", "context", "next_steps", etc.
+	Scope      string `json:"scope,omitempty"` // Scope of the matched change, next step or learning
+	Field      string `json:"field"`           // Specific field that matched
+	Content    string `json:"content"`         // Matched content
+	MatchLine  string `json:"match_line"`      // Line containing match
+	Fuzzy      bool   `json:"fuzzy"`           // Matched only via --fuzzy; ranked after exact matches
 }
 
 // Search searches checkpoint history
 func Search(projectPath string, opts SearchOptions) {
-	if opts.Query == "" && !opts.Failed && !opts.Pattern && !opts.Decision {
+	if opts.Query == "" && opts.Ref == "" && !opts.Failed && !opts.Pattern && !opts.Decision {
 		fmt.Fprintf(os.Stderr, "error: search query required\n")
 		fmt.Fprintf(os.Stderr, "usage: checkpoint search <query> [flags]\n")
 		fmt.Fprintf(os.Stderr, "\nFlags:\n")
@@ -97,25 +366,63 @@ func Search(projectPath string, opts SearchOptions) {
 		fmt.Fprintf(os.Stderr, "  --pattern     Search established patterns\n")
 		fmt.Fprintf(os.Stderr, "  --decision    Search decisions made\n")
 		fmt.Fprintf(os.Stderr, "  --scope <s>   Filter by scope\n")
+		fmt.Fprintf(os.Stderr, "  --ref <id>    Changes referencing an issue/ticket ID\n")
 		fmt.Fprintf(os.Stderr, "  --recent <n>  Limit to recent N checkpoints\n")
 		fmt.Fprintf(os.Stderr, "  --context     Search context file\n")
+		fmt.Fprintf(os.Stderr, "  --truncate <n> Truncate fields to N chars (default %d)\n", defaultSearchTruncate)
+		fmt.Fprintf(os.Stderr, "  --full        Show full field values\n")
+		fmt.Fprintf(os.Stderr, "  --fuzzy       Tolerate typos (see --fuzzy-distance, default %d)\n", defaultFuzzyDistance)
+		fmt.Fprintf(os.Stderr, "  --regex       Match the query as a regular expression\n")
+		fmt.Fprintf(os.Stderr, "  --across <s>  Sources: changelog,context,session,learnings (default %s)\n", defaultSearchSources)
+		fmt.Fprintf(os.Stderr, "  --sort <k>    Order by timestamp, score or scope (--reverse flips)\n")
+		os.Exit(1)
+	}
+
+	if _, err := opts.matcher(); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := validateSearchSort(opts.Sort); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		os.Exit(1)
 	}
 
 	var results []SearchResult
 
 	// Search changelog
-	changelogPath := filepath.Join(projectPath, config.ChangelogFileName)
-	if changelogResults, err := searchChangelog(changelogPath, opts); err == nil {
-		results = append(results, changelogResults...)
+	if opts.searches("changelog") {
+		changelogPath := filepath.Join(projectPath, config.ChangelogFileName)
+		if changelogResults, err := searchChangelog(changelogPath, opts); err == nil {
+			results = append(results, changelogResults...)
+		}
 	}
 
 	// Search context file
-	contextPath := filepath.Join(projectPath, config.ContextFileName)
-	if contextResults, err := searchContext(contextPath, opts); err == nil {
-		results = append(results, contextResults...)
+	if opts.searches("context") && opts.Ref == "" {
+		contextPath := filepath.Join(projectPath, config.ContextFileName)
+		if contextResults, err := searchContext(contextPath, opts); err == nil {
+			results = append(results, contextResults...)
+		}
+	}
+
+	// Search current session
+	if opts.searches("session") && opts.Ref == "" {
+		sessionPath := filepath.Join(projectPath, sessionFileName)
+		if sessionResults, err := searchSession(sessionPath, opts); err == nil {
+			results = append(results, sessionResults...)
+		}
 	}
 
+	// Search learnings log
+	if opts.searches("learnings") && opts.Ref == "" {
+		learningsPath := filepath.Join(projectPath, config.CheckpointDir, config.LearningsFileName)
+		if learningsResults, err := searchLearnings(learningsPath, opts); err == nil {
+			results = append(results, learningsResults...)
+		}
+	}
+
+	sortSearchResults(results, opts.Sort, opts.Reverse)
+
 	// Display results
 	if opts.JSON {
 		output := struct {
@@ -141,7 +448,11 @@ func Search(projectPath string, opts SearchOptions) {
 		if i > 0 {
 			fmt.Println("---")
 		}
-		fmt.Printf("[%s] %s\n", r.Source, r.Timestamp)
+		if r.Fuzzy {
+			fmt.Printf("[%s] %s (fuzzy)\n", r.Source, r.Timestamp)
+		} else {
+			fmt.Printf("[%s] %s\n", r.Source, r.Timestamp)
+		}
 		if r.CommitHash != "" {
 			fmt.Printf("Commit: %s\n", r.CommitHash[:min(8, len(r.CommitHash))])
 		}
@@ -163,6 +474,10 @@ func min(a, b int) int {
 
 // searchChangelog searches the changelog file
 func searchChangelog(path string, opts SearchOptions) ([]SearchResult, error) {
+	tm, err := opts.matcher()
+	if err != nil {
+		return nil, err
+	}
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
@@ -188,6 +503,9 @@ func searchChangelog(path string, opts SearchOptions) ([]SearchResult, error) {
 		if docType, ok := entry["document_type"].(string); ok && docType == "meta" {
 			continue
 		}
+		if !opts.inWindow(entry["timestamp"]) {
+			continue
+		}
 
 		timestamp, _ := entry["timestamp"].(string)
 		commitHash, _ := entry["commit_hash"].(string)
@@ -196,14 +514,17 @@ func searchChangelog(path string, opts SearchOptions) ([]SearchResult, error) {
 		if changes, ok := entry["changes"].([]interface{}); ok {
 			for _, change := range changes {
 				if changeMap, ok := change.(map[string]interface{}); ok {
-					if matchesSearch(changeMap, opts) {
-						content := formatChangeContent(changeMap)
+					if kind := matchesSearch(changeMap, opts, tm); kind != noMatch {
+						content := formatChangeContent(changeMap, opts.fieldLimit())
+						scope, _ := changeMap["scope"].(string)
 						results = append(results, SearchResult{
 							Source:     "changelog",
 							Timestamp:  timestamp,
 							CommitHash: commitHash,
 							Section:    "changes",
+							Scope:      scope,
 							Content:    content,
+							Fuzzy:      kind == fuzzyMatch,
 						})
 					}
 				}
@@ -214,14 +535,17 @@ func searchChangelog(path string, opts SearchOptions) ([]SearchResult, error) {
 		if steps, ok := entry["next_steps"].([]interface{}); ok {
 			for _, step := range steps {
 				if stepMap, ok := step.(map[string]interface{}); ok {
-					if matchesSearch(stepMap, opts) {
-						content := formatStepContent(stepMap)
+					if kind := matchesSearch(stepMap, opts, tm); kind != noMatch {
+						content := formatStepContent(stepMap, opts.fieldLimit())
+						scope, _ := stepMap["scope"].(string)
 						results = append(results, SearchResult{
 							Source:     "changelog",
 							Timestamp:  timestamp,
 							CommitHash: commitHash,
 							Section:    "next_steps",
+							Scope:      scope,
 							Content:    content,
+							Fuzzy:      kind == fuzzyMatch,
 						})
 					}
 				}
@@ -234,6 +558,10 @@ func searchChangelog(path string, opts SearchOptions) ([]SearchResult, error) {
 
 // searchContext searches the context file
 func searchContext(path string, opts SearchOptions) ([]SearchResult, error) {
+	tm, err := opts.matcher()
+	if err != nil {
+		return nil, err
+	}
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
@@ -255,6 +583,9 @@ func searchContext(path string, opts SearchOptions) ([]SearchResult, error) {
 			continue
 		}
 
+		if !opts.inWindow(entry["timestamp"]) {
+			continue
+		}
 		timestamp, _ := entry["timestamp"].(string)
 		commitHash, _ := entry["commit_hash"].(string)
 
@@ -268,8 +599,8 @@ func searchContext(path string, opts SearchOptions) ([]SearchResult, error) {
 		if opts.Failed || (opts.Query != "" && !opts.Pattern && !opts.Decision) {
 			if failed, ok := context["failed_approaches"].([]interface{}); ok {
 				for _, item := range failed {
-					if matchesQuery(item, opts.Query) || opts.Failed {
-						content := formatContextItem("failed_approach", item)
+					if kind := matchesQuery(item, tm); kind != noMatch || opts.Failed {
+						content := formatContextItem("failed_approach", item, opts.fieldLimit())
 						results = append(results, SearchResult{
 							Source:     "context",
 							Timestamp:  timestamp,
@@ -277,6 +608,7 @@ func searchContext(path string, opts SearchOptions) ([]SearchResult, error) {
 							Section:    "context",
 							Field:      "failed_approaches",
 							Content:    content,
+							Fuzzy:      kind == fuzzyMatch,
 						})
 					}
 				}
@@ -287,8 +619,8 @@ func searchContext(path string, opts SearchOptions) ([]SearchResult, error) {
 		if opts.Pattern || (opts.Query != "" && !opts.Failed && !opts.Decision) {
 			if patterns, ok := context["established_patterns"].([]interface{}); ok {
 				for _, item := range patterns {
-					if matchesQuery(item, opts.Query) || opts.Pattern {
-						content := formatContextItem("pattern", item)
+					if kind := matchesQuery(item, tm); kind != noMatch || opts.Pattern {
+						content := formatContextItem("pattern", item, opts.fieldLimit())
 						results = append(results, SearchResult{
 							Source:     "context",
 							Timestamp:  timestamp,
@@ -296,6 +628,7 @@ func searchContext(path string, opts SearchOptions) ([]SearchResult, error) {
 							Section:    "context",
 							Field:      "established_patterns",
 							Content:    content,
+							Fuzzy:      kind == fuzzyMatch,
 						})
 					}
 				}
@@ -306,8 +639,8 @@ func searchContext(path string, opts SearchOptions) ([]SearchResult, error) {
 		if opts.Decision || (opts.Query != "" && !opts.Failed && !opts.Pattern) {
 			if decisions, ok := context["decisions_made"].([]interface{}); ok {
 				for _, item := range decisions {
-					if matchesQuery(item, opts.Query) || opts.Decision {
-						content := formatContextItem("decision", item)
+					if kind := matchesQuery(item, tm); kind != noMatch || opts.Decision {
+						content := formatContextItem("decision", item, opts.fieldLimit())
 						results = append(results, SearchResult{
 							Source:     "context",
 							Timestamp:  timestamp,
@@ -315,6 +648,7 @@ func searchContext(path string, opts SearchOptions) ([]SearchResult, error) {
 							Section:    "context",
 							Field:      "decisions_made",
 							Content:    content,
+							Fuzzy:      kind == fuzzyMatch,
 						})
 					}
 				}
@@ -325,8 +659,8 @@ func searchContext(path string, opts SearchOptions) ([]SearchResult, error) {
 		if opts.Query != "" && !opts.Failed && !opts.Pattern && !opts.Decision {
 			if insights, ok := context["key_insights"].([]interface{}); ok {
 				for _, item := range insights {
-					if matchesQuery(item, opts.Query) {
-						content := formatContextItem("insight", item)
+					if kind := matchesQuery(item, tm); kind != noMatch {
+						content := formatContextItem("insight", item, opts.fieldLimit())
 						results = append(results, SearchResult{
 							Source:     "context",
 							Timestamp:  timestamp,
@@ -334,6 +668,47 @@ func searchContext(path string, opts SearchOptions) ([]SearchResult, error) {
 							Section:    "context",
 							Field:      "key_insights",
 							Content:    content,
+							Fuzzy:      kind == fuzzyMatch,
+						})
+					}
+				}
+			}
+		}
+
+		// Search conversation context
+		if opts.Query != "" && !opts.Failed && !opts.Pattern && !opts.Decision {
+			if exchanges, ok := context["conversation_context"].([]interface{}); ok {
+				for _, item := range exchanges {
+					if kind := matchesQuery(item, tm); kind != noMatch {
+						content := formatContextItem("conversation", item, opts.fieldLimit())
+						results = append(results, SearchResult{
+							Source:     "context",
+							Timestamp:  timestamp,
+							CommitHash: commitHash,
+							Section:    "context",
+							Field:      "conversation_context",
+							Content:    content,
+							Fuzzy:      kind == fuzzyMatch,
+						})
+					}
+				}
+			}
+		}
+
+		// Search external context attached with commit --context-from
+		if opts.Query != "" && !opts.Failed && !opts.Pattern && !opts.Decision {
+			if external, ok := context["external_context"].([]interface{}); ok {
+				for _, item := range external {
+					if kind := matchesQuery(item, tm); kind != noMatch {
+						content := formatExternalContext(item, opts.fieldLimit())
+						results = append(results, SearchResult{
+							Source:     "context",
+							Timestamp:  timestamp,
+							CommitHash: commitHash,
+							Section:    "context",
+							Field:      "external_context",
+							Content:    content,
+							Fuzzy:      kind == fuzzyMatch,
 						})
 					}
 				}
@@ -343,14 +718,15 @@ func searchContext(path string, opts SearchOptions) ([]SearchResult, error) {
 		// Search problem statement
 		if opts.Query != "" {
 			if problem, ok := context["problem_statement"].(string); ok {
-				if matchesQueryString(problem, opts.Query) {
+				if kind := matchesQueryString(problem, tm); kind != noMatch {
 					results = append(results, SearchResult{
 						Source:     "context",
 						Timestamp:  timestamp,
 						CommitHash: commitHash,
 						Section:    "context",
 						Field:      "problem_statement",
-						Content:    problem,
+						Content:    truncateField(problem, opts.fieldLimit()),
+						Fuzzy:      kind == fuzzyMatch,
 					})
 				}
 			}
@@ -360,6 +736,109 @@ func searchContext(path string, opts SearchOptions) ([]SearchResult, error) {
 	return results, nil
 }
 
+// searchSession searches goals, decisions, and learnings in the session file.
+// --decision limits it to decisions; --failed and --pattern have no session
+// counterpart and skip it.
+func searchSession(path string, opts SearchOptions) ([]SearchResult, error) {
+	if opts.Failed || opts.Pattern {
+		return nil, nil
+	}
+	tm, err := opts.matcher()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var session SessionState
+	if err := yaml.Unmarshal(data, &session); err != nil {
+		return nil, err
+	}
+
+	var results []SearchResult
+	add := func(field, content string, kind matchKind) {
+		results = append(results, SearchResult{
+			Source:    "session",
+			Timestamp: session.Updated,
+			Section:   "session",
+			Field:     field,
+			Content:   content,
+			Fuzzy:     kind == fuzzyMatch,
+		})
+	}
+
+	if !opts.Decision {
+		for _, g := range session.Goals {
+			if kind := matchesQuery(g, tm); kind != noMatch {
+				add("goals", truncateField(g, opts.fieldLimit()), kind)
+			}
+		}
+	}
+	for _, d := range session.Decisions {
+		item := map[string]interface{}{"decision": d.Decision, "rationale": d.Rationale}
+		if kind := matchesQuery(item, tm); kind != noMatch {
+			add("decisions", formatContextItem("decision", item, opts.fieldLimit()), kind)
+		}
+	}
+	if !opts.Decision {
+		for _, l := range session.Learnings {
+			if kind := matchesQuery(l, tm); kind != noMatch {
+				add("learnings", truncateField(l, opts.fieldLimit()), kind)
+			}
+		}
+	}
+	return results, nil
+}
+
+// searchLearnings searches the learnings log; it only answers text queries
+func searchLearnings(path string, opts SearchOptions) ([]SearchResult, error) {
+	if opts.Query == "" || opts.Failed || opts.Pattern || opts.Decision {
+		return nil, nil
+	}
+	tm, err := opts.matcher()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []SearchResult
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	for {
+		var entry struct {
+			Timestamp string   `yaml:"timestamp"`
+			Learning  string   `yaml:"learning"`
+			Tags      []string `yaml:"tags"`
+			Scope     string   `yaml:"scope"`
+		}
+		if err := decoder.Decode(&entry); err != nil {
+			break
+		}
+		if entry.Learning == "" || !opts.inWindow(entry.Timestamp) {
+			continue
+		}
+		kind := matchesQuery(entry.Learning, tm)
+		for _, tag := range entry.Tags {
+			kind = max(kind, matchesQuery(tag, tm))
+		}
+		if kind != noMatch {
+			results = append(results, SearchResult{
+				Source:    "learnings",
+				Timestamp: entry.Timestamp,
+				Section:   "learnings",
+				Scope:     entry.Scope,
+				Field:     "learning",
+				Content:   truncateField(entry.Learning, opts.fieldLimit()),
+				Fuzzy:     kind == fuzzyMatch,
+			})
+		}
+	}
+	return results, nil
+}
+
 func splitYAMLDocuments(content string) []string {
 	var docs []string
 	parts := strings.Split(content, "\n---")
@@ -372,138 +851,296 @@ func splitYAMLDocuments(content string) []string {
 	return docs
 }
 
-func matchesSearch(m map[string]interface{}, opts SearchOptions) bool {
+// matchKind records how a query matched so exact hits can rank above fuzzy ones
+type matchKind int
+
+const (
+	noMatch matchKind = iota
+	fuzzyMatch
+	exactMatch
+)
+
+func matchesSearch(m map[string]interface{}, opts SearchOptions, tm textMatcher) matchKind {
 	// Check scope filter
 	if opts.Scope != "" {
 		if scope, ok := m["scope"].(string); ok {
 			if !strings.Contains(strings.ToLower(scope), strings.ToLower(opts.Scope)) {
-				return false
+				return noMatch
 			}
 		} else {
-			return false
+			return noMatch
 		}
 	}
 
+	// Check ref filter; only changes carry refs
+	if opts.Ref != "" && !hasRef(m["refs"], opts.Ref) {
+		return noMatch
+	}
+
 	// Check query
 	if opts.Query != "" {
-		return matchesMapQuery(m, opts.Query)
+		return matchesMapQuery(m, tm)
 	}
 
-	return true
+	return exactMatch
 }
 
-func matchesMapQuery(m map[string]interface{}, query string) bool {
-	query = strings.ToLower(query)
+// matchesMapQuery returns the best match across the map's string values
+func matchesMapQuery(m map[string]interface{}, tm textMatcher) matchKind {
+	best := noMatch
 	for _, v := range m {
 		switch val := v.(type) {
 		case string:
-			if strings.Contains(strings.ToLower(val), query) {
-				return true
-			}
+			best = max(best, tm.match(val))
 		case []interface{}:
 			for _, item := range val {
 				if str, ok := item.(string); ok {
-					if strings.Contains(strings.ToLower(str), query) {
-						return true
-					}
+					best = max(best, tm.match(str))
 				}
 			}
 		}
+		if best == exactMatch {
+			break
+		}
+	}
+	return best
+}
+
+// hasRef reports whether a decoded refs list contains ref, ignoring case
+// and surrounding whitespace
+func hasRef(refs interface{}, ref string) bool {
+	list, ok := refs.([]interface{})
+	if !ok {
+		return false
+	}
+	ref = strings.TrimSpace(ref)
+	for _, r := range list {
+		if s, ok := r.(string); ok && strings.EqualFold(strings.TrimSpace(s), ref) {
+			return true
+		}
 	}
 	return false
 }
 
-func matchesQuery(item interface{}, query string) bool {
-	if query == "" {
-		return true
+func matchesQuery(item interface{}, tm textMatcher) matchKind {
+	if tm.query == "" {
+		return exactMatch
 	}
-	query = strings.ToLower(query)
 
 	switch v := item.(type) {
 	case string:
-		return strings.Contains(strings.ToLower(v), query)
+		return tm.match(v)
 	case map[string]interface{}:
-		return matchesMapQuery(v, query)
+		return matchesMapQuery(v, tm)
 	}
-	return false
+	return noMatch
+}
+
+func matchesQueryString(s string, tm textMatcher) matchKind {
+	if tm.query == "" {
+		return noMatch
+	}
+	return tm.match(s)
+}
+
+// textMatcher applies one matching strategy to every searched field
+type textMatcher struct {
+	query string
+	re    *regexp.Regexp // compiled query for --regex
+	fuzzy int            // max edit distance for --fuzzy, 0 when disabled
 }
 
-func matchesQueryString(s, query string) bool {
-	if query == "" {
+// matcher builds the matcher for the selected mode; an invalid --regex
+// pattern is an error rather than a silent fallback to substring matching
+func (o SearchOptions) matcher() (textMatcher, error) {
+	tm := textMatcher{query: o.Query, fuzzy: o.fuzzyDistance()}
+	if !o.Regex || o.Query == "" {
+		return tm, nil
+	}
+	if o.Fuzzy {
+		return tm, fmt.Errorf("--regex and --fuzzy cannot be combined")
+	}
+	re, err := regexp.Compile("(?i)" + o.Query)
+	if err != nil {
+		return tm, fmt.Errorf("invalid --regex pattern: %w", err)
+	}
+	tm.re = re
+	return tm, nil
+}
+
+// match reports how s matches: the regex when set, otherwise substring
+// with optional fuzzy fallback
+func (tm textMatcher) match(s string) matchKind {
+	if tm.re != nil {
+		if tm.re.MatchString(s) {
+			return exactMatch
+		}
+		return noMatch
+	}
+	return matchText(s, tm.query, tm.fuzzy)
+}
+
+// matchText matches query as a case-insensitive substring, falling back to
+// fuzzy word matching when fuzzy (the max edit distance) is positive
+func matchText(s, query string, fuzzy int) matchKind {
+	if strings.Contains(strings.ToLower(s), strings.ToLower(query)) {
+		return exactMatch
+	}
+	if fuzzy > 0 && fuzzyContains(s, query, fuzzy) {
+		return fuzzyMatch
+	}
+	return noMatch
+}
+
+// fuzzyContains reports whether every word of query is within maxDist edits
+// of some word in s. Short words get a tighter limit (a third of their
+// length, at least 1) so "api" does not match every three-letter word.
+func fuzzyContains(s, query string, maxDist int) bool {
+	words := searchWords(s)
+	terms := searchWords(query)
+	if len(terms) == 0 {
 		return false
 	}
-	// Support regex if query contains special chars
-	if strings.ContainsAny(query, ".*+?[](){}|\\^$") {
-		if re, err := regexp.Compile("(?i)" + query); err == nil {
-			return re.MatchString(s)
+	for _, term := range terms {
+		limit := min(maxDist, max(1, len([]rune(term))/3))
+		found := false
+		for _, w := range words {
+			if levenshtein(term, w) <= limit {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
 		}
 	}
-	return strings.Contains(strings.ToLower(s), strings.ToLower(query))
+	return true
 }
 
-func formatChangeContent(m map[string]interface{}) string {
+// searchWords splits s into lowercase runs of letters and digits
+func searchWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// levenshtein returns the edit distance between a and b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(min(prev[j]+1, curr[j-1]+1), prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func formatChangeContent(m map[string]interface{}, limit int) string {
 	var sb strings.Builder
 	if summary, ok := m["summary"].(string); ok {
-		sb.WriteString(fmt.Sprintf("Summary: %s\n", summary))
+		sb.WriteString(fmt.Sprintf("Summary: %s\n", truncateField(summary, limit)))
 	}
 	if details, ok := m["details"].(string); ok && details != "" {
-		sb.WriteString(fmt.Sprintf("Details: %s\n", details))
+		sb.WriteString(fmt.Sprintf("Details: %s\n", truncateField(details, limit)))
 	}
 	if changeType, ok := m["change_type"].(string); ok {
-		sb.WriteString(fmt.Sprintf("Type: %s\n", changeType))
+		sb.WriteString(fmt.Sprintf("Type: %s\n", truncateField(changeType, limit)))
 	}
 	if scope, ok := m["scope"].(string); ok {
-		sb.WriteString(fmt.Sprintf("Scope: %s\n", scope))
+		sb.WriteString(fmt.Sprintf("Scope: %s\n", truncateField(scope, limit)))
+	}
+	if refs, ok := m["refs"].([]interface{}); ok && len(refs) > 0 {
+		var ids []string
+		for _, r := range refs {
+			if s, ok := r.(string); ok {
+				ids = append(ids, s)
+			}
+		}
+		sb.WriteString(fmt.Sprintf("Refs: %s\n", strings.Join(ids, ", ")))
 	}
 	return sb.String()
 }
 
-func formatStepContent(m map[string]interface{}) string {
+func formatStepContent(m map[string]interface{}, limit int) string {
 	var sb strings.Builder
 	if summary, ok := m["summary"].(string); ok {
-		sb.WriteString(fmt.Sprintf("Summary: %s\n", summary))
+		sb.WriteString(fmt.Sprintf("Summary: %s\n", truncateField(summary, limit)))
 	}
 	if details, ok := m["details"].(string); ok && details != "" {
-		sb.WriteString(fmt.Sprintf("Details: %s\n", details))
+		sb.WriteString(fmt.Sprintf("Details: %s\n", truncateField(details, limit)))
 	}
 	if priority, ok := m["priority"].(string); ok {
-		sb.WriteString(fmt.Sprintf("Priority: %s\n", priority))
+		sb.WriteString(fmt.Sprintf("Priority: %s\n", truncateField(priority, limit)))
 	}
 	if scope, ok := m["scope"].(string); ok {
-		sb.WriteString(fmt.Sprintf("Scope: %s\n", scope))
+		sb.WriteString(fmt.Sprintf("Scope: %s\n", truncateField(scope, limit)))
 	}
 	return sb.String()
 }
 
-func formatContextItem(itemType string, item interface{}) string {
+func formatContextItem(itemType string, item interface{}, limit int) string {
 	switch v := item.(type) {
 	case string:
-		return v
+		return truncateField(v, limit)
 	case map[string]interface{}:
 		var sb strings.Builder
 		// Common fields
-		for _, key := range []string{"insight", "pattern", "decision", "approach", "description"} {
+		for _, key := range []string{"insight", "pattern", "decision", "approach", "exchange", "description"} {
 			if val, ok := v[key].(string); ok {
-				sb.WriteString(val)
+				sb.WriteString(truncateField(val, limit))
 				sb.WriteString("\n")
 				break
 			}
 		}
 		// Additional details
 		if rationale, ok := v["rationale"].(string); ok {
-			sb.WriteString(fmt.Sprintf("Rationale: %s\n", rationale))
+			sb.WriteString(fmt.Sprintf("Rationale: %s\n", truncateField(rationale, limit)))
 		}
 		if why, ok := v["why_failed"].(string); ok {
-			sb.WriteString(fmt.Sprintf("Why failed: %s\n", why))
+			sb.WriteString(fmt.Sprintf("Why failed: %s\n", truncateField(why, limit)))
 		}
 		if lessons, ok := v["lessons_learned"].(string); ok {
-			sb.WriteString(fmt.Sprintf("Lessons: %s\n", lessons))
+			sb.WriteString(fmt.Sprintf("Lessons: %s\n", truncateField(lessons, limit)))
+		}
+		if outcome, ok := v["outcome"].(string); ok {
+			sb.WriteString(fmt.Sprintf("Outcome: %s\n", truncateField(outcome, limit)))
 		}
 		if scope, ok := v["scope"].(string); ok {
-			sb.WriteString(fmt.Sprintf("Scope: %s\n", scope))
+			sb.WriteString(fmt.Sprintf("Scope: %s\n", truncateField(scope, limit)))
 		}
 		return sb.String()
 	default:
-		return fmt.Sprintf("%v", item)
+		return truncateField(fmt.Sprintf("%v", item), limit)
+	}
+}
+
+// formatExternalContext shows the source file and its (truncated) content
+func formatExternalContext(item interface{}, limit int) string {
+	m, ok := item.(map[string]interface{})
+	if !ok {
+		return truncateField(fmt.Sprintf("%v", item), limit)
+	}
+	source, _ := m["source"].(string)
+	content, _ := m["content"].(string)
+	return fmt.Sprintf("Source: %s\n%s\n", source, truncateField(content, limit))
+}
+
+// truncateField elides a field value longer than limit runes with "..."
+func truncateField(s string, limit int) string {
+	r := []rune(s)
+	if limit <= 0 || len(r) <= limit {
+		return s
 	}
+	return strings.TrimRight(string(r[:limit]), " \n") + "..."
 }
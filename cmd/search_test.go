@@ -0,0 +1,349 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSearchSources(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{"changelog,context", []string{"changelog", "context"}, false},
+		{" Session , learnings,session", []string{"session", "learnings"}, false},
+		{"changelog,wiki", nil, true},
+		{" , ", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := parseSearchSources(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("parseSearchSources(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+		}
+		if len(got) != len(tt.want) {
+			t.Fatalf("parseSearchSources(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("parseSearchSources(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestSearchSessionAndLearnings(t *testing.T) {
+	dir := t.TempDir()
+	sessionPath := filepath.Join(dir, "session.yaml")
+	session := `schema_version: "1"
+updated: "2025-01-02T00:00:00Z"
+goals:
+  - "Speed up cache warmup"
+decisions:
+  - decision: "Warm the cache lazily"
+    rationale: "Startup matters more than first request"
+learnings:
+  - "Cache keys must include the schema version"
+`
+	if err := os.WriteFile(sessionPath, []byte(session), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := searchSession(sessionPath, SearchOptions{Query: "cache"})
+	if err != nil {
+		t.Fatalf("searchSession error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected 3 session matches, got %+v", results)
+	}
+	for i, field := range []string{"goals", "decisions", "learnings"} {
+		if results[i].Source != "session" || results[i].Field != field {
+			t.Errorf("result %d: expected session/%s, got %s/%s", i, field, results[i].Source, results[i].Field)
+		}
+	}
+
+	results, _ = searchSession(sessionPath, SearchOptions{Decision: true})
+	if len(results) != 1 || results[0].Field != "decisions" {
+		t.Errorf("expected only the decision with --decision, got %+v", results)
+	}
+
+	learningsPath := filepath.Join(dir, "learnings.yml")
+	learnings := "---\ntimestamp: 2025-01-01T00:00:00Z\nlearning: Retry flaky network tests once\n---\ntimestamp: 2025-01-02T00:00:00Z\nlearning: Cache warmup dominates startup\n"
+	if err := os.WriteFile(learningsPath, []byte(learnings), 0644); err != nil {
+		t.Fatal(err)
+	}
+	results, err = searchLearnings(learningsPath, SearchOptions{Query: "cache"})
+	if err != nil {
+		t.Fatalf("searchLearnings error: %v", err)
+	}
+	if len(results) != 1 || results[0].Source != "learnings" || results[0].Timestamp != "2025-01-02T00:00:00Z" {
+		t.Errorf("unexpected learnings results: %+v", results)
+	}
+}
+
+func TestSearchContextConversation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "context.yaml")
+	content := `---
+timestamp: "2025-01-02T00:00:00Z"
+commit_hash: abc1234
+context:
+  conversation_context:
+    - exchange: "Asked whether eager cache warmup is required"
+      outcome: "Lazy warmup is fine"
+    - exchange: "Discussed log format"
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	results, err := searchContext(path, SearchOptions{Query: "warmup"})
+	if err != nil {
+		t.Fatalf("searchContext error: %v", err)
+	}
+	if len(results) != 1 || results[0].Field != "conversation_context" || !strings.Contains(results[0].Content, "eager cache warmup") {
+		t.Errorf("expected one conversation_context match, got %+v", results)
+	}
+	results, _ = searchContext(path, SearchOptions{Query: "warmup", Decision: true})
+	if len(results) != 0 {
+		t.Errorf("expected --decision to skip conversation context, got %+v", results)
+	}
+}
+
+func TestMatchTextFuzzy(t *testing.T) {
+	tests := []struct {
+		text  string
+		query string
+		fuzzy int
+		want  matchKind
+	}{
+		{"Add authentication middleware", "authentication", 2, exactMatch},
+		{"Add authentication middleware", "authetication", 0, noMatch},
+		{"Add authentication middleware", "authetication", 2, fuzzyMatch},
+		{"Add authentication middleware", "authetication midleware", 2, fuzzyMatch},
+		{"Add authentication middleware", "authetication database", 2, noMatch},
+		{"Expose the API", "apo", 2, fuzzyMatch},
+		{"Expose the API", "xyz", 2, noMatch}, // three-letter words allow only one edit
+		{"Cache warmup", "warmpu", 1, noMatch},
+		{"Cache warmup", "warmpu", 2, fuzzyMatch},
+	}
+	for _, tt := range tests {
+		if got := matchText(tt.text, tt.query, tt.fuzzy); got != tt.want {
+			t.Errorf("matchText(%q, %q, %d) = %v, want %v", tt.text, tt.query, tt.fuzzy, got, tt.want)
+		}
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"authetication", "authentication", 1},
+		{"same", "same", 0},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSearchMatcherRegex(t *testing.T) {
+	tm, err := SearchOptions{Query: `auth(entication|orization)\b`, Regex: true}.matcher()
+	if err != nil {
+		t.Fatalf("matcher error: %v", err)
+	}
+	change := map[string]interface{}{"summary": "Add AUTHORIZATION checks", "scope": "api"}
+	if got := matchesMapQuery(change, tm); got != exactMatch {
+		t.Errorf("expected regex to match map values case-insensitively, got %v", got)
+	}
+	if got := matchesQueryString("authorize requests", tm); got != noMatch {
+		t.Errorf("expected no match, got %v", got)
+	}
+
+	// Without --regex the same query is a literal substring
+	plain, _ := SearchOptions{Query: "a.c"}.matcher()
+	if got := plain.match("abc"); got != noMatch {
+		t.Errorf("expected literal matching without --regex, got %v", got)
+	}
+
+	if _, err := (SearchOptions{Query: "(unclosed", Regex: true}).matcher(); err == nil {
+		t.Error("expected error for invalid regex")
+	}
+	if _, err := (SearchOptions{Query: "x", Regex: true, Fuzzy: true}).matcher(); err == nil {
+		t.Error("expected error combining --regex and --fuzzy")
+	}
+}
+
+func TestSearchWindow(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	since, until, err := parseSearchWindow("2025-01-10", "2025-01-20", now)
+	if err != nil {
+		t.Fatalf("parseSearchWindow error: %v", err)
+	}
+	opts := SearchOptions{Since: since, Until: until}
+	tests := []struct {
+		ts   interface{}
+		want bool
+	}{
+		{"2025-01-09T23:59:59Z", false},
+		{"2025-01-10T00:00:00Z", true},
+		{"2025-01-20T23:00:00Z", true}, // date-only --until covers the whole day
+		{"2025-01-21T00:00:00Z", false},
+		{time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), true},
+		{"not a time", false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := opts.inWindow(tt.ts); got != tt.want {
+			t.Errorf("inWindow(%v) = %v, want %v", tt.ts, got, tt.want)
+		}
+	}
+
+	if !(SearchOptions{}).inWindow(nil) {
+		t.Error("without a window every entry should match")
+	}
+	sinceOnly, _, _ := parseSearchWindow("2025-01-10", "", now)
+	if (SearchOptions{Since: sinceOnly}).inWindow("2025-01-01T00:00:00Z") {
+		t.Error("--since alone should exclude earlier entries")
+	}
+	if _, _, err := parseSearchWindow("2025-02-01", "2025-01-01", now); err == nil {
+		t.Error("expected error when --until is before --since")
+	}
+	if _, _, err := parseSearchWindow("yesterday", "", now); err == nil {
+		t.Error("expected error for invalid --since")
+	}
+}
+
+func TestSearchChangelogWindow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "changelog.yaml")
+	content := "---\nschema_version: \"1\"\ndocument_type: meta\n" +
+		"---\ntimestamp: \"2025-01-05T00:00:00Z\"\nchanges:\n  - summary: \"Add cache\"\n" +
+		"---\ntimestamp: \"2025-02-05T00:00:00Z\"\nchanges:\n  - summary: \"Tune cache\"\n" +
+		"---\nchanges:\n  - summary: \"Undated cache\"\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write changelog: %v", err)
+	}
+	since, _, _ := parseSearchWindow("2025-02-01", "", time.Now())
+	results, err := searchChangelog(path, SearchOptions{Query: "cache", Since: since})
+	if err != nil {
+		t.Fatalf("searchChangelog error: %v", err)
+	}
+	if len(results) != 1 || !strings.Contains(results[0].Content, "Tune cache") {
+		t.Errorf("expected only the February entry, got %+v", results)
+	}
+}
+
+func TestSearchChangelogRef(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "changelog.yaml")
+	content := "---\nschema_version: \"1\"\ndocument_type: meta\n" +
+		"---\ntimestamp: \"2025-01-05T00:00:00Z\"\nchanges:\n" +
+		"  - summary: \"Add cache\"\n    refs: [\"JIRA-123\", \"#456\"]\n" +
+		"  - summary: \"Fix typo\"\n" +
+		"next_steps:\n  - summary: \"Tune cache for JIRA-123\"\n" +
+		"---\ntimestamp: \"2025-02-05T00:00:00Z\"\nchanges:\n  - summary: \"Tune cache\"\n    refs: [\"jira-999\"]\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write changelog: %v", err)
+	}
+
+	results, err := searchChangelog(path, SearchOptions{Ref: "jira-123"})
+	if err != nil {
+		t.Fatalf("searchChangelog error: %v", err)
+	}
+	if len(results) != 1 || !strings.Contains(results[0].Content, "Add cache") {
+		t.Fatalf("expected only the change referencing JIRA-123, got %+v", results)
+	}
+	if !strings.Contains(results[0].Content, "Refs: JIRA-123, #456") {
+		t.Errorf("expected refs in content, got %q", results[0].Content)
+	}
+
+	results, _ = searchChangelog(path, SearchOptions{Ref: "#456", Query: "typo"})
+	if len(results) != 0 {
+		t.Errorf("expected query and ref to both apply, got %+v", results)
+	}
+}
+
+func TestSortSearchResults(t *testing.T) {
+	base := []SearchResult{
+		{Content: "a", Timestamp: "2025-01-01T00:00:00Z", Scope: "parser"},
+		{Content: "b", Timestamp: "2025-03-01T00:00:00Z"},
+		{Content: "c", Timestamp: "2025-02-01T00:00:00Z", Scope: "cli", Fuzzy: true},
+		{Content: "d", Timestamp: "not a time", Scope: "api"},
+	}
+	order := func(results []SearchResult) string {
+		var sb strings.Builder
+		for _, r := range results {
+			sb.WriteString(r.Content)
+		}
+		return sb.String()
+	}
+
+	tests := []struct {
+		key     string
+		reverse bool
+		want    string
+	}{
+		{key: "", want: "badc"},
+		{key: "timestamp", reverse: true, want: "abdc"},
+		{key: "score", want: "abdc"},
+		{key: "score", reverse: true, want: "dbac"},
+		{key: "scope", want: "dabc"},
+		{key: "scope", reverse: true, want: "adbc"},
+	}
+	for _, tt := range tests {
+		results := append([]SearchResult(nil), base...)
+		sortSearchResults(results, tt.key, tt.reverse)
+		if got := order(results); got != tt.want {
+			t.Errorf("sort %q reverse=%t = %s, want %s", tt.key, tt.reverse, got, tt.want)
+		}
+	}
+
+	if err := validateSearchSort("relevance"); err == nil {
+		t.Error("expected an invalid sort key to be rejected")
+	}
+}
+
+func TestTruncateField(t *testing.T) {
+	tests := []struct {
+		name  string
+		in    string
+		limit int
+		want  string
+	}{
+		{"short", "cache", 10, "cache"},
+		{"exact length", "cache", 5, "cache"},
+		{"truncated", "cache warmup", 5, "cache..."},
+		{"multi-byte by rune", "日本語のテキスト", 3, "日本語..."},
+		{"multi-byte under limit", "日本語", 3, "日本語"},
+		{"zero is no limit", "cache warmup", 0, "cache warmup"},
+		{"negative is no limit", "cache warmup", -1, "cache warmup"},
+		{"trailing whitespace trimmed", "cache \nwarmup", 7, "cache..."},
+	}
+	for _, tt := range tests {
+		if got := truncateField(tt.in, tt.limit); got != tt.want {
+			t.Errorf("%s: truncateField(%q, %d) = %q, want %q", tt.name, tt.in, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestSearchFieldLimit(t *testing.T) {
+	tests := []struct {
+		name string
+		opts SearchOptions
+		want int
+	}{
+		{"--truncate", SearchOptions{Truncate: 50}, 50},
+		{"--truncate 0", SearchOptions{Truncate: 0}, 0},
+		{"--full", SearchOptions{Truncate: 50, Full: true}, 0},
+		{"--json", SearchOptions{Truncate: 50, JSON: true}, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.opts.fieldLimit(); got != tt.want {
+			t.Errorf("%s: fieldLimit() = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
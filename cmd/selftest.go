@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/dmoose/checkpoint/internal/context"
+	"github.com/dmoose/checkpoint/internal/schema"
+	"github.com/dmoose/checkpoint/pkg/config"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var selftestOpts struct {
+	verbose bool
+}
+
+func init() {
+	rootCmd.AddCommand(selftestCmd)
+	selftestCmd.Flags().BoolVarP(&selftestOpts.verbose, "verbose", "v", false, "Show output of each step")
+}
+
+var selftestCmd = &cobra.Command{
+	Use:   "selftest",
+	Short: "Run an end-to-end check of this binary in a temp repo",
+	Long: `Runs init, check, commit and changelog verification against a throwaway
+git repository to confirm the installed binary works with the local git.
+
+No network access is needed. The temp directory is always removed, and the
+test is skipped (exit 0) when git is not installed.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		Selftest(SelftestOptions{Verbose: selftestOpts.verbose})
+	},
+}
+
+// SelftestOptions holds flags for the selftest command
+type SelftestOptions struct {
+	Verbose bool // print output of each step
+}
+
+// selftestSummary is the change recorded by the self-test checkpoint
+const selftestSummary = "Add selftest fixture"
+
+// selftestStep is one stage of the end-to-end run
+type selftestStep struct {
+	name string
+	run  func() (string, error)
+}
+
+// Selftest runs the end-to-end workflow and exits 1 if any step fails
+func Selftest(opts SelftestOptions) {
+	if _, err := exec.LookPath("git"); err != nil {
+		fmt.Println("selftest skipped: git not found in PATH")
+		return
+	}
+	bin, err := os.Executable()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: cannot locate checkpoint binary: %v\n", err)
+		os.Exit(1)
+	}
+	if err := runSelftest(bin, opts); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runSelftest runs the workflow with the checkpoint binary at bin, printing
+// one line per step; it returns an error for the first step that fails
+func runSelftest(bin string, opts SelftestOptions) error {
+	tmpDir, err := os.MkdirTemp("", "checkpoint-selftest")
+	if err != nil {
+		return fmt.Errorf("cannot create temp dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	repo := filepath.Join(tmpDir, "repo")
+	home := filepath.Join(tmpDir, "home")
+	for _, dir := range []string{repo, home} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("cannot create %s: %w", dir, err)
+		}
+	}
+
+	// Isolate from the user's home (global skills) and git config
+	env := append(os.Environ(),
+		"HOME="+home,
+		"GIT_CONFIG_NOSYSTEM=1",
+		"GIT_CONFIG_GLOBAL="+filepath.Join(home, ".gitconfig"),
+	)
+	run := func(name string, args ...string) (string, error) {
+		c := exec.Command(name, args...)
+		c.Dir = repo
+		c.Env = env
+		out, err := c.CombinedOutput()
+		return string(out), err
+	}
+
+	steps := []selftestStep{
+		{"git init", func() (string, error) {
+			for _, args := range [][]string{
+				{"init", "-q"},
+				{"config", "user.email", "selftest@example.com"},
+				{"config", "user.name", "Checkpoint Selftest"},
+				{"config", "commit.gpgsign", "false"},
+			} {
+				if out, err := run("git", args...); err != nil {
+					return out, err
+				}
+			}
+			return run("git", "--version")
+		}},
+		{"checkpoint init", func() (string, error) {
+			return run(bin, "init", ".")
+		}},
+		{"modify file", func() (string, error) {
+			return "", os.WriteFile(filepath.Join(repo, "selftest.txt"), []byte("checkpoint selftest\n"), 0644)
+		}},
+		{"checkpoint check", func() (string, error) {
+			return run(bin, "check", ".")
+		}},
+		{"fill input", func() (string, error) {
+			return "", fillSelftestInput(repo)
+		}},
+		{"checkpoint lint", func() (string, error) {
+			return run(bin, "lint", ".")
+		}},
+		{"checkpoint commit", func() (string, error) {
+			return run(bin, "commit", ".")
+		}},
+		{"verify changelog", func() (string, error) {
+			return "", verifySelftestChangelog(repo)
+		}},
+		{"verify git commit", func() (string, error) {
+			out, err := run("git", "log", "-1", "--format=%s")
+			if err == nil && !strings.Contains(out, selftestSummary) {
+				err = fmt.Errorf("last commit message does not mention %q", selftestSummary)
+			}
+			return out, err
+		}},
+	}
+
+	fmt.Println("Checkpoint Selftest")
+	fmt.Println("===================")
+	fmt.Println()
+
+	for _, step := range steps {
+		out, err := step.run()
+		if err != nil {
+			fmt.Printf("[FAIL] %s: %v\n", step.name, err)
+			if out = strings.TrimSpace(out); out != "" {
+				fmt.Println(indentLines(out, "   "))
+			}
+			fmt.Println()
+			fmt.Println("Selftest failed.")
+			return fmt.Errorf("selftest step %q failed: %w", step.name, err)
+		}
+		fmt.Printf("[OK] %s\n", step.name)
+		if opts.Verbose {
+			if out = strings.TrimSpace(out); out != "" {
+				fmt.Println(indentLines(out, "   "))
+			}
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("Selftest passed.")
+	return nil
+}
+
+// fillSelftestInput replaces the generated template with a valid single change
+func fillSelftestInput(repo string) error {
+	inputPath := filepath.Join(repo, config.InputFileName)
+	data, err := os.ReadFile(inputPath)
+	if err != nil {
+		return fmt.Errorf("read input: %w", err)
+	}
+	entry, err := schema.ParseInputFile(string(data))
+	if err != nil {
+		return err
+	}
+	entry.Changes = []schema.Change{{Summary: selftestSummary, ChangeType: "other", Scope: "selftest"}}
+	entry.Context = context.CheckpointContext{ProblemStatement: "Verify the installed checkpoint binary"}
+	entry.NextSteps = nil
+	if err := schema.ValidateEntry(entry); err != nil {
+		return fmt.Errorf("filled input invalid: %w", err)
+	}
+	out, err := yaml.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshal input: %w", err)
+	}
+	return os.WriteFile(inputPath, out, 0644)
+}
+
+// verifySelftestChangelog checks the changelog recorded the change with a backfilled commit hash
+func verifySelftestChangelog(repo string) error {
+	entries, err := loadChangelogEntries(filepath.Join(repo, config.ChangelogFileName))
+	if err != nil {
+		return err
+	}
+	if len(entries) != 1 {
+		return fmt.Errorf("expected 1 changelog entry, found %d", len(entries))
+	}
+	e := entries[0]
+	if len(e.Changes) != 1 || e.Changes[0].Summary != selftestSummary {
+		return fmt.Errorf("changelog entry does not contain the selftest change")
+	}
+	if e.CommitHash == "" {
+		return fmt.Errorf("commit hash was not backfilled")
+	}
+	for _, leftover := range []string{config.InputFileName, config.LockFileName} {
+		if _, err := os.Stat(filepath.Join(repo, leftover)); err == nil {
+			return fmt.Errorf("%s was not removed after commit", leftover)
+		}
+	}
+	return nil
+}
+
+func indentLines(s, prefix string) string {
+	return prefix + strings.ReplaceAll(s, "\n", "\n"+prefix)
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunSelftest(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the checkpoint binary")
+	}
+	for _, tool := range []string{"go", "git"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s not found in PATH", tool)
+		}
+	}
+
+	bin := filepath.Join(t.TempDir(), "checkpoint")
+	build := exec.Command("go", "build", "-o", bin, "github.com/dmoose/checkpoint")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("go build: %v\n%s", err, out)
+	}
+
+	if err := runSelftest(bin, SelftestOptions{}); err != nil {
+		t.Fatalf("selftest failed: %v", err)
+	}
+}
@@ -1,33 +1,132 @@
 package cmd
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
-	"os/exec"
+	"os/signal"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/dmoose/checkpoint/internal/context"
+	"github.com/dmoose/checkpoint/internal/explain"
+	"github.com/dmoose/checkpoint/internal/file"
+	"github.com/dmoose/checkpoint/internal/git"
+	"github.com/dmoose/checkpoint/internal/schema"
+	"github.com/dmoose/checkpoint/pkg/config"
+
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
 
 var sessionOpts struct {
-	json bool
+	json           bool
+	appendContext  bool
+	next           bool
+	snapshot       bool
+	markdown       bool
+	linkCheckpoint bool
+	verify         bool
+	stashAware     bool
+	goals          []string
+	actions        []string
+	risks          []string
+	questions      []string
+	approach       string
+	watch          bool
 }
 
 func init() {
 	rootCmd.AddCommand(sessionCmd)
 	sessionCmd.Flags().BoolVar(&sessionOpts.json, "json", false, "Output as JSON (for show)")
+	sessionCmd.Flags().BoolVar(&sessionOpts.appendContext, "append-context", false, "Embed recent decisions and learnings in the handoff (for handoff)")
+	sessionCmd.Flags().BoolVar(&sessionOpts.verify, "verify", false, "Flag uncommitted changes and an in-progress checkpoint as unfinished (for handoff)")
+	sessionCmd.Flags().BoolVar(&sessionOpts.next, "next", false, "Print only the recommended next action (for show)")
+	sessionCmd.Flags().BoolVar(&sessionOpts.watch, "watch", false, "Re-render focus, pending actions and blockers whenever the session file changes (for show)")
+	sessionCmd.Flags().BoolVar(&sessionOpts.markdown, "markdown", false, "Output pure markdown (for show; default when piped)")
+	sessionCmd.Flags().BoolVar(&sessionOpts.snapshot, "snapshot", false, "Also keep a timestamped copy in .checkpoint/session-snapshots/ (for save)")
+	sessionCmd.Flags().BoolVar(&sessionOpts.linkCheckpoint, "link-checkpoint", false, "Record the hash of each following checkpoint commit in the session (for save)")
+	sessionCmd.Flags().StringArrayVar(&sessionOpts.goals, "goal", nil, "Add a goal (repeatable; for plan)")
+	sessionCmd.Flags().StringArrayVar(&sessionOpts.actions, "action", nil, "Add a next action as summary[:priority[:status]] (repeatable; for plan)")
+	sessionCmd.Flags().StringArrayVar(&sessionOpts.risks, "risk", nil, "Add a risk (repeatable; for plan)")
+	sessionCmd.Flags().StringArrayVar(&sessionOpts.questions, "question", nil, "Add an open question (repeatable; for plan)")
+	sessionCmd.Flags().StringVar(&sessionOpts.approach, "approach", "", "Set the planned approach (for plan)")
+	sessionCmd.Flags().BoolVar(&sessionOpts.stashAware, "git-stash-aware", false, "Record git stashes in the session so they are not forgotten (for save)")
 }
 
 var sessionCmd = &cobra.Command{
-	Use:   "session [action] [summary]",
+	Use:   "session [action] [summary] [reason]",
 	Short: "Manage session state for LLM handoff",
 	Long: `Capture and restore session state across LLM conversations.
-Actions: show, save <summary>, clear, handoff`,
-	Args: cobra.MaximumNArgs(2),
+Actions: show, save <summary>, clear, handoff, snapshots list,
+restore-snapshot <timestamp>, merge <other-session.yaml>, plan,
+start <n>, done <n>, block <n> <reason>, import,
+blocker resolve <n|text>
+
+'blocker resolve' removes a blocker chosen by its number in 'show' or by a
+case-insensitive substring of its issue; an ambiguous substring lists the
+candidates and changes nothing.
+
+'import' seeds the session's next actions from the most recent
+checkpoint's next_steps (summary and priority), skipping any whose summary
+is already listed.
+
+'start', 'done' and 'block' update the status of the Nth next action (as
+numbered by 'show'): in_progress, done, or blocked with the reason recorded
+as blocked_by. Handoff lists every action not yet done as unfinished.
+
+Use 'plan' to fill in the planning section without editing YAML:
+  checkpoint session plan --goal "Ship the parser" \
+    --action "Write lexer tests:high" --action "Refactor AST:med:in_progress" \
+    --risk "Grammar may change" --question "Support YAML 1.1?"
+--goal, --action, --risk and --question are repeatable and append to the
+session (created if absent); --approach replaces the approach. Actions are
+summary[:priority[:status]] with priority high, med or low and status
+pending, in_progress, done or blocked. Run 'plan' with no flags on a
+terminal to be prompted for each section.
+
+Use 'handoff --append-context' to embed the most recent decisions and
+learnings text so the next session does not need to look them up.
+Use 'handoff --verify' to check the work is committed first: uncommitted
+files and an in-progress checkpoint are reported and listed as unfinished.
+
+'show' renders plain text on a terminal and markdown when piped; use
+'show --markdown' to force clean markdown for pasting.
+
+Use 'show --next' to print just the recommended next action; exits
+non-zero when there is nothing left to do.
+
+Use 'show --watch' for a live dashboard: the current focus, next actions
+not yet done and blockers are redrawn whenever .checkpoint-session.yaml
+changes (rapid writes are coalesced). Press Ctrl-C to exit.
+
+Use 'save --snapshot' to keep a timestamped copy of the session in
+.checkpoint/session-snapshots/. 'snapshots list' shows them and
+'restore-snapshot <timestamp>' restores one (the live session is
+snapshotted first). Retention is capped by session.max_snapshots in
+.checkpoint/project.yaml (default 20).
+
+Use 'save --link-checkpoint' to have each following 'checkpoint commit'
+record its commit hash in the session (and keep the session instead of
+clearing it), so 'show' lists the commits the session produced.
+
+Use 'save --git-stash-aware' to record the current git stashes (ref and
+message) in the session. 'show' lists them and 'handoff' adds them to the
+unfinished items so stashed work is not forgotten. With no stashes the
+session's stash list is simply left empty.
+
+Use 'merge <other-session.yaml>' to fold a session kept in parallel (for
+example on another branch) into the current one. Goals, next actions,
+progress, decisions, learnings, risks and open questions are combined;
+next actions with the same summary keep the most advanced status
+(pending < blocked < in_progress < done). Differing current_focus or
+approach values are not overwritten - they are listed for you to resolve.`,
+	Args: cobra.MaximumNArgs(3),
 	Run: func(cmd *cobra.Command, args []string) {
 		projectPath := "."
 		absPath, err := filepath.Abs(projectPath)
@@ -37,7 +136,20 @@ Actions: show, save <summary>, clear, handoff`,
 		}
 
 		opts := SessionOptions{
-			JSON: sessionOpts.json,
+			JSON:           sessionOpts.json,
+			AppendContext:  sessionOpts.appendContext,
+			Next:           sessionOpts.next,
+			Snapshot:       sessionOpts.snapshot,
+			Markdown:       sessionOpts.markdown,
+			LinkCheckpoint: sessionOpts.linkCheckpoint,
+			Verify:         sessionOpts.verify,
+			StashAware:     sessionOpts.stashAware,
+			Watch:          sessionOpts.watch,
+			Goals:          sessionOpts.goals,
+			Actions:        sessionOpts.actions,
+			Risks:          sessionOpts.risks,
+			Questions:      sessionOpts.questions,
+			Approach:       sessionOpts.approach,
 		}
 		if len(args) > 0 {
 			opts.Action = args[0]
@@ -45,15 +157,34 @@ Actions: show, save <summary>, clear, handoff`,
 		if len(args) > 1 {
 			opts.Summary = args[1]
 		}
+		if len(args) > 2 {
+			opts.Reason = args[2]
+		}
 		Session(absPath, opts)
 	},
 }
 
 // SessionOptions holds flags for the session command
 type SessionOptions struct {
-	Action  string // save, show, clear, handoff
-	Summary string // session summary when saving
-	JSON    bool   // output as JSON
+	Action         string // save, show, clear, handoff
+	Summary        string // session summary when saving (or the argument to other actions)
+	Reason         string // blocked_by reason for 'block'
+	JSON           bool   // output as JSON
+	AppendContext  bool   // embed recent decisions/learnings in handoff
+	Next           bool   // print only the recommended next action
+	Snapshot       bool   // keep a timestamped copy when saving
+	Markdown       bool   // render show output as pure markdown
+	LinkCheckpoint bool   // link following checkpoint commits to the session
+	Verify         bool   // check for uncommitted work before handoff
+	StashAware     bool   // record git stashes when saving
+	Watch          bool   // redraw a live dashboard as the session changes
+
+	// Planning entries for 'plan'
+	Goals     []string
+	Actions   []string // summary[:priority[:status]]
+	Risks     []string
+	Questions []string
+	Approach  string
 }
 
 // SessionState represents the session planning document
@@ -63,11 +194,11 @@ type SessionState struct {
 	Updated       string `yaml:"updated" json:"updated"`
 
 	// Planning section
-	Goals         []string        `yaml:"goals,omitempty" json:"goals,omitempty"`
-	Approach      string          `yaml:"approach,omitempty" json:"approach,omitempty"`
-	NextActions   []NextAction    `yaml:"next_actions,omitempty" json:"next_actions,omitempty"`
-	Risks         []string        `yaml:"risks,omitempty" json:"risks,omitempty"`
-	OpenQuestions []string        `yaml:"open_questions,omitempty" json:"open_questions,omitempty"`
+	Goals         []string     `yaml:"goals,omitempty" json:"goals,omitempty"`
+	Approach      string       `yaml:"approach,omitempty" json:"approach,omitempty"`
+	NextActions   []NextAction `yaml:"next_actions,omitempty" json:"next_actions,omitempty"`
+	Risks         []string     `yaml:"risks,omitempty" json:"risks,omitempty"`
+	OpenQuestions []string     `yaml:"open_questions,omitempty" json:"open_questions,omitempty"`
 
 	// Active work section
 	CurrentFocus  string            `yaml:"current_focus,omitempty" json:"current_focus,omitempty"`
@@ -76,6 +207,11 @@ type SessionState struct {
 	Decisions     []SessionDecision `yaml:"decisions,omitempty" json:"decisions,omitempty"`
 	Learnings     []string          `yaml:"learnings,omitempty" json:"learnings,omitempty"`
 	ModifiedFiles []string          `yaml:"modified_files,omitempty" json:"modified_files,omitempty"`
+	Stashes       []SessionStash    `yaml:"stashes,omitempty" json:"stashes,omitempty"` // set by save --git-stash-aware
+
+	// Checkpoint linking (set by save --link-checkpoint, filled by commit)
+	LinkCheckpoints   bool     `yaml:"link_checkpoints,omitempty" json:"link_checkpoints,omitempty"`
+	LinkedCheckpoints []string `yaml:"linked_checkpoints,omitempty" json:"linked_checkpoints,omitempty"`
 
 	// Handoff section (appended by handoff command)
 	Handoff *SessionHandoff `yaml:"handoff,omitempty" json:"handoff,omitempty"`
@@ -101,6 +237,19 @@ type SessionDecision struct {
 	Rationale string `yaml:"rationale,omitempty" json:"rationale,omitempty"`
 }
 
+// SessionStash records a git stash present when the session was saved
+type SessionStash struct {
+	Ref     string `yaml:"ref" json:"ref"`
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+func (s SessionStash) String() string {
+	if s.Message == "" {
+		return s.Ref
+	}
+	return s.Ref + ": " + s.Message
+}
+
 // SessionHandoff contains context for handing off to another session
 type SessionHandoff struct {
 	Timestamp        string   `yaml:"timestamp" json:"timestamp"`
@@ -116,21 +265,57 @@ const sessionFileName = ".checkpoint-session.yaml"
 func Session(projectPath string, opts SessionOptions) {
 	switch opts.Action {
 	case "", "show":
-		showSession(projectPath, opts.JSON)
+		if opts.Watch {
+			if opts.JSON || opts.Next {
+				fmt.Fprintf(os.Stderr, "error: --watch cannot be combined with --json or --next\n")
+				os.Exit(1)
+			}
+			watchSession(projectPath, opts.Markdown)
+			return
+		}
+		if opts.Next {
+			showNextAction(projectPath)
+			return
+		}
+		showSession(projectPath, opts.JSON, opts.Markdown)
 	case "save":
 		saveSession(projectPath, opts)
 	case "clear":
 		clearSession(projectPath)
 	case "handoff":
 		handoffSession(projectPath, opts)
+	case "snapshots":
+		if opts.Summary != "" && opts.Summary != "list" {
+			fmt.Fprintf(os.Stderr, "unknown snapshots action: %s\n", opts.Summary)
+			fmt.Fprintf(os.Stderr, "usage: checkpoint session snapshots list\n")
+			os.Exit(1)
+		}
+		listSessionSnapshots(projectPath)
+	case "restore-snapshot":
+		restoreSessionSnapshot(projectPath, opts.Summary)
+	case "merge":
+		mergeSessionFile(projectPath, opts.Summary)
+	case "plan":
+		planSession(projectPath, opts)
+	case "import":
+		importSessionActions(projectPath)
+	case "blocker":
+		if opts.Summary != "resolve" {
+			fmt.Fprintf(os.Stderr, "unknown blocker action: %s\n", opts.Summary)
+			fmt.Fprintf(os.Stderr, "usage: checkpoint session blocker resolve <index-or-text>\n")
+			os.Exit(1)
+		}
+		resolveSessionBlocker(projectPath, opts.Reason)
+	case "start", "done", "block":
+		updateSessionAction(projectPath, opts.Action, opts.Summary, opts.Reason)
 	default:
 		fmt.Fprintf(os.Stderr, "unknown action: %s\n", opts.Action)
-		fmt.Fprintf(os.Stderr, "available: show, save, clear, handoff\n")
+		fmt.Fprintf(os.Stderr, "available: show, save, clear, handoff, snapshots, restore-snapshot, merge, plan, start, done, block, import, blocker\n")
 		os.Exit(1)
 	}
 }
 
-func showSession(projectPath string, jsonOutput bool) {
+func showSession(projectPath string, jsonOutput, markdown bool) {
 	sessionPath := filepath.Join(projectPath, sessionFileName)
 	data, err := os.ReadFile(sessionPath)
 	if err != nil {
@@ -160,168 +345,406 @@ func showSession(projectPath string, jsonOutput bool) {
 		return
 	}
 
-	renderSession(&session)
+	fmt.Print(renderSession(&session, markdown || !stdoutIsTerminal()))
 }
 
-func renderSession(session *SessionState) {
-	fmt.Println("# Session")
-	fmt.Println()
-	fmt.Printf("**Created:** %s\n", session.Created)
+// Session watch timing: how often the file is checked, and how long it must
+// stay unchanged before a redraw so a burst of writes renders once
+const (
+	sessionWatchInterval = 200 * time.Millisecond
+	sessionWatchQuiet    = 300 * time.Millisecond
+)
+
+// watchSession redraws the session dashboard whenever the session file
+// changes, until interrupted
+func watchSession(projectPath string, markdown bool) {
+	sessionPath := filepath.Join(projectPath, sessionFileName)
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	ticker := time.NewTicker(sessionWatchInterval)
+	defer ticker.Stop()
+
+	w := &sessionWatcher{quiet: sessionWatchQuiet}
+	w.last = statSessionFile(sessionPath)
+	drawSessionDashboard(sessionPath, markdown)
+	for {
+		select {
+		case <-interrupt:
+			fmt.Println()
+			return
+		case now := <-ticker.C:
+			if w.observe(statSessionFile(sessionPath), now) {
+				drawSessionDashboard(sessionPath, markdown)
+			}
+		}
+	}
+}
+
+// sessionFileStamp identifies one version of the session file on disk
+type sessionFileStamp struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+func statSessionFile(path string) sessionFileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return sessionFileStamp{}
+	}
+	return sessionFileStamp{exists: true, size: info.Size(), modTime: info.ModTime()}
+}
+
+// sessionWatcher debounces session file changes: a change is reported once
+// the file has stayed the same for the quiet period
+type sessionWatcher struct {
+	quiet   time.Duration
+	last    sessionFileStamp
+	pending bool
+	since   time.Time // when the file last changed
+}
+
+// observe records the file's current stamp and reports whether to redraw
+func (w *sessionWatcher) observe(stamp sessionFileStamp, now time.Time) bool {
+	if stamp != w.last {
+		w.last = stamp
+		w.pending = true
+		w.since = now
+		return false
+	}
+	if w.pending && now.Sub(w.since) >= w.quiet {
+		w.pending = false
+		return true
+	}
+	return false
+}
+
+func drawSessionDashboard(sessionPath string, markdown bool) {
+	var out string
+	data, err := os.ReadFile(sessionPath)
+	switch {
+	case os.IsNotExist(err):
+		out = "No active session state.\n"
+	case err != nil:
+		out = fmt.Sprintf("error reading session: %v\n", err)
+	default:
+		var session SessionState
+		if err := yaml.Unmarshal(data, &session); err != nil {
+			// Likely caught mid-write; the next change redraws
+			out = fmt.Sprintf("error parsing session: %v\n", err)
+		} else {
+			out = renderSessionDashboard(&session, markdown)
+		}
+	}
+	// Clear the screen and home the cursor before each render
+	fmt.Print("\033[H\033[2J")
+	fmt.Print(out)
+	fmt.Printf("Watching %s (Ctrl-C to exit)\n", sessionFileName)
+}
+
+// renderSessionDashboard formats the live view for 'show --watch': current
+// focus, next actions not yet done (numbered as in 'show'), and blockers
+func renderSessionDashboard(session *SessionState, markdown bool) string {
+	r := &sessionRenderer{markdown: markdown}
+
+	r.heading(1, "Session")
+	fmt.Fprintf(&r.sb, "%s %s\n\n", r.bold("Updated:"), session.Updated)
+
+	r.heading(2, "Current Focus")
+	if session.CurrentFocus != "" {
+		r.text(session.CurrentFocus)
+	} else {
+		r.text("(none)")
+	}
+
+	r.heading(2, "Next Actions")
+	pending := 0
+	for i, a := range session.NextActions {
+		if a.Status == "done" {
+			continue
+		}
+		fmt.Fprintf(&r.sb, "%d. %s\n", i+1, formatNextAction(a))
+		pending++
+	}
+	if pending == 0 {
+		r.sb.WriteString("(none pending)\n")
+	}
+	r.sb.WriteString("\n")
+
+	r.heading(2, "Blockers")
+	if len(session.Blockers) == 0 {
+		r.text("(none)")
+	} else {
+		r.numbered(formatBlockers(session.Blockers))
+	}
+
+	return r.sb.String()
+}
+
+func showNextAction(projectPath string) {
+	sessionPath := filepath.Join(projectPath, sessionFileName)
+	data, err := os.ReadFile(sessionPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "error reading session: %v\n", err)
+		os.Exit(1)
+	}
+
+	var session SessionState
+	if err := yaml.Unmarshal(data, &session); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing session: %v\n", err)
+		os.Exit(1)
+	}
+
+	next := nextSessionAction(&session)
+	if next == "" {
+		os.Exit(1)
+	}
+	fmt.Println(next)
+}
+
+// nextSessionAction returns the top open next action, ordered by priority
+// (high, med, low, unset) with in-progress work ahead of pending work at the
+// same priority. Falls back to the handoff's recommended start when no open
+// action remains. Returns "" when there is nothing to do.
+func nextSessionAction(session *SessionState) string {
+	priorityRank := func(p string) int {
+		switch strings.ToLower(p) {
+		case "high":
+			return 0
+		case "med":
+			return 1
+		case "low":
+			return 2
+		default:
+			return 3
+		}
+	}
+
+	var best *NextAction
+	bestRank := 0
+	for i := range session.NextActions {
+		a := &session.NextActions[i]
+		rank := 0
+		switch a.Status {
+		case "in_progress":
+			rank = priorityRank(a.Priority) * 2
+		case "", "pending":
+			rank = priorityRank(a.Priority)*2 + 1
+		default:
+			continue
+		}
+		if best == nil || rank < bestRank {
+			best, bestRank = a, rank
+		}
+	}
+	if best != nil {
+		return best.Summary
+	}
+
+	if session.Handoff != nil {
+		return session.Handoff.RecommendedStart
+	}
+	return ""
+}
+
+// sessionRenderer formats headings and emphasis for one output style
+type sessionRenderer struct {
+	sb       strings.Builder
+	markdown bool
+}
+
+func (r *sessionRenderer) heading(level int, title string) {
+	if r.markdown {
+		r.sb.WriteString(strings.Repeat("#", level) + " " + title + "\n\n")
+		return
+	}
+	switch level {
+	case 1:
+		r.sb.WriteString(title + "\n" + strings.Repeat("=", len(title)) + "\n\n")
+	case 2:
+		r.sb.WriteString(title + "\n" + strings.Repeat("-", len(title)) + "\n\n")
+	default:
+		r.sb.WriteString(title + ":\n")
+	}
+}
+
+func (r *sessionRenderer) bold(s string) string {
+	if r.markdown {
+		return "**" + s + "**"
+	}
+	return s
+}
+
+func (r *sessionRenderer) list(items []string) {
+	for _, item := range items {
+		r.sb.WriteString("- " + item + "\n")
+	}
+	r.sb.WriteString("\n")
+}
+
+func (r *sessionRenderer) numbered(items []string) {
+	for i, item := range items {
+		r.sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, item))
+	}
+	r.sb.WriteString("\n")
+}
+
+func (r *sessionRenderer) text(s string) {
+	r.sb.WriteString(s + "\n\n")
+}
+
+// renderSession formats the session as pure markdown (markdown=true) or as
+// plain terminal text with underlined headings and no emphasis markers
+func renderSession(session *SessionState, markdown bool) string {
+	r := &sessionRenderer{markdown: markdown}
+
+	r.heading(1, "Session")
+	fmt.Fprintf(&r.sb, "%s %s\n", r.bold("Created:"), session.Created)
 	if session.Updated != session.Created {
-		fmt.Printf("**Updated:** %s\n", session.Updated)
+		fmt.Fprintf(&r.sb, "%s %s\n", r.bold("Updated:"), session.Updated)
 	}
-	fmt.Println()
+	r.sb.WriteString("\n")
 
 	// Planning section
 	if len(session.Goals) > 0 {
-		fmt.Println("## Goals")
-		fmt.Println()
-		for _, g := range session.Goals {
-			fmt.Printf("- %s\n", g)
-		}
-		fmt.Println()
+		r.heading(2, "Goals")
+		r.list(session.Goals)
 	}
 
 	if session.Approach != "" {
-		fmt.Println("## Approach")
-		fmt.Println()
-		fmt.Println(session.Approach)
-		fmt.Println()
+		r.heading(2, "Approach")
+		r.text(session.Approach)
 	}
 
 	if len(session.NextActions) > 0 {
-		fmt.Println("## Next Actions")
-		fmt.Println()
+		r.heading(2, "Next Actions")
+		var items []string
 		for _, a := range session.NextActions {
-			status := a.Status
-			if status == "" {
-				status = "pending"
-			}
-			priority := ""
-			if a.Priority != "" {
-				priority = fmt.Sprintf("[%s] ", strings.ToUpper(a.Priority))
-			}
-			fmt.Printf("- %s%s (%s)", priority, a.Summary, status)
-			if a.BlockedBy != "" {
-				fmt.Printf(" - blocked by: %s", a.BlockedBy)
-			}
-			fmt.Println()
+			items = append(items, formatNextAction(a))
 		}
-		fmt.Println()
+		r.numbered(items)
 	}
 
 	if len(session.Risks) > 0 {
-		fmt.Println("## Risks")
-		fmt.Println()
-		for _, r := range session.Risks {
-			fmt.Printf("- %s\n", r)
-		}
-		fmt.Println()
+		r.heading(2, "Risks")
+		r.list(session.Risks)
 	}
 
 	if len(session.OpenQuestions) > 0 {
-		fmt.Println("## Open Questions")
-		fmt.Println()
-		for _, q := range session.OpenQuestions {
-			fmt.Printf("- %s\n", q)
-		}
-		fmt.Println()
+		r.heading(2, "Open Questions")
+		r.list(session.OpenQuestions)
 	}
 
 	// Active work section
 	if session.CurrentFocus != "" {
-		fmt.Println("## Current Focus")
-		fmt.Println()
-		fmt.Println(session.CurrentFocus)
-		fmt.Println()
+		r.heading(2, "Current Focus")
+		r.text(session.CurrentFocus)
 	}
 
 	if len(session.Progress) > 0 {
-		fmt.Println("## Progress")
-		fmt.Println()
-		for _, p := range session.Progress {
-			fmt.Printf("- %s\n", p)
-		}
-		fmt.Println()
+		r.heading(2, "Progress")
+		r.list(session.Progress)
 	}
 
 	if len(session.Blockers) > 0 {
-		fmt.Println("## Blockers")
-		fmt.Println()
-		for _, b := range session.Blockers {
-			fmt.Printf("- %s", b.Issue)
-			if b.WaitingOn != "" {
-				fmt.Printf(" (waiting on: %s)", b.WaitingOn)
-			}
-			fmt.Println()
-		}
-		fmt.Println()
+		r.heading(2, "Blockers")
+		r.numbered(formatBlockers(session.Blockers))
 	}
 
 	if len(session.Decisions) > 0 {
-		fmt.Println("## Decisions")
-		fmt.Println()
+		r.heading(2, "Decisions")
+		var items []string
 		for _, d := range session.Decisions {
-			fmt.Printf("- **%s**", d.Decision)
+			item := r.bold(d.Decision)
 			if d.Rationale != "" {
-				fmt.Printf(": %s", d.Rationale)
+				item += ": " + d.Rationale
 			}
-			fmt.Println()
+			items = append(items, item)
 		}
-		fmt.Println()
+		r.list(items)
 	}
 
 	if len(session.Learnings) > 0 {
-		fmt.Println("## Learnings")
-		fmt.Println()
-		for _, l := range session.Learnings {
-			fmt.Printf("- %s\n", l)
-		}
-		fmt.Println()
+		r.heading(2, "Learnings")
+		r.list(session.Learnings)
 	}
 
 	if len(session.ModifiedFiles) > 0 {
-		fmt.Println("## Modified Files")
-		fmt.Println()
-		for _, f := range session.ModifiedFiles {
-			fmt.Printf("- %s\n", f)
+		r.heading(2, "Modified Files")
+		r.list(session.ModifiedFiles)
+	}
+
+	if len(session.Stashes) > 0 {
+		r.heading(2, "Stashed Work")
+		var items []string
+		for _, st := range session.Stashes {
+			items = append(items, st.String())
 		}
-		fmt.Println()
+		r.list(items)
+	}
+
+	if len(session.LinkedCheckpoints) > 0 {
+		r.heading(2, "Linked Checkpoints")
+		r.text("This session produced commits " + strings.Join(session.LinkedCheckpoints, ", "))
 	}
 
 	// Handoff section
 	if session.Handoff != nil {
-		fmt.Println("## Handoff")
-		fmt.Println()
-		fmt.Printf("**Timestamp:** %s\n", session.Handoff.Timestamp)
-		fmt.Println()
+		r.heading(2, "Handoff")
+		fmt.Fprintf(&r.sb, "%s %s\n\n", r.bold("Timestamp:"), session.Handoff.Timestamp)
 		if session.Handoff.Summary != "" {
-			fmt.Println("### Summary")
-			fmt.Println()
-			fmt.Println(session.Handoff.Summary)
-			fmt.Println()
+			r.heading(3, "Summary")
+			r.text(session.Handoff.Summary)
 		}
 		if len(session.Handoff.Unfinished) > 0 {
-			fmt.Println("### Unfinished")
-			fmt.Println()
-			for _, u := range session.Handoff.Unfinished {
-				fmt.Printf("- %s\n", u)
-			}
-			fmt.Println()
+			r.heading(3, "Unfinished")
+			r.list(session.Handoff.Unfinished)
 		}
 		if session.Handoff.ContextForNext != "" {
-			fmt.Println("### Context for Next Session")
-			fmt.Println()
-			fmt.Println(session.Handoff.ContextForNext)
-			fmt.Println()
+			r.heading(3, "Context for Next Session")
+			r.text(session.Handoff.ContextForNext)
 		}
 		if session.Handoff.RecommendedStart != "" {
-			fmt.Println("### Recommended Start")
-			fmt.Println()
-			fmt.Println(session.Handoff.RecommendedStart)
-			fmt.Println()
+			r.heading(3, "Recommended Start")
+			r.text(session.Handoff.RecommendedStart)
+		}
+	}
+
+	return r.sb.String()
+}
+
+// formatNextAction renders one next action as "[PRIO] summary (status)"
+func formatNextAction(a NextAction) string {
+	status := a.Status
+	if status == "" {
+		status = "pending"
+	}
+	priority := ""
+	if a.Priority != "" {
+		priority = fmt.Sprintf("[%s] ", strings.ToUpper(a.Priority))
+	}
+	item := fmt.Sprintf("%s%s (%s)", priority, a.Summary, status)
+	if a.BlockedBy != "" {
+		item += " - blocked by: " + a.BlockedBy
+	}
+	return item
+}
+
+func formatBlockers(blockers []Blocker) []string {
+	var items []string
+	for _, b := range blockers {
+		item := b.Issue
+		if b.WaitingOn != "" {
+			item += fmt.Sprintf(" (waiting on: %s)", b.WaitingOn)
 		}
+		items = append(items, item)
 	}
+	return items
 }
 
 func saveSession(projectPath string, opts SessionOptions) {
@@ -345,6 +768,17 @@ func saveSession(projectPath string, opts SessionOptions) {
 	// Update timestamp and modified files
 	session.Updated = time.Now().Format(time.RFC3339)
 	session.ModifiedFiles = getModifiedFiles(projectPath)
+	if opts.LinkCheckpoint {
+		session.LinkCheckpoints = true
+	}
+	if opts.StashAware {
+		stashes, err := git.StashList(projectPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: failed to list git stashes: %v\n", err)
+		} else {
+			session.Stashes = sessionStashes(stashes)
+		}
+	}
 
 	// Write session state
 	data, err := yaml.Marshal(&session)
@@ -362,90 +796,858 @@ func saveSession(projectPath string, opts SessionOptions) {
 	if len(session.ModifiedFiles) > 0 {
 		fmt.Printf("  %d modified files tracked\n", len(session.ModifiedFiles))
 	}
-}
+	if opts.LinkCheckpoint {
+		fmt.Println("  Next checkpoint commits will be linked to this session")
+	}
+	if opts.StashAware && len(session.Stashes) > 0 {
+		fmt.Printf("  %d git stash(es) recorded\n", len(session.Stashes))
+	}
 
-func clearSession(projectPath string) {
-	sessionPath := filepath.Join(projectPath, sessionFileName)
-	if err := os.Remove(sessionPath); err != nil {
-		if os.IsNotExist(err) {
-			fmt.Println("No session to clear.")
+	if opts.Snapshot {
+		name, err := writeSessionSnapshot(projectPath, data)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: failed to write session snapshot: %v\n", err)
 			return
 		}
-		fmt.Fprintf(os.Stderr, "error clearing session: %v\n", err)
-		os.Exit(1)
+		fmt.Printf("  Snapshot saved: %s\n", strings.TrimSuffix(name, ".yaml"))
 	}
-	fmt.Println("Session cleared.")
 }
 
-func handoffSession(projectPath string, opts SessionOptions) {
-	sessionPath := filepath.Join(projectPath, sessionFileName)
+// sessionStashes converts git stashes for the session file; nil when there are none
+func sessionStashes(stashes []git.Stash) []SessionStash {
+	var out []SessionStash
+	for _, st := range stashes {
+		out = append(out, SessionStash{Ref: st.Ref, Message: st.Message})
+	}
+	return out
+}
 
-	// Load existing session
+// linkSessionCheckpoint appends commitHash to the session's linked checkpoints
+// when linking was enabled with 'save --link-checkpoint'; reports whether it did
+func linkSessionCheckpoint(sessionPath, commitHash string) (bool, error) {
 	data, err := os.ReadFile(sessionPath)
 	if err != nil {
-		if os.IsNotExist(err) {
-			fmt.Fprintf(os.Stderr, "No active session to hand off.\n")
-			fmt.Fprintf(os.Stderr, "hint: Use 'checkpoint plan' to create a session first\n")
-			os.Exit(1)
-		}
-		fmt.Fprintf(os.Stderr, "error reading session: %v\n", err)
-		os.Exit(1)
+		return false, err
 	}
-
 	var session SessionState
 	if err := yaml.Unmarshal(data, &session); err != nil {
-		fmt.Fprintf(os.Stderr, "error parsing session: %v\n", err)
-		os.Exit(1)
-	}
-
-	// Build handoff section
-	handoff := SessionHandoff{
-		Timestamp: time.Now().Format(time.RFC3339),
+		return false, fmt.Errorf("parse session: %w", err)
 	}
-
-	// Auto-generate summary from session state
-	var summaryParts []string
-	if session.CurrentFocus != "" {
-		summaryParts = append(summaryParts, fmt.Sprintf("Was working on: %s", session.CurrentFocus))
+	if !session.LinkCheckpoints || commitHash == "" {
+		return false, nil
 	}
-	if len(session.Progress) > 0 {
-		summaryParts = append(summaryParts, fmt.Sprintf("Completed %d items.", len(session.Progress)))
+	for _, h := range session.LinkedCheckpoints {
+		if h == commitHash {
+			return true, nil
+		}
 	}
-	if len(session.Blockers) > 0 {
-		summaryParts = append(summaryParts, fmt.Sprintf("Blocked on %d issue(s).", len(session.Blockers)))
+	session.LinkedCheckpoints = append(session.LinkedCheckpoints, commitHash)
+	session.Updated = time.Now().Format(time.RFC3339)
+	out, err := yaml.Marshal(&session)
+	if err != nil {
+		return false, fmt.Errorf("marshal session: %w", err)
 	}
-	if len(summaryParts) > 0 {
-		handoff.Summary = strings.Join(summaryParts, " ")
+	if err := os.WriteFile(sessionPath, out, 0644); err != nil {
+		return false, err
 	}
+	return true, nil
+}
 
-	// Collect unfinished actions
-	for _, a := range session.NextActions {
-		if a.Status != "done" {
-			handoff.Unfinished = append(handoff.Unfinished, a.Summary)
-		}
-	}
+// defaultMaxSessionSnapshots caps retained snapshots when session.max_snapshots is unset
+const defaultMaxSessionSnapshots = 20
 
-	// Build context for next session
-	var contextParts []string
-	if len(session.Decisions) > 0 {
-		contextParts = append(contextParts, "Key decisions were made - review the Decisions section.")
+// writeSessionSnapshot stores data as a timestamped snapshot, pruning the oldest
+// beyond the configured cap, and returns the snapshot file name
+func writeSessionSnapshot(projectPath string, data []byte) (string, error) {
+	snapshotDir := filepath.Join(projectPath, config.CheckpointDir, config.SessionSnapshotsDir)
+	if err := os.MkdirAll(snapshotDir, 0755); err != nil {
+		return "", fmt.Errorf("create snapshot dir: %w", err)
 	}
-	if len(session.Learnings) > 0 {
-		contextParts = append(contextParts, "Learnings captured - review before continuing.")
+	// Sessions are local working state; keep snapshots out of 'git add -A'
+	ignorePath := filepath.Join(snapshotDir, ".gitignore")
+	if !file.Exists(ignorePath) {
+		_ = file.WriteFile(ignorePath, "*\n")
 	}
-	if len(contextParts) > 0 {
-		handoff.ContextForNext = strings.Join(contextParts, " ")
+
+	name := time.Now().UTC().Format("20060102T150405Z") + ".yaml"
+	if err := os.WriteFile(filepath.Join(snapshotDir, name), data, 0644); err != nil {
+		return "", fmt.Errorf("write snapshot: %w", err)
 	}
 
-	// Recommended start
+	limit := loadProjectSettings(projectPath).Session.MaxSnapshots
+	if limit <= 0 {
+		limit = defaultMaxSessionSnapshots
+	}
+	snapshots := listSnapshotNames(snapshotDir)
+	for len(snapshots) > limit {
+		_ = os.Remove(filepath.Join(snapshotDir, snapshots[0]))
+		snapshots = snapshots[1:]
+	}
+	return name, nil
+}
+
+// listSnapshotNames returns snapshot file names, oldest first
+func listSnapshotNames(snapshotDir string) []string {
+	entries, err := os.ReadDir(snapshotDir)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".yaml") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func listSessionSnapshots(projectPath string) {
+	snapshotDir := filepath.Join(projectPath, config.CheckpointDir, config.SessionSnapshotsDir)
+	names := listSnapshotNames(snapshotDir)
+	if len(names) == 0 {
+		fmt.Println("No session snapshots.")
+		fmt.Println("\nhint: Use 'checkpoint session save --snapshot' to create one")
+		return
+	}
+
+	fmt.Printf("Session snapshots (%d):\n\n", len(names))
+	for i := len(names) - 1; i >= 0; i-- {
+		line := strings.TrimSuffix(names[i], ".yaml")
+		var snap SessionState
+		if data, err := os.ReadFile(filepath.Join(snapshotDir, names[i])); err == nil && yaml.Unmarshal(data, &snap) == nil {
+			if snap.CurrentFocus != "" {
+				line += "  " + snap.CurrentFocus
+			}
+		}
+		fmt.Printf("  %s\n", line)
+	}
+	fmt.Println("\nRestore with: checkpoint session restore-snapshot <timestamp>")
+}
+
+// findSessionSnapshot resolves a timestamp (or unique prefix) to a snapshot file name
+func findSessionSnapshot(snapshotDir, timestamp string) (string, error) {
+	timestamp = strings.TrimSuffix(timestamp, ".yaml")
+	var matches []string
+	for _, name := range listSnapshotNames(snapshotDir) {
+		stem := strings.TrimSuffix(name, ".yaml")
+		if stem == timestamp {
+			return name, nil
+		}
+		if strings.HasPrefix(stem, timestamp) {
+			matches = append(matches, name)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no session snapshot matches '%s'", timestamp)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("'%s' matches %d snapshots; use a longer timestamp", timestamp, len(matches))
+	}
+}
+
+func restoreSessionSnapshot(projectPath, timestamp string) {
+	if timestamp == "" {
+		fmt.Fprintf(os.Stderr, "error: snapshot timestamp required\n")
+		fmt.Fprintf(os.Stderr, "usage: checkpoint session restore-snapshot <timestamp>\n")
+		fmt.Fprintf(os.Stderr, "hint: Use 'checkpoint session snapshots list' to see available snapshots\n")
+		os.Exit(1)
+	}
+
+	snapshotDir := filepath.Join(projectPath, config.CheckpointDir, config.SessionSnapshotsDir)
+	name, err := findSessionSnapshot(snapshotDir, timestamp)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "hint: Use 'checkpoint session snapshots list' to see available snapshots\n")
+		os.Exit(1)
+	}
+	data, err := os.ReadFile(filepath.Join(snapshotDir, name))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading snapshot: %v\n", err)
+		os.Exit(1)
+	}
+	var session SessionState
+	if err := yaml.Unmarshal(data, &session); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing snapshot: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Keep the live session recoverable before overwriting it
+	sessionPath := filepath.Join(projectPath, sessionFileName)
+	if current, err := os.ReadFile(sessionPath); err == nil && string(current) != string(data) {
+		if saved, err := writeSessionSnapshot(projectPath, current); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: failed to snapshot current session: %v\n", err)
+		} else if saved != name {
+			fmt.Printf("Current session saved as snapshot %s\n", strings.TrimSuffix(saved, ".yaml"))
+		}
+	}
+
+	if err := os.WriteFile(sessionPath, data, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing session: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Session restored from snapshot %s\n", strings.TrimSuffix(name, ".yaml"))
+}
+
+func importSessionActions(projectPath string) {
+	history, err := explain.LoadHistory(projectPath, 1)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error loading history: %v\n", err)
+		os.Exit(1)
+	}
+	if len(history.RecentCheckpoints) == 0 {
+		fmt.Println("No checkpoints yet; nothing to import.")
+		return
+	}
+	latest := history.RecentCheckpoints[0]
+
+	sessionPath := filepath.Join(projectPath, sessionFileName)
+	var session SessionState
+	if existing, err := os.ReadFile(sessionPath); err == nil {
+		if err := yaml.Unmarshal(existing, &session); err != nil {
+			fmt.Fprintf(os.Stderr, "error parsing existing session: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		session = SessionState{
+			SchemaVersion: "1",
+			Created:       time.Now().Format(time.RFC3339),
+		}
+	}
+
+	added := importNextSteps(&session, latest.NextSteps)
+	if added == 0 {
+		fmt.Printf("No new next steps to import from checkpoint %s.\n", latest.Timestamp)
+		return
+	}
+	session.Updated = time.Now().Format(time.RFC3339)
+
+	data, err := yaml.Marshal(&session)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error marshaling session: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(sessionPath, data, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing session: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Imported %d next action(s) from checkpoint %s.\n", added, latest.Timestamp)
+}
+
+// importNextSteps appends changelog next steps as pending next actions,
+// skipping summaries the session already has; returns how many were added
+func importNextSteps(session *SessionState, steps []explain.NextStepEntry) int {
+	existing := map[string]bool{}
+	for _, a := range session.NextActions {
+		existing[a.Summary] = true
+	}
+	added := 0
+	for _, step := range steps {
+		summary := strings.TrimSpace(step.Summary)
+		if summary == "" || existing[summary] {
+			continue
+		}
+		existing[summary] = true
+		priority, err := normalizePriority(step.Priority)
+		if err != nil {
+			priority = ""
+		}
+		session.NextActions = append(session.NextActions, NextAction{Summary: summary, Priority: priority})
+		added++
+	}
+	return added
+}
+
+func resolveSessionBlocker(projectPath, selector string) {
+	if strings.TrimSpace(selector) == "" {
+		fmt.Fprintf(os.Stderr, "error: blocker index or text required\n")
+		fmt.Fprintf(os.Stderr, "usage: checkpoint session blocker resolve <index-or-text>\n")
+		os.Exit(1)
+	}
+
+	sessionPath := filepath.Join(projectPath, sessionFileName)
+	data, err := os.ReadFile(sessionPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "error: no session found\n")
+		} else {
+			fmt.Fprintf(os.Stderr, "error reading session: %v\n", err)
+		}
+		os.Exit(1)
+	}
+	var session SessionState
+	if err := yaml.Unmarshal(data, &session); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing session: %v\n", err)
+		os.Exit(1)
+	}
+
+	idx, candidates, err := findBlocker(session.Blockers, selector)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		for _, i := range candidates {
+			fmt.Fprintf(os.Stderr, "  %d. %s\n", i+1, session.Blockers[i].Issue)
+		}
+		fmt.Fprintf(os.Stderr, "hint: Use 'checkpoint session show' to see numbered blockers\n")
+		os.Exit(1)
+	}
+	resolved := session.Blockers[idx]
+	session.Blockers = append(session.Blockers[:idx], session.Blockers[idx+1:]...)
+	session.Updated = time.Now().Format(time.RFC3339)
+
+	out, err := yaml.Marshal(&session)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error marshaling session: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(sessionPath, out, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing session: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Resolved blocker: %s\n", resolved.Issue)
+	if len(session.Blockers) == 0 {
+		fmt.Println("No blockers remaining.")
+		return
+	}
+	fmt.Println("Remaining blockers:")
+	for i, b := range session.Blockers {
+		fmt.Printf("  %d. %s\n", i+1, b.Issue)
+	}
+}
+
+// findBlocker picks a blocker by 1-based index or by case-insensitive
+// substring of its issue (an exact match wins). When the substring matches
+// several blockers it returns their indexes as candidates with an error.
+func findBlocker(blockers []Blocker, selector string) (int, []int, error) {
+	if len(blockers) == 0 {
+		return -1, nil, fmt.Errorf("session has no blockers")
+	}
+	selector = strings.TrimSpace(selector)
+	if n, err := strconv.Atoi(selector); err == nil {
+		if n < 1 || n > len(blockers) {
+			return -1, nil, fmt.Errorf("invalid index '%s' (expected 1-%d)", selector, len(blockers))
+		}
+		return n - 1, nil, nil
+	}
+
+	needle := strings.ToLower(selector)
+	var matches []int
+	for i, b := range blockers {
+		issue := strings.ToLower(b.Issue)
+		if issue == needle {
+			return i, nil, nil
+		}
+		if strings.Contains(issue, needle) {
+			matches = append(matches, i)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return -1, nil, fmt.Errorf("no blocker matches '%s'", selector)
+	case 1:
+		return matches[0], nil, nil
+	default:
+		return -1, matches, fmt.Errorf("'%s' matches %d blockers; be more specific or use the index", selector, len(matches))
+	}
+}
+
+// sessionActionStatus maps the start/done/block actions to a next action status
+var sessionActionStatus = map[string]string{
+	"start": "in_progress",
+	"done":  "done",
+	"block": "blocked",
+}
+
+func updateSessionAction(projectPath, action, indexArg, reason string) {
+	usage := fmt.Sprintf("usage: checkpoint session %s <index>", action)
+	if action == "block" {
+		usage += " \"<reason>\""
+	}
+	if indexArg == "" {
+		fmt.Fprintf(os.Stderr, "error: next action index required\n")
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(1)
+	}
+	if action == "block" && strings.TrimSpace(reason) == "" {
+		fmt.Fprintf(os.Stderr, "error: a reason is required to block an action\n")
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(1)
+	}
+	if action != "block" && reason != "" {
+		fmt.Fprintf(os.Stderr, "error: unexpected argument %q\n", reason)
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(1)
+	}
+
+	sessionPath := filepath.Join(projectPath, sessionFileName)
+	data, err := os.ReadFile(sessionPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "error: no session found\n")
+			fmt.Fprintf(os.Stderr, "hint: Use 'checkpoint session plan --action \"...\"' to add next actions\n")
+		} else {
+			fmt.Fprintf(os.Stderr, "error reading session: %v\n", err)
+		}
+		os.Exit(1)
+	}
+	var session SessionState
+	if err := yaml.Unmarshal(data, &session); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing session: %v\n", err)
+		os.Exit(1)
+	}
+
+	updated, err := setNextActionStatus(&session, indexArg, sessionActionStatus[action], strings.TrimSpace(reason))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "hint: Use 'checkpoint session show' to see numbered next actions\n")
+		os.Exit(1)
+	}
+	session.Updated = time.Now().Format(time.RFC3339)
+
+	out, err := yaml.Marshal(&session)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error marshaling session: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(sessionPath, out, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing session: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Next action %s: %s -> %s\n", indexArg, updated.Summary, updated.Status)
+	if updated.BlockedBy != "" {
+		fmt.Printf("  blocked by: %s\n", updated.BlockedBy)
+	}
+}
+
+// setNextActionStatus sets the status of the 1-based indexArg-th next action.
+// blockedBy is recorded for blocked actions and cleared otherwise.
+func setNextActionStatus(session *SessionState, indexArg, status, blockedBy string) (NextAction, error) {
+	count := len(session.NextActions)
+	if count == 0 {
+		return NextAction{}, fmt.Errorf("session has no next actions")
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(indexArg))
+	if err != nil || n < 1 || n > count {
+		return NextAction{}, fmt.Errorf("invalid index '%s' (expected 1-%d)", indexArg, count)
+	}
+	a := &session.NextActions[n-1]
+	a.Status = status
+	a.BlockedBy = ""
+	if status == "blocked" {
+		a.BlockedBy = blockedBy
+	}
+	return *a, nil
+}
+
+func planSession(projectPath string, opts SessionOptions) {
+	if len(opts.Goals)+len(opts.Actions)+len(opts.Risks)+len(opts.Questions) == 0 && opts.Approach == "" {
+		if !stdinIsTerminal() {
+			fmt.Fprintf(os.Stderr, "error: nothing to plan\n")
+			fmt.Fprintf(os.Stderr, "hint: Use --goal, --action, --risk, --question or --approach, or run on a terminal to be prompted\n")
+			os.Exit(1)
+		}
+		if err := promptSessionPlan(bufio.NewReader(os.Stdin), os.Stdout, &opts); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		if len(opts.Goals)+len(opts.Actions)+len(opts.Risks)+len(opts.Questions) == 0 && opts.Approach == "" {
+			fmt.Println("Nothing entered; session unchanged.")
+			return
+		}
+	}
+
+	actions := make([]NextAction, 0, len(opts.Actions))
+	for _, spec := range opts.Actions {
+		action, err := parseNextAction(spec)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			fmt.Fprintf(os.Stderr, "hint: Use --action \"summary[:priority[:status]]\", e.g. \"Write tests:high:pending\"\n")
+			os.Exit(1)
+		}
+		actions = append(actions, action)
+	}
+
+	sessionPath := filepath.Join(projectPath, sessionFileName)
+	var session SessionState
+	if existing, err := os.ReadFile(sessionPath); err == nil {
+		if err := yaml.Unmarshal(existing, &session); err != nil {
+			fmt.Fprintf(os.Stderr, "error parsing existing session: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		session = SessionState{
+			SchemaVersion: "1",
+			Created:       time.Now().Format(time.RFC3339),
+		}
+	}
+
+	session.Goals = append(session.Goals, opts.Goals...)
+	session.NextActions = append(session.NextActions, actions...)
+	session.Risks = append(session.Risks, opts.Risks...)
+	session.OpenQuestions = append(session.OpenQuestions, opts.Questions...)
+	if opts.Approach != "" {
+		session.Approach = opts.Approach
+	}
+	session.Updated = time.Now().Format(time.RFC3339)
+
+	data, err := yaml.Marshal(&session)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error marshaling session: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(sessionPath, data, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing session: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Session plan updated.")
+	for _, added := range []struct {
+		n    int
+		noun string
+	}{
+		{len(opts.Goals), "goal(s)"},
+		{len(actions), "next action(s)"},
+		{len(opts.Risks), "risk(s)"},
+		{len(opts.Questions), "open question(s)"},
+	} {
+		if added.n > 0 {
+			fmt.Printf("  %d %s added\n", added.n, added.noun)
+		}
+	}
+	if opts.Approach != "" {
+		fmt.Println("  Approach set")
+	}
+}
+
+// parseNextAction parses summary[:priority[:status]]. Trailing fields are only
+// taken as priority/status when they are valid values, so summaries may
+// themselves contain colons (e.g. "fix: flaky test:high").
+func parseNextAction(spec string) (NextAction, error) {
+	parts := strings.Split(spec, ":")
+	action := NextAction{}
+	if n := len(parts); n >= 3 && isNextActionStatus(parts[n-1]) && isNextActionPriority(parts[n-2]) {
+		action.Priority = strings.TrimSpace(parts[n-2])
+		action.Status = strings.TrimSpace(parts[n-1])
+		parts = parts[:n-2]
+	} else if n >= 2 && isNextActionPriority(parts[n-1]) {
+		action.Priority = strings.TrimSpace(parts[n-1])
+		parts = parts[:n-1]
+	}
+	action.Summary = strings.TrimSpace(strings.Join(parts, ":"))
+	if action.Summary == "" {
+		return NextAction{}, fmt.Errorf("action %q has no summary", spec)
+	}
+	return action, nil
+}
+
+// isNextActionPriority accepts high, med, low, or empty (for summary::status)
+func isNextActionPriority(s string) bool {
+	switch strings.TrimSpace(s) {
+	case "", "high", "med", "low":
+		return true
+	}
+	return false
+}
+
+func isNextActionStatus(s string) bool {
+	switch strings.TrimSpace(s) {
+	case "pending", "in_progress", "done", "blocked":
+		return true
+	}
+	return false
+}
+
+// promptSessionPlan asks for each planning section; a blank line ends a list
+func promptSessionPlan(r *bufio.Reader, out io.Writer, opts *SessionOptions) error {
+	sections := []struct {
+		label string
+		dest  *[]string
+	}{
+		{"Goal", &opts.Goals},
+		{"Next action (summary[:priority[:status]])", &opts.Actions},
+		{"Risk", &opts.Risks},
+		{"Open question", &opts.Questions},
+	}
+	for _, s := range sections {
+		for {
+			line, err := promptLine(r, out, s.label+" (blank to finish): ")
+			if err != nil {
+				return err
+			}
+			if line == "" {
+				break
+			}
+			*s.dest = append(*s.dest, line)
+		}
+	}
+	approach, err := promptLine(r, out, "Approach (optional): ")
+	if err != nil {
+		return err
+	}
+	opts.Approach = approach
+	return nil
+}
+
+func mergeSessionFile(projectPath, otherPath string) {
+	if otherPath == "" {
+		fmt.Fprintf(os.Stderr, "error: session file to merge required\n")
+		fmt.Fprintf(os.Stderr, "usage: checkpoint session merge <other-session.yaml>\n")
+		os.Exit(1)
+	}
+	otherData, err := os.ReadFile(otherPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading %s: %v\n", otherPath, err)
+		os.Exit(1)
+	}
+	var other SessionState
+	if err := yaml.Unmarshal(otherData, &other); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing %s: %v\n", otherPath, err)
+		os.Exit(1)
+	}
+
+	sessionPath := filepath.Join(projectPath, sessionFileName)
+	var session SessionState
+	if existing, err := os.ReadFile(sessionPath); err == nil {
+		if err := yaml.Unmarshal(existing, &session); err != nil {
+			fmt.Fprintf(os.Stderr, "error parsing existing session: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		session = SessionState{SchemaVersion: "1"}
+	}
+
+	merged, conflicts := mergeSessions(session, other)
+	merged.Updated = time.Now().Format(time.RFC3339)
+	if merged.Created == "" {
+		merged.Created = merged.Updated
+	}
+
+	data, err := yaml.Marshal(&merged)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error marshaling session: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(sessionPath, data, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing session: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Merged %s into the session.\n", otherPath)
+	fmt.Printf("  %d goals, %d next actions, %d decisions, %d learnings\n",
+		len(merged.Goals), len(merged.NextActions), len(merged.Decisions), len(merged.Learnings))
+	if len(conflicts) > 0 {
+		fmt.Println()
+		fmt.Println("Conflicts to resolve (current value kept):")
+		for _, c := range conflicts {
+			fmt.Printf("  %s\n", c)
+		}
+		fmt.Printf("hint: Edit %s to pick the right value\n", sessionFileName)
+	}
+}
+
+// nextActionStatusRank orders statuses from least to most advanced
+var nextActionStatusRank = map[string]int{
+	"":            0,
+	"pending":     0,
+	"blocked":     1,
+	"in_progress": 2,
+	"done":        3,
+}
+
+// mergeSessions unions other into session. Next actions with the same summary
+// keep the most advanced status. Differing current_focus and approach values
+// keep session's value and are returned as conflicts for the user to resolve.
+func mergeSessions(session, other SessionState) (SessionState, []string) {
+	merged := session
+	if merged.SchemaVersion == "" {
+		merged.SchemaVersion = other.SchemaVersion
+	}
+	if other.Created != "" && (merged.Created == "" || other.Created < merged.Created) {
+		merged.Created = other.Created
+	}
+
+	merged.Goals = mergeStrings(session.Goals, other.Goals)
+	merged.Progress = mergeStrings(session.Progress, other.Progress)
+	merged.Learnings = mergeStrings(session.Learnings, other.Learnings)
+	merged.Risks = mergeStrings(session.Risks, other.Risks)
+	merged.OpenQuestions = mergeStrings(session.OpenQuestions, other.OpenQuestions)
+
+	merged.Decisions = append([]SessionDecision(nil), session.Decisions...)
+	for _, d := range other.Decisions {
+		found := false
+		for _, existing := range merged.Decisions {
+			if existing.Decision == d.Decision {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged.Decisions = append(merged.Decisions, d)
+		}
+	}
+
+	merged.NextActions = append([]NextAction(nil), session.NextActions...)
+	for _, a := range other.NextActions {
+		idx := -1
+		for i, existing := range merged.NextActions {
+			if existing.Summary == a.Summary {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			merged.NextActions = append(merged.NextActions, a)
+			continue
+		}
+		existing := &merged.NextActions[idx]
+		if nextActionStatusRank[a.Status] > nextActionStatusRank[existing.Status] {
+			existing.Status = a.Status
+			existing.BlockedBy = a.BlockedBy
+		}
+		if existing.Priority == "" {
+			existing.Priority = a.Priority
+		}
+	}
+
+	var conflicts []string
+	mergeField := func(name string, current *string, incoming string) {
+		switch {
+		case incoming == "" || incoming == *current:
+		case *current == "":
+			*current = incoming
+		default:
+			conflicts = append(conflicts, fmt.Sprintf("%s: %q vs %q", name, *current, incoming))
+		}
+	}
+	mergeField("current_focus", &merged.CurrentFocus, other.CurrentFocus)
+	mergeField("approach", &merged.Approach, other.Approach)
+
+	return merged, conflicts
+}
+
+// mergeStrings appends the entries of b missing from a, keeping order
+func mergeStrings(a, b []string) []string {
+	out := append([]string(nil), a...)
+	seen := make(map[string]bool, len(a))
+	for _, s := range a {
+		seen[s] = true
+	}
+	for _, s := range b {
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func clearSession(projectPath string) {
+	sessionPath := filepath.Join(projectPath, sessionFileName)
+	if err := os.Remove(sessionPath); err != nil {
+		if os.IsNotExist(err) {
+			fmt.Println("No session to clear.")
+			return
+		}
+		fmt.Fprintf(os.Stderr, "error clearing session: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("Session cleared.")
+}
+
+func handoffSession(projectPath string, opts SessionOptions) {
+	sessionPath := filepath.Join(projectPath, sessionFileName)
+
+	// Load existing session
+	data, err := os.ReadFile(sessionPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "No active session to hand off.\n")
+			fmt.Fprintf(os.Stderr, "hint: Use 'checkpoint plan' to create a session first\n")
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "error reading session: %v\n", err)
+		os.Exit(1)
+	}
+
+	var session SessionState
+	if err := yaml.Unmarshal(data, &session); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing session: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Build handoff section
+	handoff := SessionHandoff{
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+
+	// Auto-generate summary from session state
+	var summaryParts []string
+	if session.CurrentFocus != "" {
+		summaryParts = append(summaryParts, fmt.Sprintf("Was working on: %s", session.CurrentFocus))
+	}
+	if len(session.Progress) > 0 {
+		summaryParts = append(summaryParts, fmt.Sprintf("Completed %d items.", len(session.Progress)))
+	}
+	if len(session.Blockers) > 0 {
+		summaryParts = append(summaryParts, fmt.Sprintf("Blocked on %d issue(s).", len(session.Blockers)))
+	}
+	if len(summaryParts) > 0 {
+		handoff.Summary = strings.Join(summaryParts, " ")
+	}
+
+	// Collect unfinished actions
+	for _, a := range session.NextActions {
+		if a.Status != "done" {
+			handoff.Unfinished = append(handoff.Unfinished, a.Summary)
+		}
+	}
+
+	// Build context for next session
+	var contextParts []string
+	if len(session.Decisions) > 0 {
+		contextParts = append(contextParts, "Key decisions were made - review the Decisions section.")
+	}
+	if len(session.Learnings) > 0 {
+		contextParts = append(contextParts, "Learnings captured - review before continuing.")
+	}
+	if len(contextParts) > 0 {
+		handoff.ContextForNext = strings.Join(contextParts, " ")
+	}
+	if opts.AppendContext {
+		if embedded := buildHandoffContext(projectPath, session); embedded != "" {
+			if handoff.ContextForNext != "" {
+				handoff.ContextForNext += "\n\n"
+			}
+			handoff.ContextForNext += embedded
+		}
+	}
+
+	// Recommended start
 	if len(handoff.Unfinished) > 0 {
 		handoff.RecommendedStart = fmt.Sprintf("Continue with: %s", handoff.Unfinished[0])
 	}
 
+	// Stashed work is easy to forget between sessions
+	for _, st := range session.Stashes {
+		handoff.Unfinished = append(handoff.Unfinished, "Stashed work: "+st.String())
+	}
+
+	modified := getModifiedFiles(projectPath)
+	var issues []string
+	if opts.Verify {
+		issues = handoffVerifyIssues(projectPath, modified)
+		handoff.Unfinished = append(handoff.Unfinished, issues...)
+		if handoff.RecommendedStart == "" && len(issues) > 0 {
+			handoff.RecommendedStart = "Checkpoint the uncommitted work: checkpoint check"
+		}
+	}
+
 	// Update session with handoff
 	session.Handoff = &handoff
 	session.Updated = time.Now().Format(time.RFC3339)
-	session.ModifiedFiles = getModifiedFiles(projectPath)
+	session.ModifiedFiles = modified
 
 	// Write updated session
 	newData, err := yaml.Marshal(&session)
@@ -459,33 +1661,133 @@ func handoffSession(projectPath string, opts SessionOptions) {
 		os.Exit(1)
 	}
 
+	if opts.Verify {
+		if len(issues) > 0 {
+			fmt.Fprintf(os.Stderr, "warning: handing off with uncommitted work (added to unfinished):\n")
+			for _, issue := range issues {
+				fmt.Fprintf(os.Stderr, "  - %s\n", issue)
+			}
+			fmt.Fprintf(os.Stderr, "hint: run 'checkpoint check' and 'checkpoint commit' before handing off\n")
+		} else {
+			fmt.Println("✓ Working tree clean; no checkpoint in progress")
+		}
+	}
+
 	fmt.Println("Session prepared for handoff.")
 	fmt.Println()
 	fmt.Println("The session file now contains handoff context for the next LLM.")
 	fmt.Println("Next session can read it with: checkpoint session show")
 }
 
+// handoffVerifyIssues describes work a handoff would leave behind: a
+// checkpoint in progress and modified files other than the session itself
+func handoffVerifyIssues(projectPath string, modified []string) []string {
+	var issues []string
+	if file.Exists(filepath.Join(projectPath, config.LockFileName)) {
+		issues = append(issues, "Checkpoint in progress: finish with 'checkpoint commit' or discard with 'checkpoint clean'")
+	}
+	for _, path := range modified {
+		if path == sessionFileName {
+			continue
+		}
+		issues = append(issues, "Uncommitted: "+path)
+	}
+	return issues
+}
+
+// maxHandoffContextChars caps the decisions/learnings text embedded by --append-context
+const maxHandoffContextChars = 2000
+
+// buildHandoffContext collects recent decisions and learnings, newest first, from
+// the session, recent checkpoint context, and project learnings, capped in size.
+// A decision recorded in several places is listed once, at its newest position.
+func buildHandoffContext(projectPath string, session SessionState) string {
+	var decisions, learnings []string
+
+	seenDecisions := make(map[string]bool)
+	addDecision := func(decision, rationale string) {
+		key := strings.TrimSpace(decision)
+		if key == "" || seenDecisions[key] {
+			return
+		}
+		seenDecisions[key] = true
+		if rationale != "" {
+			decisions = append(decisions, fmt.Sprintf("%s (%s)", decision, rationale))
+		} else {
+			decisions = append(decisions, decision)
+		}
+	}
+
+	for i := len(session.Decisions) - 1; i >= 0; i-- {
+		addDecision(session.Decisions[i].Decision, session.Decisions[i].Rationale)
+	}
+	for i := len(session.Learnings) - 1; i >= 0; i-- {
+		learnings = append(learnings, session.Learnings[i])
+	}
+
+	contextPath := filepath.Join(projectPath, config.ContextFileName)
+	if entries, err := context.GetRecentContextEntries(contextPath, 3); err == nil {
+		for i := len(entries) - 1; i >= 0; i-- {
+			for _, d := range entries[i].Context.DecisionsMade {
+				addDecision(d.Decision, d.Rationale)
+			}
+		}
+	}
+
+	if ctx, err := explain.LoadExplainContext(projectPath); err == nil {
+		for i := len(ctx.Learnings) - 1; i >= 0; i-- {
+			learnings = append(learnings, ctx.Learnings[i].Learning)
+		}
+	}
+
+	var sb strings.Builder
+	omitted := 0
+	writeSection := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		header := title + ":\n"
+		for i, item := range items {
+			line := "- " + strings.TrimSpace(item) + "\n"
+			if sb.Len()+len(header)+len(line) > maxHandoffContextChars {
+				omitted += len(items) - i
+				return
+			}
+			sb.WriteString(header)
+			header = ""
+			sb.WriteString(line)
+		}
+	}
+	writeSection("Recent decisions", decisions)
+	writeSection("Recent learnings", learnings)
+	if omitted > 0 {
+		sb.WriteString(fmt.Sprintf("(%d older item(s) omitted)\n", omitted))
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
 func getModifiedFiles(projectPath string) []string {
-	cmd := exec.Command("git", "status", "--porcelain")
-	cmd.Dir = projectPath
-	output, err := cmd.Output()
+	status, err := git.GetStatus(projectPath)
 	if err != nil {
 		return nil
 	}
+	return modifiedFilesFromStatus(status)
+}
 
+// modifiedFilesFromStatus lists the paths in porcelain status text: the
+// destination of renames and copies, unquoted, without ignored entries or
+// checkpoint temporary files
+func modifiedFilesFromStatus(status string) []string {
 	var files []string
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if len(line) > 3 {
-			// Status is first 2 chars, then space, then filename
-			file := strings.TrimSpace(line[2:])
-			// Skip checkpoint temporary files
-			if strings.HasPrefix(file, ".checkpoint-") && !strings.HasSuffix(file, ".yaml") {
-				continue
-			}
-			files = append(files, file)
+	for _, fs := range schema.ParseGitStatus(status) {
+		if fs.State == "ignored" {
+			continue
+		}
+		// Skip checkpoint temporary files
+		if strings.HasPrefix(fs.Path, ".checkpoint-") && !strings.HasSuffix(fs.Path, ".yaml") {
+			continue
 		}
+		files = append(files, fs.Path)
 	}
 	return files
 }
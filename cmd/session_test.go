@@ -0,0 +1,507 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dmoose/checkpoint/internal/explain"
+	"github.com/dmoose/checkpoint/pkg/config"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestNextSessionAction(t *testing.T) {
+	tests := []struct {
+		name    string
+		session SessionState
+		want    string
+	}{
+		{
+			name: "empty session",
+			want: "",
+		},
+		{
+			name: "priority ordering",
+			session: SessionState{NextActions: []NextAction{
+				{Summary: "low task", Priority: "low"},
+				{Summary: "high task", Priority: "high"},
+				{Summary: "med task", Priority: "med"},
+			}},
+			want: "high task",
+		},
+		{
+			name: "in progress ahead of pending at same priority",
+			session: SessionState{NextActions: []NextAction{
+				{Summary: "pending", Priority: "med", Status: "pending"},
+				{Summary: "started", Priority: "med", Status: "in_progress"},
+			}},
+			want: "started",
+		},
+		{
+			name: "skips done and blocked",
+			session: SessionState{NextActions: []NextAction{
+				{Summary: "finished", Priority: "high", Status: "done"},
+				{Summary: "stuck", Priority: "high", Status: "blocked"},
+				{Summary: "remaining", Priority: "low"},
+			}},
+			want: "remaining",
+		},
+		{
+			name: "falls back to handoff",
+			session: SessionState{
+				NextActions: []NextAction{{Summary: "finished", Status: "done"}},
+				Handoff:     &SessionHandoff{RecommendedStart: "Continue with: review"},
+			},
+			want: "Continue with: review",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextSessionAction(&tt.session); got != tt.want {
+				t.Errorf("nextSessionAction() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindSessionSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"20250101T090000Z.yaml", "20250101T100000Z.yaml", "20250102T090000Z.yaml", ".gitignore"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("schema_version: \"1\"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := listSnapshotNames(dir); len(got) != 3 || got[0] != "20250101T090000Z.yaml" {
+		t.Errorf("listSnapshotNames = %v", got)
+	}
+
+	tests := []struct {
+		timestamp string
+		want      string
+		wantErr   bool
+	}{
+		{"20250101T100000Z", "20250101T100000Z.yaml", false},
+		{"20250102", "20250102T090000Z.yaml", false},
+		{"20250101", "", true}, // ambiguous
+		{"2024", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.timestamp, func(t *testing.T) {
+			got, err := findSessionSnapshot(dir, tt.timestamp)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("findSessionSnapshot(%q) error = %v, wantErr %v", tt.timestamp, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("findSessionSnapshot(%q) = %q, want %q", tt.timestamp, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderSession(t *testing.T) {
+	session := &SessionState{
+		Created:   "2025-01-01T00:00:00Z",
+		Updated:   "2025-01-02T00:00:00Z",
+		Goals:     []string{"Ship search --across"},
+		Decisions: []SessionDecision{{Decision: "Reuse matchesQuery", Rationale: "Consistent matching"}},
+		Stashes:   []SessionStash{{Ref: "stash@{0}", Message: "On main: wip parser"}},
+		Handoff:   &SessionHandoff{Timestamp: "2025-01-02T00:00:00Z", Summary: "Search done"},
+	}
+
+	md := renderSession(session, true)
+	for _, want := range []string{"## Stashed Work\n\n- stash@{0}: On main: wip parser\n", "# Session\n", "**Updated:** 2025-01-02T00:00:00Z", "## Goals\n\n- Ship search --across\n", "- **Reuse matchesQuery**: Consistent matching", "### Summary\n\nSearch done\n"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown output missing %q:\n%s", want, md)
+		}
+	}
+
+	plain := renderSession(session, false)
+	if strings.Contains(plain, "#") || strings.Contains(plain, "**") {
+		t.Errorf("terminal output should have no markdown markers:\n%s", plain)
+	}
+	for _, want := range []string{"Session\n=======\n", "Goals\n-----\n", "- Reuse matchesQuery: Consistent matching", "Summary:\nSearch done\n"} {
+		if !strings.Contains(plain, want) {
+			t.Errorf("terminal output missing %q:\n%s", want, plain)
+		}
+	}
+}
+
+func TestLinkSessionCheckpoint(t *testing.T) {
+	sessionPath := filepath.Join(t.TempDir(), sessionFileName)
+	if err := os.WriteFile(sessionPath, []byte("schema_version: \"1\"\ngoals:\n  - Ship it\n"), 0644); err != nil {
+		t.Fatalf("write session: %v", err)
+	}
+
+	// Linking is opt-in
+	linked, err := linkSessionCheckpoint(sessionPath, "abc123")
+	if err != nil || linked {
+		t.Fatalf("expected no link without link_checkpoints, got %v, %v", linked, err)
+	}
+
+	if err := os.WriteFile(sessionPath, []byte("schema_version: \"1\"\nlink_checkpoints: true\n"), 0644); err != nil {
+		t.Fatalf("write session: %v", err)
+	}
+	for _, hash := range []string{"abc123", "def456", "abc123"} {
+		if linked, err := linkSessionCheckpoint(sessionPath, hash); err != nil || !linked {
+			t.Fatalf("expected %s linked, got %v, %v", hash, linked, err)
+		}
+	}
+
+	data, _ := os.ReadFile(sessionPath)
+	var session SessionState
+	if err := yaml.Unmarshal(data, &session); err != nil {
+		t.Fatalf("parse session: %v", err)
+	}
+	if strings.Join(session.LinkedCheckpoints, ",") != "abc123,def456" {
+		t.Errorf("unexpected linked checkpoints: %v", session.LinkedCheckpoints)
+	}
+	if out := renderSession(&session, true); !strings.Contains(out, "This session produced commits abc123, def456") {
+		t.Errorf("show output missing linked commits:\n%s", out)
+	}
+}
+
+func TestHandoffVerifyIssues(t *testing.T) {
+	tmpDir := t.TempDir()
+	if issues := handoffVerifyIssues(tmpDir, []string{sessionFileName}); len(issues) != 0 {
+		t.Errorf("session file alone should not block handoff, got %v", issues)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, config.LockFileName), []byte("pid: 1\n"), 0644); err != nil {
+		t.Fatalf("write lock: %v", err)
+	}
+	issues := handoffVerifyIssues(tmpDir, []string{"main.go", sessionFileName})
+	if len(issues) != 2 || !strings.Contains(issues[0], "Checkpoint in progress") || issues[1] != "Uncommitted: main.go" {
+		t.Errorf("unexpected issues: %v", issues)
+	}
+}
+
+func TestBuildHandoffContext(t *testing.T) {
+	long := strings.Repeat("x", 96)
+	var manyDecisions []SessionDecision
+	var capped []string
+	for i := 0; i < 30; i++ {
+		manyDecisions = append(manyDecisions, SessionDecision{Decision: fmt.Sprintf("%02d %s", i, long)})
+	}
+	// Each line is 103 bytes; 19 fit under the cap after the header
+	capped = append(capped, "Recent decisions:")
+	for i := 29; i > 10; i-- {
+		capped = append(capped, fmt.Sprintf("- %02d %s", i, long))
+	}
+	capped = append(capped, "(11 older item(s) omitted)")
+
+	tests := []struct {
+		name      string
+		session   SessionState
+		context   string // .checkpoint-context.yaml content
+		learnings string // .checkpoint/learnings.yaml content
+		want      []string
+	}{
+		{
+			name: "empty",
+			want: []string{""},
+		},
+		{
+			name: "newest first across sources",
+			session: SessionState{
+				Decisions: []SessionDecision{{Decision: "Use yaml.v3"}, {Decision: "No cgo", Rationale: "static builds"}},
+				Learnings: []string{"Tests need network"},
+			},
+			context: "---\nschema_version: \"1\"\ntimestamp: \"2025-01-01T00:00:00Z\"\ncontext:\n  decisions_made:\n    - decision: \"Old decision\"\n" +
+				"---\nschema_version: \"1\"\ntimestamp: \"2025-01-02T00:00:00Z\"\ncontext:\n  decisions_made:\n    - decision: \"Newer decision\"\n      rationale: \"simpler\"\n",
+			learnings: "---\nlearning: \"Old learning\"\n---\nlearning: \"New learning\"\n",
+			want: []string{
+				"Recent decisions:",
+				"- No cgo (static builds)",
+				"- Use yaml.v3",
+				"- Newer decision (simpler)",
+				"- Old decision",
+				"Recent learnings:",
+				"- Tests need network",
+				"- New learning",
+				"- Old learning",
+			},
+		},
+		{
+			name:    "decision in session and checkpoint context listed once",
+			session: SessionState{Decisions: []SessionDecision{{Decision: "Use yaml.v3", Rationale: "comments survive"}}},
+			context: "---\nschema_version: \"1\"\ntimestamp: \"2025-01-02T00:00:00Z\"\ncontext:\n  decisions_made:\n    - decision: \"Use yaml.v3\"\n      rationale: \"already a dependency\"\n",
+			want: []string{
+				"Recent decisions:",
+				"- Use yaml.v3 (comments survive)",
+			},
+		},
+		{
+			name:    "capped with omitted count",
+			session: SessionState{Decisions: manyDecisions},
+			want:    capped,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.context != "" {
+				if err := os.WriteFile(filepath.Join(dir, config.ContextFileName), []byte(tt.context), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.learnings != "" {
+				if err := os.MkdirAll(filepath.Join(dir, config.CheckpointDir), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, config.CheckpointDir, "learnings.yaml"), []byte(tt.learnings), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got := buildHandoffContext(dir, tt.session)
+			if body, _, _ := strings.Cut(got, "\n("); len(body) > maxHandoffContextChars {
+				t.Errorf("items take %d bytes, over the %d cap", len(body), maxHandoffContextChars)
+			}
+			if want := strings.Join(tt.want, "\n"); got != want {
+				t.Errorf("buildHandoffContext() =\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestMergeSessions(t *testing.T) {
+	current := SessionState{
+		Created:      "2025-02-01T00:00:00Z",
+		Goals:        []string{"ship parser"},
+		CurrentFocus: "lexer",
+		NextActions: []NextAction{
+			{Summary: "write tests", Status: "pending"},
+			{Summary: "fix bug", Status: "done"},
+		},
+		Decisions: []SessionDecision{{Decision: "use yaml.v3"}},
+	}
+	other := SessionState{
+		Created:      "2025-01-15T00:00:00Z",
+		Goals:        []string{"ship parser", "update docs"},
+		CurrentFocus: "docs",
+		Approach:     "incremental",
+		NextActions: []NextAction{
+			{Summary: "write tests", Status: "in_progress", Priority: "high"},
+			{Summary: "fix bug", Status: "pending"},
+			{Summary: "write guide"},
+		},
+		Decisions: []SessionDecision{{Decision: "use yaml.v3"}, {Decision: "no cgo"}},
+		Learnings: []string{"tests need network"},
+	}
+
+	merged, conflicts := mergeSessions(current, other)
+	if merged.Created != other.Created {
+		t.Errorf("Created = %q, want earliest %q", merged.Created, other.Created)
+	}
+	if len(merged.Goals) != 2 || len(merged.Decisions) != 2 || len(merged.Learnings) != 1 {
+		t.Errorf("unexpected union: %+v", merged)
+	}
+	if len(merged.NextActions) != 3 {
+		t.Fatalf("expected 3 next actions, got %+v", merged.NextActions)
+	}
+	if a := merged.NextActions[0]; a.Status != "in_progress" || a.Priority != "high" {
+		t.Errorf("write tests = %+v, want in_progress/high", a)
+	}
+	if a := merged.NextActions[1]; a.Status != "done" {
+		t.Errorf("fix bug status = %q, want done", a.Status)
+	}
+	if merged.CurrentFocus != "lexer" || merged.Approach != "incremental" {
+		t.Errorf("focus/approach = %q/%q", merged.CurrentFocus, merged.Approach)
+	}
+	if len(conflicts) != 1 || !strings.Contains(conflicts[0], "current_focus") {
+		t.Errorf("unexpected conflicts: %v", conflicts)
+	}
+	if len(current.NextActions) != 2 || current.NextActions[0].Status != "pending" {
+		t.Error("merge should not modify the input session")
+	}
+}
+
+func TestParseNextAction(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    NextAction
+		wantErr bool
+	}{
+		{spec: "Write tests", want: NextAction{Summary: "Write tests"}},
+		{spec: "Write tests:high", want: NextAction{Summary: "Write tests", Priority: "high"}},
+		{spec: "Refactor AST:med:in_progress", want: NextAction{Summary: "Refactor AST", Priority: "med", Status: "in_progress"}},
+		{spec: "Triage::blocked", want: NextAction{Summary: "Triage", Status: "blocked"}},
+		{spec: "fix: flaky test:low", want: NextAction{Summary: "fix: flaky test", Priority: "low"}},
+		{spec: "note: not a priority", want: NextAction{Summary: "note: not a priority"}},
+		{spec: ":high", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseNextAction(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseNextAction(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseNextAction(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+}
+
+func TestSetNextActionStatus(t *testing.T) {
+	session := &SessionState{NextActions: []NextAction{
+		{Summary: "write tests"},
+		{Summary: "ship", Status: "blocked", BlockedBy: "review"},
+	}}
+
+	a, err := setNextActionStatus(session, "1", "in_progress", "")
+	if err != nil || a.Status != "in_progress" || session.NextActions[0].Status != "in_progress" {
+		t.Fatalf("start: got %+v, %v", a, err)
+	}
+	if _, err := setNextActionStatus(session, "1", "blocked", "CI outage"); err != nil || session.NextActions[0].BlockedBy != "CI outage" {
+		t.Errorf("block: got %+v, %v", session.NextActions[0], err)
+	}
+	if _, err := setNextActionStatus(session, "2", "done", ""); err != nil || session.NextActions[1].BlockedBy != "" {
+		t.Errorf("done should clear blocked_by: %+v, %v", session.NextActions[1], err)
+	}
+	for _, idx := range []string{"0", "3", "x"} {
+		if _, err := setNextActionStatus(session, idx, "done", ""); err == nil {
+			t.Errorf("expected error for index %q", idx)
+		}
+	}
+}
+
+func TestImportNextSteps(t *testing.T) {
+	session := &SessionState{NextActions: []NextAction{{Summary: "Write docs", Status: "in_progress"}}}
+	steps := []explain.NextStepEntry{
+		{Summary: "Write docs", Priority: "low"},
+		{Summary: "Add caching", Priority: "HIGH"},
+		{Summary: "Profile", Priority: "urgent"},
+		{Summary: "Add caching"},
+	}
+
+	if added := importNextSteps(session, steps); added != 2 {
+		t.Fatalf("added = %d, want 2", added)
+	}
+	want := []NextAction{
+		{Summary: "Write docs", Status: "in_progress"},
+		{Summary: "Add caching", Priority: "high"},
+		{Summary: "Profile"},
+	}
+	if len(session.NextActions) != len(want) {
+		t.Fatalf("next actions = %+v", session.NextActions)
+	}
+	for i := range want {
+		if session.NextActions[i] != want[i] {
+			t.Errorf("action %d = %+v, want %+v", i, session.NextActions[i], want[i])
+		}
+	}
+}
+
+func TestModifiedFilesFromStatus(t *testing.T) {
+	status := strings.Join([]string{
+		" M cmd/session.go",
+		"R  old/name.go -> new/name.go",
+		" D removed.go",
+		`?? "docs/my notes.md"`,
+		`M  "caf\303\251.txt"`,
+		"!! build/out.bin",
+		"?? .checkpoint-diff",
+		"",
+	}, "\n")
+
+	got := modifiedFilesFromStatus(status)
+	want := []string{"cmd/session.go", "new/name.go", "removed.go", "docs/my notes.md", "café.txt"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("modifiedFilesFromStatus() = %q, want %q", got, want)
+	}
+}
+
+func TestFindBlocker(t *testing.T) {
+	blockers := []Blocker{
+		{Issue: "Waiting on API keys"},
+		{Issue: "API rate limits"},
+		{Issue: "CI is red"},
+	}
+	tests := []struct {
+		selector   string
+		want       int
+		candidates int
+		wantErr    bool
+	}{
+		{selector: "3", want: 2},
+		{selector: "ci", want: 2},
+		{selector: "api rate limits", want: 1},
+		{selector: "api", want: -1, candidates: 2, wantErr: true},
+		{selector: "4", want: -1, wantErr: true},
+		{selector: "deploy", want: -1, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, candidates, err := findBlocker(blockers, tt.selector)
+		if (err != nil) != tt.wantErr || got != tt.want || len(candidates) != tt.candidates {
+			t.Errorf("findBlocker(%q) = %d, %v, %v; want %d with %d candidates (err %v)",
+				tt.selector, got, candidates, err, tt.want, tt.candidates, tt.wantErr)
+		}
+	}
+}
+
+func TestRenderSessionDashboard(t *testing.T) {
+	session := &SessionState{
+		Updated:      "2025-01-02T00:00:00Z",
+		CurrentFocus: "Parser rewrite",
+		Goals:        []string{"Not shown"},
+		NextActions: []NextAction{
+			{Summary: "Write lexer", Status: "done"},
+			{Summary: "Write parser", Priority: "high", Status: "in_progress"},
+		},
+		Blockers: []Blocker{{Issue: "CI down", WaitingOn: "infra"}},
+	}
+	out := renderSessionDashboard(session, false)
+	for _, want := range []string{"Parser rewrite\n", "2. [HIGH] Write parser (in_progress)\n", "1. CI down (waiting on: infra)\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dashboard missing %q:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"Write lexer", "Not shown"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("dashboard should not show %q:\n%s", unwanted, out)
+		}
+	}
+
+	empty := renderSessionDashboard(&SessionState{}, false)
+	if !strings.Contains(empty, "(none pending)") {
+		t.Errorf("expected empty placeholders:\n%s", empty)
+	}
+}
+
+func TestSessionWatcherDebounce(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	w := &sessionWatcher{quiet: 300 * time.Millisecond}
+	stamp := func(size int64) sessionFileStamp {
+		return sessionFileStamp{exists: true, size: size, modTime: start}
+	}
+
+	if w.observe(sessionFileStamp{}, start) {
+		t.Error("no change should not redraw")
+	}
+	// A burst of writes redraws once, after the file settles
+	at := start
+	for size := int64(1); size <= 3; size++ {
+		at = at.Add(100 * time.Millisecond)
+		if w.observe(stamp(size), at) {
+			t.Fatalf("redraw during burst at write %d", size)
+		}
+	}
+	if w.observe(stamp(3), at.Add(200*time.Millisecond)) {
+		t.Error("redraw before the quiet period elapsed")
+	}
+	if !w.observe(stamp(3), at.Add(300*time.Millisecond)) {
+		t.Error("expected a redraw once the file settled")
+	}
+	if w.observe(stamp(3), at.Add(time.Second)) {
+		t.Error("expected a single redraw per change")
+	}
+	// Deleting the file is a change too
+	if w.observe(sessionFileStamp{}, at.Add(2*time.Second)) || !w.observe(sessionFileStamp{}, at.Add(3*time.Second)) {
+		t.Error("expected a redraw after the session file was removed")
+	}
+}
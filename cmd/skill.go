@@ -29,8 +29,9 @@ var skillCmd = &cobra.Command{
 	Short: "Manage skills for LLM context",
 	Long: `Manage skills that provide LLM context.
 Actions: list, show <name>, add <name>, create <name>`,
-	Aliases: []string{"skills"},
-	Args:    cobra.MaximumNArgs(2),
+	Aliases:           []string{"skills"},
+	Args:              cobra.MaximumNArgs(2),
+	ValidArgsFunction: completeSkillArgs,
 	Run: func(cmd *cobra.Command, args []string) {
 		projectPath := "."
 		absPath, err := filepath.Abs(projectPath)
@@ -235,6 +236,7 @@ func showSkill(projectPath string, name string) {
 	// Try to find in loaded skills
 	for _, s := range ctx.SkillDefs {
 		if s.Name == name {
+			recordSkillView(projectPath, name)
 			fmt.Print(s.Content)
 			return
 		}
@@ -255,6 +257,7 @@ func showSkill(projectPath string, name string) {
 	// Try local skills directory
 	localPath := filepath.Join(projectPath, config.CheckpointDir, config.SkillsDir, name, "skill.md")
 	if content, err := os.ReadFile(localPath); err == nil {
+		recordSkillView(projectPath, name)
 		fmt.Print(string(content))
 		return
 	}
@@ -328,6 +331,9 @@ func addSkill(projectPath string, name string) {
 	fmt.Printf("✓ Added global skill '%s' to project\n", name)
 }
 
+// skillPlaceholder is the intro line createSkill writes; its presence means the skill was never filled in
+const skillPlaceholder = "(Describe what this skill is and when to use it)"
+
 func createSkill(projectPath string, name string) {
 	if name == "" {
 		fmt.Fprintf(os.Stderr, "error: skill name required\n")
@@ -351,7 +357,7 @@ func createSkill(projectPath string, name string) {
 
 	template := fmt.Sprintf(`# %s
 
-(Describe what this skill is and when to use it)
+%s
 
 ## Purpose
 
@@ -376,7 +382,7 @@ func createSkill(projectPath string, name string) {
 ## Related
 
 - (Related skills or resources)
-`, name)
+`, name, skillPlaceholder)
 
 	if err := os.WriteFile(skillPath, []byte(template), 0644); err != nil {
 		fmt.Fprintf(os.Stderr, "error writing skill.md: %v\n", err)
@@ -80,8 +80,8 @@ func startInternal(projectPath string) bool {
 
 	// Check 3: No checkpoint in progress
 	lockPath := filepath.Join(projectPath, config.LockFileName)
-	inputPath := filepath.Join(projectPath, config.InputFileName)
-	if file.Exists(lockPath) || file.Exists(inputPath) {
+	_, inputInProgress := findInputFile(projectPath)
+	if file.Exists(lockPath) || inputInProgress {
 		fmt.Println("⚠ Checkpoint in progress")
 		fmt.Println("  You have an unfinished checkpoint")
 		fmt.Println("  Options:")
@@ -0,0 +1,427 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dmoose/checkpoint/internal/file"
+	"github.com/dmoose/checkpoint/internal/git"
+	"github.com/dmoose/checkpoint/internal/schema"
+	"github.com/dmoose/checkpoint/pkg/config"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var statsOpts struct {
+	contributors bool
+	json         bool
+	trend        bool
+	ascii        bool
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	statsCmd.Flags().BoolVar(&statsOpts.contributors, "contributors", false, "Break down checkpoints by author")
+	statsCmd.Flags().BoolVar(&statsOpts.json, "json", false, "Output as JSON")
+	statsCmd.Flags().BoolVar(&statsOpts.trend, "trend", false, "Show weekly checkpoint activity as a sparkline")
+	statsCmd.Flags().BoolVar(&statsOpts.ascii, "ascii", false, "Use ASCII characters for the --trend sparkline")
+}
+
+var statsCmd = &cobra.Command{
+	Use:   "stats [path]",
+	Short: "Show changelog statistics",
+	Long: `Aggregates the changelog: checkpoint and change counts, change_type
+distribution, the most frequent scopes, average changes per checkpoint, and
+checkpoints per week (over the span from the first to the last checkpoint).
+
+With --contributors, checkpoints are attributed to the author of their
+commit_hash. Entries without a resolvable commit are counted as "unknown".
+
+With --trend, checkpoints are bucketed by week (weeks start Monday, UTC) from
+the first to the last checkpoint, including empty weeks, and shown as a
+sparkline with the busiest and quietest weeks. --ascii avoids unicode blocks.
+
+Examples:
+  checkpoint stats
+  checkpoint stats --contributors
+  checkpoint stats --contributors --json
+  checkpoint stats --trend`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		projectPath := "."
+		if len(args) > 0 {
+			projectPath = args[0]
+		}
+		absPath, err := filepath.Abs(projectPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: cannot resolve path: %v\n", err)
+			os.Exit(1)
+		}
+		Stats(absPath, StatsOptions{
+			Contributors: statsOpts.contributors,
+			JSON:         statsOpts.json,
+			Trend:        statsOpts.trend,
+			ASCII:        statsOpts.ascii,
+		})
+	},
+}
+
+// StatsOptions holds flags for the stats command
+type StatsOptions struct {
+	Contributors bool // --contributors flag
+	JSON         bool // --json flag
+	Trend        bool // --trend flag
+	ASCII        bool // --ascii flag (for --trend)
+}
+
+// ChangelogStats is the aggregate view of the changelog
+type ChangelogStats struct {
+	Checkpoints        int                `json:"checkpoints"`
+	Changes            int                `json:"changes"`
+	ChangeTypes        map[string]int     `json:"change_types"`
+	Scopes             []ScopeCount       `json:"scopes"`   // most frequent first, top maxStatsScopes
+	Unscoped           int                `json:"unscoped"` // changes without a scope
+	AvgChanges         float64            `json:"avg_changes_per_checkpoint"`
+	CheckpointsPerWeek float64            `json:"checkpoints_per_week"`
+	Contributors       []ContributorStats `json:"contributors,omitempty"`
+	Trend              *TrendStats        `json:"trend,omitempty"`
+}
+
+// ScopeCount counts changes in one scope
+type ScopeCount struct {
+	Scope   string `json:"scope"`
+	Changes int    `json:"changes"`
+}
+
+// maxStatsScopes caps the scope list in stats output
+const maxStatsScopes = 10
+
+// TrendStats is the weekly activity view for --trend
+type TrendStats struct {
+	Weeks    []WeekBucket `json:"weeks"`
+	Busiest  *WeekBucket  `json:"busiest,omitempty"`
+	Quietest *WeekBucket  `json:"quietest,omitempty"`
+}
+
+// WeekBucket counts checkpoints in the week starting on Week (a Monday, UTC)
+type WeekBucket struct {
+	Week        string `json:"week"`
+	Checkpoints int    `json:"checkpoints"`
+}
+
+// ContributorStats is the per-author breakdown for --contributors
+type ContributorStats struct {
+	Author      string         `json:"author"`
+	Checkpoints int            `json:"checkpoints"`
+	ChangeTypes map[string]int `json:"change_types"`
+}
+
+const unknownAuthor = "unknown"
+
+// Stats prints aggregate statistics over the changelog
+func Stats(projectPath string, opts StatsOptions) {
+	changelogPath := filepath.Join(projectPath, config.ChangelogFileName)
+	if !file.Exists(changelogPath) {
+		fmt.Fprintf(os.Stderr, "error: changelog not found at %s\n", changelogPath)
+		fmt.Fprintf(os.Stderr, "hint: run 'checkpoint init' to initialize\n")
+		os.Exit(1)
+	}
+
+	entries, err := loadChangelogEntries(changelogPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: failed to read changelog: %v\n", err)
+		os.Exit(1)
+	}
+
+	stats := computeStats(entries)
+	if opts.Contributors {
+		stats.Contributors = computeContributorStats(entries, func(hash string) string {
+			author, err := git.GetCommitAuthor(projectPath, hash)
+			if err != nil {
+				return ""
+			}
+			return author
+		})
+	}
+
+	if opts.Trend {
+		trend := computeTrend(entries)
+		stats.Trend = &trend
+	}
+
+	if opts.JSON {
+		writeJSON(stats)
+		return
+	}
+	printStats(stats)
+	if stats.Trend != nil {
+		printTrend(*stats.Trend, opts.ASCII)
+	}
+}
+
+// computeTrend buckets checkpoints by UTC week from the first to the last
+// checkpoint; weeks without activity get a zero bucket so spacing is accurate.
+// Entries with unparseable timestamps are skipped.
+func computeTrend(entries []schema.CheckpointEntry) TrendStats {
+	counts := map[time.Time]int{}
+	var first, last time.Time
+	for _, e := range entries {
+		ts, err := time.Parse(time.RFC3339, e.Timestamp)
+		if err != nil {
+			continue
+		}
+		week := weekStart(ts)
+		counts[week]++
+		if first.IsZero() || week.Before(first) {
+			first = week
+		}
+		if week.After(last) {
+			last = week
+		}
+	}
+
+	trend := TrendStats{Weeks: []WeekBucket{}}
+	if first.IsZero() {
+		return trend
+	}
+	for w := first; !w.After(last); w = w.AddDate(0, 0, 7) {
+		trend.Weeks = append(trend.Weeks, WeekBucket{Week: w.Format("2006-01-02"), Checkpoints: counts[w]})
+	}
+
+	busiest, quietest := trend.Weeks[0], trend.Weeks[0]
+	for _, b := range trend.Weeks[1:] {
+		if b.Checkpoints > busiest.Checkpoints {
+			busiest = b
+		}
+		if b.Checkpoints < quietest.Checkpoints {
+			quietest = b
+		}
+	}
+	trend.Busiest, trend.Quietest = &busiest, &quietest
+	return trend
+}
+
+// weekStart returns midnight UTC on the Monday of t's week
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7 // days since Monday
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+// sparkline levels: index 0 marks an empty week, the rest scale up to the max
+var (
+	sparkUnicode = []rune(" ▁▂▃▄▅▆▇█")
+	sparkASCII   = []rune(" .:-=+*#@")
+)
+
+// sparkline renders one character per count, scaled to the largest count
+func sparkline(counts []int, ascii bool) string {
+	levels := sparkUnicode
+	if ascii {
+		levels = sparkASCII
+	}
+	maxCount := 0
+	for _, c := range counts {
+		if c > maxCount {
+			maxCount = c
+		}
+	}
+	var sb strings.Builder
+	for _, c := range counts {
+		idx := 0
+		if c > 0 {
+			idx = 1 + (c-1)*(len(levels)-2)/max(maxCount-1, 1)
+			if maxCount == 1 {
+				idx = len(levels) - 1
+			}
+		}
+		sb.WriteRune(levels[idx])
+	}
+	return sb.String()
+}
+
+func printTrend(trend TrendStats, ascii bool) {
+	if len(trend.Weeks) == 0 {
+		fmt.Printf("\nWeekly activity: no dated checkpoints\n")
+		return
+	}
+	counts := make([]int, len(trend.Weeks))
+	for i, b := range trend.Weeks {
+		counts[i] = b.Checkpoints
+	}
+	fmt.Printf("\nWeekly activity (%s to %s, %d weeks):\n", trend.Weeks[0].Week, trend.Weeks[len(trend.Weeks)-1].Week, len(trend.Weeks))
+	fmt.Printf("  %s\n", sparkline(counts, ascii))
+	fmt.Printf("  Busiest week:  %s (%d checkpoint(s))\n", trend.Busiest.Week, trend.Busiest.Checkpoints)
+	fmt.Printf("  Quietest week: %s (%d checkpoint(s))\n", trend.Quietest.Week, trend.Quietest.Checkpoints)
+}
+
+// loadChangelogEntries parses every checkpoint document in the changelog, skipping the meta document
+func loadChangelogEntries(path string) ([]schema.CheckpointEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []schema.CheckpointEntry
+	for _, doc := range splitYAMLDocuments(string(data)) {
+		var meta struct {
+			DocumentType string `yaml:"document_type"`
+		}
+		if err := yaml.Unmarshal([]byte(doc), &meta); err != nil || meta.DocumentType == "meta" {
+			continue
+		}
+		var entry schema.CheckpointEntry
+		if err := yaml.Unmarshal([]byte(doc), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func computeStats(entries []schema.CheckpointEntry) ChangelogStats {
+	stats := ChangelogStats{
+		Checkpoints: len(entries),
+		ChangeTypes: map[string]int{},
+		Scopes:      []ScopeCount{},
+	}
+	scopes := map[string]int{}
+	var first, last time.Time
+	for _, e := range entries {
+		for _, c := range e.Changes {
+			stats.Changes++
+			stats.ChangeTypes[c.ChangeType]++
+			if scope := strings.TrimSpace(c.Scope); scope != "" {
+				scopes[scope]++
+			} else {
+				stats.Unscoped++
+			}
+		}
+		if ts, err := time.Parse(time.RFC3339, e.Timestamp); err == nil {
+			if first.IsZero() || ts.Before(first) {
+				first = ts
+			}
+			if ts.After(last) {
+				last = ts
+			}
+		}
+	}
+
+	for scope, n := range scopes {
+		stats.Scopes = append(stats.Scopes, ScopeCount{Scope: scope, Changes: n})
+	}
+	sort.Slice(stats.Scopes, func(i, j int) bool {
+		if stats.Scopes[i].Changes != stats.Scopes[j].Changes {
+			return stats.Scopes[i].Changes > stats.Scopes[j].Changes
+		}
+		return stats.Scopes[i].Scope < stats.Scopes[j].Scope
+	})
+	if len(stats.Scopes) > maxStatsScopes {
+		stats.Scopes = stats.Scopes[:maxStatsScopes]
+	}
+
+	if stats.Checkpoints > 0 {
+		stats.AvgChanges = float64(stats.Changes) / float64(stats.Checkpoints)
+	}
+	if !first.IsZero() {
+		// A span shorter than a week counts as one week
+		weeks := max(last.Sub(first).Hours()/(24*7), 1)
+		stats.CheckpointsPerWeek = float64(stats.Checkpoints) / weeks
+	}
+	return stats
+}
+
+// computeContributorStats groups entries by the author returned from resolve; entries
+// with no commit hash or an unresolvable one are bucketed under "unknown"
+func computeContributorStats(entries []schema.CheckpointEntry, resolve func(hash string) string) []ContributorStats {
+	byAuthor := map[string]*ContributorStats{}
+	for _, e := range entries {
+		author := ""
+		if e.CommitHash != "" {
+			author = resolve(e.CommitHash)
+		}
+		if author == "" {
+			author = unknownAuthor
+		}
+
+		cs, ok := byAuthor[author]
+		if !ok {
+			cs = &ContributorStats{Author: author, ChangeTypes: map[string]int{}}
+			byAuthor[author] = cs
+		}
+		cs.Checkpoints++
+		for _, c := range e.Changes {
+			cs.ChangeTypes[c.ChangeType]++
+		}
+	}
+
+	result := make([]ContributorStats, 0, len(byAuthor))
+	for _, cs := range byAuthor {
+		result = append(result, *cs)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Checkpoints != result[j].Checkpoints {
+			return result[i].Checkpoints > result[j].Checkpoints
+		}
+		return result[i].Author < result[j].Author
+	})
+	return result
+}
+
+func printStats(stats ChangelogStats) {
+	if stats.Checkpoints == 0 {
+		fmt.Println("No checkpoints yet. Run 'checkpoint check' to record the first one.")
+		return
+	}
+	fmt.Printf("Checkpoints: %d\n", stats.Checkpoints)
+	fmt.Printf("Changes:     %d (%.1f per checkpoint)\n", stats.Changes, stats.AvgChanges)
+	if stats.CheckpointsPerWeek > 0 {
+		fmt.Printf("Velocity:    %.1f checkpoints per week\n", stats.CheckpointsPerWeek)
+	}
+	if len(stats.ChangeTypes) > 0 {
+		fmt.Printf("\nChange types:\n")
+		fmt.Print(formatTypeCounts(stats.ChangeTypes, "  "))
+	}
+	if len(stats.Scopes) > 0 {
+		fmt.Printf("\nTop scopes:\n")
+		for _, sc := range stats.Scopes {
+			fmt.Printf("  %-10s %d\n", sc.Scope, sc.Changes)
+		}
+		if stats.Unscoped > 0 {
+			fmt.Printf("  %-10s %d\n", "(none)", stats.Unscoped)
+		}
+	}
+
+	if len(stats.Contributors) > 0 {
+		fmt.Printf("\nContributors:\n")
+		for _, cs := range stats.Contributors {
+			fmt.Printf("  %s: %d checkpoint(s)\n", cs.Author, cs.Checkpoints)
+			fmt.Print(formatTypeCounts(cs.ChangeTypes, "    "))
+		}
+	}
+}
+
+// formatTypeCounts renders change type counts, most frequent first
+func formatTypeCounts(counts map[string]int, prefix string) string {
+	types := make([]string, 0, len(counts))
+	for t := range counts {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if counts[types[i]] != counts[types[j]] {
+			return counts[types[i]] > counts[types[j]]
+		}
+		return types[i] < types[j]
+	})
+
+	var sb strings.Builder
+	for _, t := range types {
+		sb.WriteString(fmt.Sprintf("%s%-10s %d\n", prefix, t, counts[t]))
+	}
+	return sb.String()
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/dmoose/checkpoint/internal/schema"
+)
+
+func TestComputeContributorStats(t *testing.T) {
+	entries := []schema.CheckpointEntry{
+		{CommitHash: "aaa", Changes: []schema.Change{{ChangeType: "feature"}, {ChangeType: "fix"}}},
+		{CommitHash: "bbb", Changes: []schema.Change{{ChangeType: "docs"}}},
+		{CommitHash: "ccc", Changes: []schema.Change{{ChangeType: "feature"}}},
+		{CommitHash: "", Changes: []schema.Change{{ChangeType: "other"}}},
+		{CommitHash: "missing", Changes: []schema.Change{{ChangeType: "fix"}}},
+	}
+	authors := map[string]string{"aaa": "alice", "bbb": "bob", "ccc": "alice"}
+
+	result := computeContributorStats(entries, func(hash string) string { return authors[hash] })
+
+	if len(result) != 3 {
+		t.Fatalf("expected 3 contributors, got %d: %+v", len(result), result)
+	}
+	if result[0].Author != "alice" || result[0].Checkpoints != 2 {
+		t.Errorf("expected alice with 2 checkpoints first, got %+v", result[0])
+	}
+	if result[0].ChangeTypes["feature"] != 2 || result[0].ChangeTypes["fix"] != 1 {
+		t.Errorf("unexpected alice change types: %v", result[0].ChangeTypes)
+	}
+	if result[1].Author != unknownAuthor || result[1].Checkpoints != 2 {
+		t.Errorf("expected unknown with 2 checkpoints second, got %+v", result[1])
+	}
+	if result[2].Author != "bob" || result[2].ChangeTypes["docs"] != 1 {
+		t.Errorf("expected bob last, got %+v", result[2])
+	}
+}
+
+func TestComputeTrend(t *testing.T) {
+	entries := []schema.CheckpointEntry{
+		{Timestamp: "2025-01-06T09:00:00Z"},      // Monday, week 1
+		{Timestamp: "2025-01-12T23:00:00Z"},      // Sunday, still week 1
+		{Timestamp: "2025-01-13T01:00:00+02:00"}, // Sunday 23:00 UTC, week 1
+		{Timestamp: "2025-01-27T12:00:00Z"},      // week 4, after two empty weeks
+		{Timestamp: "not a timestamp"},
+	}
+	trend := computeTrend(entries)
+
+	want := []WeekBucket{
+		{Week: "2025-01-06", Checkpoints: 3},
+		{Week: "2025-01-13", Checkpoints: 0},
+		{Week: "2025-01-20", Checkpoints: 0},
+		{Week: "2025-01-27", Checkpoints: 1},
+	}
+	if len(trend.Weeks) != len(want) {
+		t.Fatalf("expected %d weeks, got %+v", len(want), trend.Weeks)
+	}
+	for i := range want {
+		if trend.Weeks[i] != want[i] {
+			t.Errorf("week %d = %+v, want %+v", i, trend.Weeks[i], want[i])
+		}
+	}
+	if trend.Busiest.Week != "2025-01-06" || trend.Quietest.Week != "2025-01-13" {
+		t.Errorf("unexpected busiest/quietest: %+v / %+v", trend.Busiest, trend.Quietest)
+	}
+
+	if empty := computeTrend(nil); len(empty.Weeks) != 0 || empty.Busiest != nil {
+		t.Errorf("expected empty trend, got %+v", empty)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	if got := sparkline([]int{3, 0, 0, 1}, false); got != "█  ▁" {
+		t.Errorf("unicode sparkline = %q", got)
+	}
+	if got := sparkline([]int{1, 0, 1}, true); got != "@ @" {
+		t.Errorf("ascii sparkline = %q", got)
+	}
+}
+
+func TestComputeStats(t *testing.T) {
+	entries := []schema.CheckpointEntry{
+		{Timestamp: "2025-01-01T09:00:00Z", Changes: []schema.Change{
+			{ChangeType: "feature", Scope: "cmd"},
+			{ChangeType: "fix", Scope: "cmd"},
+			{ChangeType: "docs"},
+		}},
+		{Timestamp: "2025-01-15T09:00:00Z", Changes: []schema.Change{
+			{ChangeType: "feature", Scope: "internal/git"},
+		}},
+	}
+
+	stats := computeStats(entries)
+
+	if stats.Checkpoints != 2 || stats.Changes != 4 {
+		t.Fatalf("expected 2 checkpoints and 4 changes, got %+v", stats)
+	}
+	if stats.AvgChanges != 2 {
+		t.Errorf("expected 2 changes per checkpoint, got %v", stats.AvgChanges)
+	}
+	if stats.CheckpointsPerWeek != 1 {
+		t.Errorf("expected 1 checkpoint per week over two weeks, got %v", stats.CheckpointsPerWeek)
+	}
+	if len(stats.Scopes) != 2 || stats.Scopes[0] != (ScopeCount{Scope: "cmd", Changes: 2}) {
+		t.Errorf("unexpected scopes: %+v", stats.Scopes)
+	}
+	if stats.Unscoped != 1 {
+		t.Errorf("expected 1 unscoped change, got %d", stats.Unscoped)
+	}
+
+	empty := computeStats(nil)
+	if empty.Checkpoints != 0 || empty.AvgChanges != 0 || empty.CheckpointsPerWeek != 0 || empty.Scopes == nil {
+		t.Errorf("unexpected stats for empty changelog: %+v", empty)
+	}
+}
@@ -2,13 +2,17 @@ package cmd
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/dmoose/checkpoint/internal/context"
 	"github.com/dmoose/checkpoint/internal/file"
 	"github.com/dmoose/checkpoint/internal/git"
+	"github.com/dmoose/checkpoint/internal/schema"
 	"github.com/dmoose/checkpoint/pkg/config"
 
 	"github.com/spf13/cobra"
@@ -16,19 +20,25 @@ import (
 )
 
 var summaryOpts struct {
-	json bool
+	json    bool
+	compare string
 }
 
 func init() {
 	rootCmd.AddCommand(summaryCmd)
 	summaryCmd.Flags().BoolVar(&summaryOpts.json, "json", false, "Output as JSON")
+	summaryCmd.Flags().StringVar(&summaryOpts.compare, "compare", "", "Show deltas since a point in time (e.g. 2w, 3d, 2025-01-15)")
 }
 
 var summaryCmd = &cobra.Command{
 	Use:   "summary [path]",
 	Short: "Show project overview and recent activity",
-	Long:  `Displays checkpoint count, recent activity, next steps, and patterns.`,
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Displays checkpoint count, recent activity, next steps, and patterns.
+
+With --compare <when>, shows what changed since then instead: checkpoints
+added, next steps resolved or added, and patterns established. <when> is a
+relative duration (12h, 3d, 2w, 1m) or a date (2006-01-02 or RFC3339).`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		projectPath := "."
 		if len(args) > 0 {
@@ -39,6 +49,10 @@ var summaryCmd = &cobra.Command{
 			fmt.Fprintf(os.Stderr, "error: cannot resolve path: %v\n", err)
 			os.Exit(1)
 		}
+		if summaryOpts.compare != "" {
+			SummaryCompare(absPath, summaryOpts.compare, summaryOpts.json)
+			return
+		}
 		Summary(absPath, summaryOpts.json)
 	},
 }
@@ -457,3 +471,164 @@ func formatTimeAgo(timestamp string) string {
 		return fmt.Sprintf("%d months ago", months)
 	}
 }
+
+// summaryDelta is the before/after view produced by --compare
+type summaryDelta struct {
+	Since               string         `json:"since"`
+	CheckpointsAdded    int            `json:"checkpoints_added"`
+	ChangeTypes         map[string]int `json:"change_types"`
+	NextStepsResolved   []string       `json:"next_steps_resolved"`
+	NextStepsAdded      []string       `json:"next_steps_added"`
+	PatternsEstablished []string       `json:"patterns_established"`
+}
+
+// SummaryCompare displays deltas between a past point in time and now
+func SummaryCompare(projectPath, when string, jsonOutput bool) {
+	since, err := parseSinceTime(when, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "hint: use a duration like 3d, 2w, 1m or a date like 2025-01-15\n")
+		os.Exit(1)
+	}
+
+	changelogPath := filepath.Join(projectPath, config.ChangelogFileName)
+	if !file.Exists(changelogPath) {
+		fmt.Fprintf(os.Stderr, "Checkpoint not initialized in %s\n", projectPath)
+		fmt.Fprintf(os.Stderr, "Hint: Run 'checkpoint init' to initialize\n")
+		os.Exit(1)
+	}
+	entries, err := loadChangelogEntries(changelogPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: failed to read changelog: %v\n", err)
+		os.Exit(1)
+	}
+
+	contextPath := filepath.Join(projectPath, config.ContextFileName)
+	contextEntries, _ := context.GetRecentContextEntries(contextPath, math.MaxInt32)
+
+	delta := computeSummaryDelta(entries, contextEntries, since)
+
+	if jsonOutput {
+		writeJSON(delta)
+		return
+	}
+	printSummaryDelta(delta)
+}
+
+// computeSummaryDelta compares the state at since with the latest state
+func computeSummaryDelta(entries []schema.CheckpointEntry, contextEntries []context.ContextEntry, since time.Time) summaryDelta {
+	delta := summaryDelta{
+		Since:               since.Format(time.RFC3339),
+		ChangeTypes:         map[string]int{},
+		NextStepsResolved:   []string{},
+		NextStepsAdded:      []string{},
+		PatternsEstablished: []string{},
+	}
+
+	var before, after []schema.NextStep
+	for _, e := range entries {
+		ts, err := time.Parse(time.RFC3339, e.Timestamp)
+		if err != nil {
+			continue
+		}
+		if ts.Before(since) {
+			before = e.NextSteps
+			continue
+		}
+		delta.CheckpointsAdded++
+		for _, c := range e.Changes {
+			delta.ChangeTypes[c.ChangeType]++
+		}
+		after = e.NextSteps
+	}
+	if delta.CheckpointsAdded == 0 {
+		after = before
+	}
+
+	key := func(s string) string { return strings.ToLower(strings.TrimSpace(s)) }
+	beforeSet := map[string]bool{}
+	for _, ns := range before {
+		beforeSet[key(ns.Summary)] = true
+	}
+	afterSet := map[string]bool{}
+	for _, ns := range after {
+		afterSet[key(ns.Summary)] = true
+		if !beforeSet[key(ns.Summary)] {
+			delta.NextStepsAdded = append(delta.NextStepsAdded, ns.Summary)
+		}
+	}
+	for _, ns := range before {
+		if !afterSet[key(ns.Summary)] {
+			delta.NextStepsResolved = append(delta.NextStepsResolved, ns.Summary)
+		}
+	}
+
+	for _, ce := range contextEntries {
+		ts, err := time.Parse(time.RFC3339, ce.Timestamp)
+		if err != nil || ts.Before(since) {
+			continue
+		}
+		for _, p := range ce.Context.EstablishedPatterns {
+			delta.PatternsEstablished = append(delta.PatternsEstablished, p.Pattern)
+		}
+	}
+
+	return delta
+}
+
+func printSummaryDelta(delta summaryDelta) {
+	fmt.Println()
+	fmt.Printf("CHANGES SINCE %s\n", delta.Since)
+	fmt.Println(strings.Repeat("━", 60))
+	fmt.Printf("Checkpoints added: %d\n", delta.CheckpointsAdded)
+	if len(delta.ChangeTypes) > 0 {
+		fmt.Print(formatTypeCounts(delta.ChangeTypes, "  "))
+	}
+	fmt.Println()
+
+	printDeltaList := func(title string, items []string) {
+		if len(items) == 0 {
+			return
+		}
+		fmt.Println(title)
+		fmt.Println(strings.Repeat("━", 60))
+		for _, item := range items {
+			fmt.Printf("• %s\n", item)
+		}
+		fmt.Println()
+	}
+	printDeltaList("NEXT STEPS RESOLVED", delta.NextStepsResolved)
+	printDeltaList("NEXT STEPS ADDED", delta.NextStepsAdded)
+	printDeltaList("PATTERNS ESTABLISHED", delta.PatternsEstablished)
+}
+
+// parseSinceTime parses a relative duration (12h, 3d, 2w, 1m, 1y) or a date
+// (2006-01-02 or RFC3339) into an absolute time before now
+func parseSinceTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return t, nil
+	}
+
+	if len(s) >= 2 {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err == nil && n >= 0 {
+			switch s[len(s)-1] {
+			case 'h':
+				return now.Add(-time.Duration(n) * time.Hour), nil
+			case 'd':
+				return now.AddDate(0, 0, -n), nil
+			case 'w':
+				return now.AddDate(0, 0, -7*n), nil
+			case 'm':
+				return now.AddDate(0, -n, 0), nil
+			case 'y':
+				return now.AddDate(-n, 0, 0), nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time '%s'", s)
+}
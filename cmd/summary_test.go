@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dmoose/checkpoint/internal/context"
+	"github.com/dmoose/checkpoint/internal/schema"
+)
+
+func TestParseSinceTime(t *testing.T) {
+	now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"12h", now.Add(-12 * time.Hour), false},
+		{"3d", now.AddDate(0, 0, -3), false},
+		{"2w", now.AddDate(0, 0, -14), false},
+		{"1m", now.AddDate(0, -1, 0), false},
+		{"2025-01-15", time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), false},
+		{"2025-01-15T08:00:00Z", time.Date(2025, 1, 15, 8, 0, 0, 0, time.UTC), false},
+		{"soon", time.Time{}, true},
+		{"5x", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseSinceTime(tt.input, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSinceTime(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("parseSinceTime(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeSummaryDelta(t *testing.T) {
+	entries := []schema.CheckpointEntry{
+		{
+			Timestamp: "2025-01-01T00:00:00Z",
+			Changes:   []schema.Change{{ChangeType: "feature"}},
+			NextSteps: []schema.NextStep{{Summary: "Write docs"}, {Summary: "Add tests"}},
+		},
+		{
+			Timestamp: "2025-01-10T00:00:00Z",
+			Changes:   []schema.Change{{ChangeType: "docs"}, {ChangeType: "fix"}},
+			NextSteps: []schema.NextStep{{Summary: "Add tests"}, {Summary: "Release"}},
+		},
+	}
+	contextEntries := []context.ContextEntry{
+		{Timestamp: "2025-01-01T00:00:00Z", Context: context.CheckpointContext{EstablishedPatterns: []context.Pattern{{Pattern: "Old pattern"}}}},
+		{Timestamp: "2025-01-10T00:00:00Z", Context: context.CheckpointContext{EstablishedPatterns: []context.Pattern{{Pattern: "New pattern"}}}},
+	}
+
+	delta := computeSummaryDelta(entries, contextEntries, time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC))
+
+	if delta.CheckpointsAdded != 1 {
+		t.Errorf("CheckpointsAdded = %d, want 1", delta.CheckpointsAdded)
+	}
+	if delta.ChangeTypes["docs"] != 1 || delta.ChangeTypes["fix"] != 1 || delta.ChangeTypes["feature"] != 0 {
+		t.Errorf("unexpected change types: %v", delta.ChangeTypes)
+	}
+	if len(delta.NextStepsResolved) != 1 || delta.NextStepsResolved[0] != "Write docs" {
+		t.Errorf("NextStepsResolved = %v, want [Write docs]", delta.NextStepsResolved)
+	}
+	if len(delta.NextStepsAdded) != 1 || delta.NextStepsAdded[0] != "Release" {
+		t.Errorf("NextStepsAdded = %v, want [Release]", delta.NextStepsAdded)
+	}
+	if len(delta.PatternsEstablished) != 1 || delta.PatternsEstablished[0] != "New pattern" {
+		t.Errorf("PatternsEstablished = %v, want [New pattern]", delta.PatternsEstablished)
+	}
+}
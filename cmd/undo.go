@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dmoose/checkpoint/internal/changelog"
+	"github.com/dmoose/checkpoint/internal/context"
+	"github.com/dmoose/checkpoint/internal/file"
+	"github.com/dmoose/checkpoint/internal/git"
+	"github.com/dmoose/checkpoint/internal/schema"
+	"github.com/dmoose/checkpoint/pkg/config"
+
+	"github.com/spf13/cobra"
+)
+
+var undoOpts struct {
+	force bool
+	reset bool
+}
+
+func init() {
+	rootCmd.AddCommand(undoCmd)
+	undoCmd.Flags().BoolVarP(&undoOpts.force, "force", "f", false, "Skip the confirmation prompt")
+	undoCmd.Flags().BoolVar(&undoOpts.reset, "reset", false, "Also run 'git reset --soft HEAD~1' when HEAD is the checkpoint commit")
+}
+
+var undoCmd = &cobra.Command{
+	Use:   "undo [path]",
+	Short: "Remove the most recent checkpoint",
+	Long: `Removes the last checkpoint document from the changelog (the meta
+document is kept) and its entry from the context file.
+
+With --reset, the checkpoint's git commit is undone too with
+'git reset --soft HEAD~1', leaving its changes staged. HEAD must be the
+checkpoint commit: the one whose hash was recorded in the changelog, or one
+whose subject starts with "Checkpoint:" when no hash was recorded.
+
+Undo refuses to run while files other than checkpoint artifacts have
+uncommitted changes. It lists what it will remove and asks for
+confirmation unless --force is given.
+
+Examples:
+  checkpoint undo
+  checkpoint undo --reset
+  checkpoint undo --reset --force`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		projectPath := "."
+		if len(args) > 0 {
+			projectPath = args[0]
+		}
+		absPath, err := filepath.Abs(projectPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: cannot resolve path: %v\n", err)
+			os.Exit(1)
+		}
+		Undo(absPath, UndoOptions{Force: undoOpts.force, Reset: undoOpts.reset})
+	},
+}
+
+// UndoOptions holds flags for the undo command
+type UndoOptions struct {
+	Force bool // skip confirmation
+	Reset bool // git reset --soft HEAD~1 when HEAD is the checkpoint commit
+}
+
+// Undo removes the most recent checkpoint from the changelog and context file
+func Undo(projectPath string, opts UndoOptions) {
+	changelogPath := filepath.Join(projectPath, config.ChangelogFileName)
+	entry, err := changelog.LastEntry(changelogPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: failed to read changelog: %v\n", err)
+		os.Exit(1)
+	}
+	if entry == nil {
+		fmt.Fprintf(os.Stderr, "error: no checkpoint to undo\n")
+		os.Exit(1)
+	}
+
+	status, err := git.GetStatus(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	if dirty := undoBlockingChanges(status); len(dirty) > 0 {
+		fmt.Fprintf(os.Stderr, "error: uncommitted changes outside checkpoint files:\n")
+		for _, path := range dirty {
+			fmt.Fprintf(os.Stderr, "  %s\n", path)
+		}
+		fmt.Fprintf(os.Stderr, "hint: commit or stash them before 'checkpoint undo'\n")
+		os.Exit(1)
+	}
+
+	headHash, headSubject, err := git.HeadCommit(projectPath)
+	if err != nil && opts.Reset {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	headIsCheckpoint := err == nil && isCheckpointHead(entry, headHash, headSubject)
+	short := headHash[:min(8, len(headHash))]
+	if opts.Reset && !headIsCheckpoint {
+		fmt.Fprintf(os.Stderr, "error: HEAD (%s) is not the checkpoint commit\n", short)
+		fmt.Fprintf(os.Stderr, "hint: run without --reset to only remove the changelog and context entries\n")
+		os.Exit(1)
+	}
+
+	contextPath := file.FindWithFallback(
+		filepath.Join(projectPath, config.ContextFileName),
+		filepath.Join(projectPath, config.ContextFileNameLegacy),
+	)
+
+	fmt.Printf("Will undo the checkpoint from %s:\n", entry.Timestamp)
+	for _, c := range entry.Changes {
+		fmt.Printf("  - %s: %s\n", c.ChangeType, c.Summary)
+	}
+	fmt.Printf("\nThis removes:\n")
+	fmt.Printf("  - the last entry in %s\n", config.ChangelogFileName)
+	fmt.Printf("  - its context entry in %s (if present)\n", filepath.Base(contextPath))
+	if opts.Reset {
+		fmt.Printf("  - commit %s %q (git reset --soft HEAD~1; changes stay staged)\n", short, headSubject)
+		if entry.Tag != "" {
+			fmt.Printf("\nTag %s will still point at the undone commit; delete it with 'git tag -d %s'\n", entry.Tag, entry.Tag)
+		}
+	} else if headIsCheckpoint {
+		fmt.Printf("\nThe git commit %s is kept; add --reset to undo it as well\n", short)
+	}
+
+	if !opts.Force {
+		if !stdinIsTerminal() {
+			fmt.Fprintf(os.Stderr, "error: confirmation required\n")
+			fmt.Fprintf(os.Stderr, "hint: re-run with --force to undo without prompting\n")
+			os.Exit(1)
+		}
+		answer, err := promptLine(bufio.NewReader(os.Stdin), os.Stdout, "\nProceed? [y/N] ")
+		if err != nil || !strings.EqualFold(answer, "y") && !strings.EqualFold(answer, "yes") {
+			fmt.Println("Aborted.")
+			return
+		}
+	}
+
+	// Reset first so a git failure leaves the changelog untouched
+	if opts.Reset {
+		if err := git.ResetSoft(projectPath, "HEAD~1"); err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if _, err := changelog.RemoveLastEntry(changelogPath); err != nil {
+		fmt.Fprintf(os.Stderr, "error: failed to remove changelog entry: %v\n", err)
+		os.Exit(1)
+	}
+	removedContext, err := context.RemoveContextEntry(contextPath, entry.Timestamp)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: failed to remove context entry: %v\n", err)
+	}
+
+	if opts.Reset {
+		// The reset left the checkpoint's versions of these staged
+		staged := []string{config.ChangelogFileName}
+		if removedContext {
+			staged = append(staged, filepath.Base(contextPath))
+		}
+		for _, name := range staged {
+			if err := git.StageFile(projectPath, name); err != nil {
+				fmt.Fprintf(os.Stderr, "warning: failed to stage %s: %v\n", name, err)
+			}
+		}
+	}
+
+	fmt.Printf("✓ Removed checkpoint from %s\n", entry.Timestamp)
+	if removedContext {
+		fmt.Println("✓ Removed its context entry")
+	}
+	if opts.Reset {
+		fmt.Printf("✓ Reset %s; its changes are staged\n", short)
+	}
+}
+
+// isCheckpointHead reports whether HEAD is the commit that recorded entry:
+// the backfilled commit_hash when present, otherwise a "Checkpoint:" subject
+func isCheckpointHead(entry *schema.CheckpointEntry, headHash, headSubject string) bool {
+	if entry.CommitHash != "" {
+		return headHash != "" && strings.HasPrefix(headHash, entry.CommitHash)
+	}
+	return strings.HasPrefix(headSubject, "Checkpoint:")
+}
+
+// undoBlockingChanges lists changed paths from git status that are not
+// checkpoint artifacts (the .checkpoint-* files commit writes or backfills)
+func undoBlockingChanges(status string) []string {
+	var dirty []string
+	for _, fs := range schema.ParseGitStatus(status) {
+		if strings.HasPrefix(fs.Path, ".checkpoint-") {
+			continue
+		}
+		dirty = append(dirty, fs.Path)
+	}
+	return dirty
+}
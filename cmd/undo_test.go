@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/dmoose/checkpoint/internal/schema"
+)
+
+func TestIsCheckpointHead(t *testing.T) {
+	tests := []struct {
+		name    string
+		hash    string
+		head    string
+		subject string
+		want    bool
+	}{
+		{"recorded hash matches", "abc123", "abc123def", "Custom message", true},
+		{"recorded hash differs", "abc123", "fff000", "Checkpoint: fix - x", false},
+		{"no hash, checkpoint subject", "", "fff000", "Checkpoint: fix - x", true},
+		{"no hash, other subject", "", "fff000", "Merge branch 'main'", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := &schema.CheckpointEntry{CommitHash: tt.hash}
+			if got := isCheckpointHead(entry, tt.head, tt.subject); got != tt.want {
+				t.Errorf("isCheckpointHead() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUndoBlockingChanges(t *testing.T) {
+	status := " M .checkpoint-changelog.yaml\n M .checkpoint-context.yaml\n?? .checkpoint-session.yaml\n M main.go\n?? notes.txt\n"
+	dirty := undoBlockingChanges(status)
+	if len(dirty) != 2 || dirty[0] != "main.go" || dirty[1] != "notes.txt" {
+		t.Errorf("unexpected blocking changes: %v", dirty)
+	}
+}
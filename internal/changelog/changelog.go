@@ -127,21 +127,15 @@ func prependMetaDocument(changelogPath, toolVersion, existingContent string) err
 
 // createMetaDocument creates a new meta document with project metadata
 func createMetaDocument(changelogPath, toolVersion string) (*MetaDocument, error) {
-	// Get absolute path of the working directory
 	workDir := filepath.Dir(changelogPath)
-	absPath, err := filepath.Abs(workDir)
+	pathHash, err := ComputePathHash(changelogPath)
 	if err != nil {
-		return nil, fmt.Errorf("get absolute path: %w", err)
+		return nil, err
 	}
 
 	// Generate project ID (ULID)
 	projectID := ulid.Make().String()
 
-	// Generate path hash (truncated SHA256 of absolute path)
-	hasher := sha256.New()
-	hasher.Write([]byte(absPath))
-	pathHash := fmt.Sprintf("%x", hasher.Sum(nil))[:16] // First 16 chars
-
 	// Detect project languages
 	languages, _ := language.DetectLanguages(workDir) // tolerate errors in language detection
 
@@ -156,6 +150,54 @@ func createMetaDocument(changelogPath, toolVersion string) (*MetaDocument, error
 	}, nil
 }
 
+// ComputePathHash returns the path hash for the project holding changelogPath:
+// the first 16 hex chars of the SHA256 of its absolute directory
+func ComputePathHash(changelogPath string) (string, error) {
+	absPath, err := filepath.Abs(filepath.Dir(changelogPath))
+	if err != nil {
+		return "", fmt.Errorf("get absolute path: %w", err)
+	}
+	hasher := sha256.New()
+	hasher.Write([]byte(absPath))
+	return fmt.Sprintf("%x", hasher.Sum(nil))[:16], nil
+}
+
+// UpdateMetaPathHash rewrites the path_hash line of the meta document in
+// place, leaving the rest of the changelog byte-for-byte unchanged
+func UpdateMetaPathHash(changelogPath, pathHash string) error {
+	content, err := os.ReadFile(changelogPath)
+	if err != nil {
+		return fmt.Errorf("read changelog: %w", err)
+	}
+	contentStr := string(content)
+
+	// Limit the edit to the first document
+	body := strings.TrimPrefix(contentStr, "---\n")
+	offset := len(contentStr) - len(body)
+	meta := body
+	if idx := strings.Index(body, "\n---\n"); idx != -1 {
+		meta = body[:idx]
+	}
+	if !strings.Contains(meta, "document_type: meta") {
+		return fmt.Errorf("no meta document in changelog")
+	}
+
+	var start int
+	if strings.HasPrefix(meta, "path_hash:") {
+		start = 0
+	} else if idx := strings.Index(meta, "\npath_hash:"); idx != -1 {
+		start = idx + 1
+	} else {
+		return fmt.Errorf("meta document has no path_hash field")
+	}
+	end := len(meta)
+	if idx := strings.Index(meta[start:], "\n"); idx != -1 {
+		end = start + idx
+	}
+	newContent := contentStr[:offset+start] + "path_hash: " + pathHash + contentStr[offset+end:]
+	return os.WriteFile(changelogPath, []byte(newContent), 0644)
+}
+
 // ReadMetaDocument reads and returns the meta document from the changelog
 // Returns nil if no meta document exists (non-fatal)
 func ReadMetaDocument(changelogPath string) (*MetaDocument, error) {
@@ -243,3 +285,108 @@ func UpdateLastDocument(path string, fn func(*schema.CheckpointEntry) *schema.Ch
 
 	return os.WriteFile(path, []byte(newContent), 0644)
 }
+
+// lastDocument locates the last YAML document in the changelog, returning
+// the offset of its "---" separator and its body
+func lastDocument(content string) (int, string, error) {
+	if idx := strings.LastIndex(content, "\n---\n"); idx != -1 {
+		return idx + 1, content[idx+5:], nil
+	}
+	if strings.HasPrefix(content, "---\n") {
+		return 0, content[4:], nil
+	}
+	return 0, "", fmt.Errorf("no YAML document separators found")
+}
+
+// LastEntry returns the last checkpoint document in the changelog, or nil
+// when there is none (missing file, or only the meta document)
+func LastEntry(path string) (*schema.CheckpointEntry, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read changelog: %w", err)
+	}
+	if len(strings.TrimSpace(string(content))) == 0 {
+		return nil, nil
+	}
+	_, doc, err := lastDocument(string(content))
+	if err != nil {
+		return nil, err
+	}
+	return parseCheckpointDocument(doc)
+}
+
+// RemoveLastEntry drops the last checkpoint document from the changelog,
+// leaving the meta document and earlier entries untouched, and returns it
+func RemoveLastEntry(path string) (*schema.CheckpointEntry, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read changelog: %w", err)
+	}
+	start, doc, err := lastDocument(string(content))
+	if err != nil {
+		return nil, err
+	}
+	entry, err := parseCheckpointDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("changelog has no checkpoint entries")
+	}
+	if err := os.WriteFile(path, content[:start], 0644); err != nil {
+		return nil, fmt.Errorf("write changelog: %w", err)
+	}
+	return entry, nil
+}
+
+// ReplaceLastEntry swaps the last checkpoint document for doc, leaving the
+// meta document and earlier entries untouched, and returns the replaced entry
+func ReplaceLastEntry(path, doc string) (*schema.CheckpointEntry, error) {
+	if len(doc) == 0 {
+		return nil, fmt.Errorf("empty document")
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read changelog: %w", err)
+	}
+	start, last, err := lastDocument(string(content))
+	if err != nil {
+		return nil, err
+	}
+	entry, err := parseCheckpointDocument(last)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("changelog has no checkpoint entries")
+	}
+	if doc[len(doc)-1] != '\n' {
+		doc += "\n"
+	}
+	updated := append(content[:start:start], doc...)
+	if err := os.WriteFile(path, updated, 0644); err != nil {
+		return nil, fmt.Errorf("write changelog: %w", err)
+	}
+	return entry, nil
+}
+
+// parseCheckpointDocument decodes a changelog document, returning nil for the meta document
+func parseCheckpointDocument(doc string) (*schema.CheckpointEntry, error) {
+	var kind struct {
+		DocumentType string `yaml:"document_type"`
+	}
+	if err := yaml.Unmarshal([]byte(doc), &kind); err != nil {
+		return nil, fmt.Errorf("decode last document: %w", err)
+	}
+	if kind.DocumentType == "meta" {
+		return nil, nil
+	}
+	var entry schema.CheckpointEntry
+	if err := yaml.Unmarshal([]byte(doc), &entry); err != nil {
+		return nil, fmt.Errorf("decode last document: %w", err)
+	}
+	return &entry, nil
+}
@@ -56,3 +56,82 @@ func containsAll(s string, subs []string) bool {
 	}
 	return true
 }
+
+func TestRemoveLastEntry(t *testing.T) {
+	dir := t.TempDir()
+	p := filepath.Join(dir, ".checkpoint-changelog.yaml")
+	meta := "---\nschema_version: \"1\"\ndocument_type: meta\nproject_id: abc\n"
+	first := "---\nschema_version: \"1\"\ntimestamp: \"2025-10-22T00:00:00Z\"\nchanges:\n  - summary: \"first\"\n    change_type: \"feature\"\n"
+	second := "---\nschema_version: \"1\"\ntimestamp: \"2025-10-22T01:00:00Z\"\ncommit_hash: deadbeef\nchanges:\n  - summary: \"second\"\n    change_type: \"fix\"\n"
+	if err := os.WriteFile(p, []byte(meta+first+second), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	last, err := LastEntry(p)
+	if err != nil || last == nil || last.CommitHash != "deadbeef" {
+		t.Fatalf("LastEntry = %+v, %v", last, err)
+	}
+
+	removed, err := RemoveLastEntry(p)
+	if err != nil {
+		t.Fatalf("RemoveLastEntry: %v", err)
+	}
+	if removed.Changes[0].Summary != "second" {
+		t.Errorf("removed wrong entry: %+v", removed)
+	}
+	b, _ := os.ReadFile(p)
+	if string(b) != meta+first {
+		t.Errorf("unexpected changelog after removal:\n%s", b)
+	}
+
+	if _, err := RemoveLastEntry(p); err != nil {
+		t.Fatalf("RemoveLastEntry: %v", err)
+	}
+	// Only the meta document remains and it is never removed
+	if last, err := LastEntry(p); err != nil || last != nil {
+		t.Errorf("expected no entries, got %+v, %v", last, err)
+	}
+	if _, err := RemoveLastEntry(p); err == nil {
+		t.Error("expected error removing the meta document")
+	}
+	b, _ = os.ReadFile(p)
+	if string(b) != meta {
+		t.Errorf("meta document changed:\n%s", b)
+	}
+}
+
+func TestReplaceLastEntry(t *testing.T) {
+	dir := t.TempDir()
+	p := filepath.Join(dir, ".checkpoint-changelog.yaml")
+	meta := "---\nschema_version: \"1\"\ndocument_type: meta\nproject_id: abc\n"
+	first := "---\nschema_version: \"1\"\ntimestamp: \"2025-10-22T00:00:00Z\"\nchanges:\n  - summary: \"first\"\n    change_type: \"feature\"\n"
+	second := "---\nschema_version: \"1\"\ntimestamp: \"2025-10-22T01:00:00Z\"\ncommit_hash: deadbeef\nchanges:\n  - summary: \"second\"\n    change_type: \"fix\"\n"
+	amended := "---\nschema_version: \"1\"\ntimestamp: \"2025-10-22T01:00:00Z\"\ncommit_hash: \"\"\nchanges:\n  - summary: \"second, amended\"\n    change_type: \"fix\"\n"
+	if err := os.WriteFile(p, []byte(meta+first+second), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	replaced, err := ReplaceLastEntry(p, amended)
+	if err != nil {
+		t.Fatalf("ReplaceLastEntry: %v", err)
+	}
+	if replaced.Changes[0].Summary != "second" {
+		t.Errorf("replaced wrong entry: %+v", replaced)
+	}
+	b, _ := os.ReadFile(p)
+	if string(b) != meta+first+amended {
+		t.Errorf("unexpected changelog after replace:\n%s", b)
+	}
+
+	// The meta document is never replaced
+	if err := os.WriteFile(p, []byte(meta), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := ReplaceLastEntry(p, amended); err == nil {
+		t.Error("expected error replacing the meta document")
+	}
+	b, _ = os.ReadFile(p)
+	if string(b) != meta {
+		t.Errorf("meta document changed:\n%s", b)
+	}
+}
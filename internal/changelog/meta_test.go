@@ -517,3 +517,40 @@ tool_version: "1.0.0"
 		t.Error("expected nil meta when first document is not meta type")
 	}
 }
+
+func TestUpdateMetaPathHash(t *testing.T) {
+	tmpDir := t.TempDir()
+	changelogPath := filepath.Join(tmpDir, ".checkpoint-changelog.yaml")
+	content := "---\nschema_version: \"1\"\ndocument_type: meta\nproject_id: abc\npath_hash: 0000000000000000\n" +
+		"---\nschema_version: \"1\"\nsummary: keep path_hash: untouched\n"
+	if err := os.WriteFile(changelogPath, []byte(content), 0644); err != nil {
+		t.Fatalf("write changelog: %v", err)
+	}
+
+	want, err := ComputePathHash(changelogPath)
+	if err != nil {
+		t.Fatalf("ComputePathHash failed: %v", err)
+	}
+	if err := UpdateMetaPathHash(changelogPath, want); err != nil {
+		t.Fatalf("UpdateMetaPathHash failed: %v", err)
+	}
+
+	meta, err := ReadMetaDocument(changelogPath)
+	if err != nil || meta == nil {
+		t.Fatalf("ReadMetaDocument failed: %v", err)
+	}
+	if meta.PathHash != want {
+		t.Errorf("expected path_hash %s, got %s", want, meta.PathHash)
+	}
+	data, _ := os.ReadFile(changelogPath)
+	if !strings.HasSuffix(string(data), "---\nschema_version: \"1\"\nsummary: keep path_hash: untouched\n") {
+		t.Errorf("entries after the meta document should be unchanged:\n%s", data)
+	}
+
+	if err := os.WriteFile(changelogPath, []byte("---\nschema_version: \"1\"\n"), 0644); err != nil {
+		t.Fatalf("write changelog: %v", err)
+	}
+	if err := UpdateMetaPathHash(changelogPath, want); err == nil {
+		t.Error("expected error without a meta document")
+	}
+}
@@ -3,58 +3,67 @@ package context
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
 // ContextEntry represents a single checkpoint's context
 type ContextEntry struct {
-	SchemaVersion string            `yaml:"schema_version"`
-	Timestamp     string            `yaml:"timestamp"`
-	Context       CheckpointContext `yaml:"context"`
+	SchemaVersion string            `yaml:"schema_version" json:"schema_version"`
+	Timestamp     string            `yaml:"timestamp" json:"timestamp"`
+	Context       CheckpointContext `yaml:"context" json:"context"`
 }
 
 // CheckpointContext represents the context captured at a checkpoint
 type CheckpointContext struct {
-	ProblemStatement    string             `yaml:"problem_statement"`
-	KeyInsights         []Insight          `yaml:"key_insights,omitempty"`
-	DecisionsMade       []Decision         `yaml:"decisions_made,omitempty"`
-	FailedApproaches    []FailedApproach   `yaml:"failed_approaches,omitempty"`
-	EstablishedPatterns []Pattern          `yaml:"established_patterns,omitempty"`
-	ConversationContext []ConversationItem `yaml:"conversation_context,omitempty"`
+	ProblemStatement    string             `yaml:"problem_statement" json:"problem_statement"`
+	KeyInsights         []Insight          `yaml:"key_insights,omitempty" json:"key_insights,omitempty"`
+	DecisionsMade       []Decision         `yaml:"decisions_made,omitempty" json:"decisions_made,omitempty"`
+	FailedApproaches    []FailedApproach   `yaml:"failed_approaches,omitempty" json:"failed_approaches,omitempty"`
+	EstablishedPatterns []Pattern          `yaml:"established_patterns,omitempty" json:"established_patterns,omitempty"`
+	ConversationContext []ConversationItem `yaml:"conversation_context,omitempty" json:"conversation_context,omitempty"`
+	ExternalContext     []ExternalContext  `yaml:"external_context,omitempty" json:"external_context,omitempty"`
 }
 
 type Insight struct {
-	Insight string `yaml:"insight"`
-	Impact  string `yaml:"impact,omitempty"`
-	Scope   string `yaml:"scope,omitempty"` // checkpoint|project
+	Insight string `yaml:"insight" json:"insight"`
+	Impact  string `yaml:"impact,omitempty" json:"impact,omitempty"`
+	Scope   string `yaml:"scope,omitempty" json:"scope,omitempty"` // checkpoint|project
 }
 
 type Decision struct {
-	Decision                  string   `yaml:"decision"`
-	Rationale                 string   `yaml:"rationale"`
-	AlternativesConsidered    []string `yaml:"alternatives_considered,omitempty"`
-	ConstraintsThatInfluenced string   `yaml:"constraints_that_influenced,omitempty"`
-	Scope                     string   `yaml:"scope,omitempty"` // checkpoint|project
+	Decision                  string   `yaml:"decision" json:"decision"`
+	Rationale                 string   `yaml:"rationale" json:"rationale"`
+	AlternativesConsidered    []string `yaml:"alternatives_considered,omitempty" json:"alternatives_considered,omitempty"`
+	ConstraintsThatInfluenced string   `yaml:"constraints_that_influenced,omitempty" json:"constraints_that_influenced,omitempty"`
+	Scope                     string   `yaml:"scope,omitempty" json:"scope,omitempty"` // checkpoint|project
 }
 
 type FailedApproach struct {
-	Approach       string `yaml:"approach"`
-	WhyFailed      string `yaml:"why_failed,omitempty"`
-	LessonsLearned string `yaml:"lessons_learned,omitempty"`
-	Scope          string `yaml:"scope,omitempty"` // checkpoint|project
+	Approach       string `yaml:"approach" json:"approach"`
+	WhyFailed      string `yaml:"why_failed,omitempty" json:"why_failed,omitempty"`
+	LessonsLearned string `yaml:"lessons_learned,omitempty" json:"lessons_learned,omitempty"`
+	Scope          string `yaml:"scope,omitempty" json:"scope,omitempty"` // checkpoint|project
 }
 
 type Pattern struct {
-	Pattern   string `yaml:"pattern"`
-	Rationale string `yaml:"rationale,omitempty"`
-	Examples  string `yaml:"examples,omitempty"`
-	Scope     string `yaml:"scope"` // checkpoint|project - required if present
+	Pattern   string `yaml:"pattern" json:"pattern"`
+	Rationale string `yaml:"rationale,omitempty" json:"rationale,omitempty"`
+	Examples  string `yaml:"examples,omitempty" json:"examples,omitempty"`
+	Scope     string `yaml:"scope" json:"scope"` // checkpoint|project - required if present
 }
 
 type ConversationItem struct {
-	Exchange string `yaml:"exchange"`
-	Outcome  string `yaml:"outcome,omitempty"`
+	Exchange string `yaml:"exchange" json:"exchange"`
+	Outcome  string `yaml:"outcome,omitempty" json:"outcome,omitempty"`
+}
+
+// ExternalContext is reasoning attached from a file such as a design doc or
+// chat transcript (commit --context-from)
+type ExternalContext struct {
+	Source  string `yaml:"source" json:"source"`
+	Content string `yaml:"content" json:"content"`
 }
 
 // AppendContextEntry appends a context entry to the context file
@@ -81,6 +90,35 @@ func AppendContextEntry(contextPath string, entry *ContextEntry) error {
 	return nil
 }
 
+// RemoveContextEntry removes the last context entry recorded with timestamp,
+// reporting whether one was found. A missing file is not an error.
+func RemoveContextEntry(contextPath, timestamp string) (bool, error) {
+	data, err := os.ReadFile(contextPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("read context file: %w", err)
+	}
+
+	docs, _ := splitContextDocuments(string(data))
+	for i := len(docs) - 1; i >= 0; i-- {
+		var entry ContextEntry
+		if err := yaml.Unmarshal([]byte(docs[i]), &entry); err != nil || entry.Timestamp != timestamp {
+			continue
+		}
+		docs = append(docs[:i], docs[i+1:]...)
+		if len(docs) == 0 && !strings.Contains(string(data), retentionNotePrefix) {
+			if err := os.Remove(contextPath); err != nil {
+				return false, fmt.Errorf("remove context file: %w", err)
+			}
+			return true, nil
+		}
+		return true, writeContextDocuments(contextPath, string(data), docs)
+	}
+	return false, nil
+}
+
 // GetRecentContextEntries reads the last N context entries from the file
 func GetRecentContextEntries(contextPath string, count int) ([]ContextEntry, error) {
 	f, err := os.Open(contextPath)
@@ -3,7 +3,9 @@ package context
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestGetRecentContextEntries(t *testing.T) {
@@ -115,3 +117,197 @@ func TestCreateContextEntry(t *testing.T) {
 		t.Errorf("expected problem_statement 'Test problem', got %q", entry.Context.ProblemStatement)
 	}
 }
+
+func TestEnforceRetention(t *testing.T) {
+	tmpDir := t.TempDir()
+	contextPath := filepath.Join(tmpDir, "context.yml")
+
+	content := `---
+schema_version: "1"
+timestamp: "2025-01-01T00:00:00Z"
+context:
+  problem_statement: "Oldest, checkpoint only"
+---
+schema_version: "1"
+timestamp: "2025-01-01T12:00:00Z"
+context:
+  external_context:
+    - source: "notes/old-design.md"
+      content: "External only"
+---
+schema_version: "1"
+timestamp: "2025-01-02T00:00:00Z"
+context:
+  problem_statement: "Old with project pattern"
+  established_patterns:
+    - pattern: "Table-driven tests"
+      scope: project
+    - pattern: "One-off helper"
+      scope: checkpoint
+  external_context:
+    - source: "notes/design.md"
+      content: "Design notes"
+---
+schema_version: "1"
+timestamp: "2025-01-03T00:00:00Z"
+context:
+  problem_statement: "Recent"
+`
+	if err := os.WriteFile(contextPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	now := time.Date(2025, 1, 4, 0, 0, 0, 0, time.UTC)
+	if err := EnforceRetention(contextPath, RetentionPolicy{MaxEntries: 1}, now); err != nil {
+		t.Fatalf("EnforceRetention error: %v", err)
+	}
+
+	entries, err := GetRecentContextEntries(contextPath, 10)
+	if err != nil {
+		t.Fatalf("GetRecentContextEntries error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries after pruning, got %d", len(entries))
+	}
+	trimmed := entries[0].Context
+	if trimmed.ProblemStatement != "" || len(trimmed.ExternalContext) != 0 || len(trimmed.EstablishedPatterns) != 1 || trimmed.EstablishedPatterns[0].Pattern != "Table-driven tests" {
+		t.Errorf("expected only project-scoped pattern to remain, got %+v", trimmed)
+	}
+	if entries[1].Context.ProblemStatement != "Recent" {
+		t.Errorf("recent entry should be untouched, got %+v", entries[1].Context)
+	}
+
+	data, _ := os.ReadFile(contextPath)
+	if !strings.HasPrefix(string(data), "# retention: 2 checkpoint-scoped entries pruned") {
+		t.Errorf("expected retention note, got:\n%s", string(data))
+	}
+
+	// Unlimited policy leaves the file alone
+	before, _ := os.ReadFile(contextPath)
+	if err := EnforceRetention(contextPath, RetentionPolicy{}, now); err != nil {
+		t.Fatalf("EnforceRetention unlimited error: %v", err)
+	}
+	after, _ := os.ReadFile(contextPath)
+	if string(before) != string(after) {
+		t.Errorf("unlimited policy modified the file")
+	}
+}
+
+func TestPromoteToProjectScope(t *testing.T) {
+	tmpDir := t.TempDir()
+	contextPath := filepath.Join(tmpDir, "context.yml")
+
+	content := `# retention: 2 checkpoint-scoped entries pruned (last 2025-01-01)
+---
+schema_version: "1"
+timestamp: "2025-01-02T00:00:00Z"
+context:
+  problem_statement: "Older"
+  key_insights:
+    - insight: "Cache parsed config per run"
+      impact: "Avoids repeated disk reads"
+      scope: checkpoint
+---
+schema_version: "1"
+timestamp: "2025-01-03T00:00:00Z"
+context:
+  problem_statement: "Newer"
+  key_insights:
+    - insight: "Cache parsed config per run in memory"
+      scope: checkpoint
+  established_patterns:
+    - pattern: "Atomic writes via temp file and rename"
+      rationale: "Prevents partial files"
+      scope: checkpoint
+`
+	if err := os.WriteFile(contextPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	// Exact match beats the newer substring match
+	item, err := PromoteToProjectScope(contextPath, "cache parsed config per run")
+	if err != nil {
+		t.Fatalf("PromoteToProjectScope error: %v", err)
+	}
+	if item == nil || item.Kind != "insight" || item.Text != "Cache parsed config per run" || item.Rationale != "Avoids repeated disk reads" {
+		t.Fatalf("unexpected promoted item: %+v", item)
+	}
+	if item.Timestamp != "2025-01-02T00:00:00Z" {
+		t.Errorf("expected older entry timestamp, got %s", item.Timestamp)
+	}
+
+	item, err = PromoteToProjectScope(contextPath, "atomic writes")
+	if err != nil || item == nil || item.Kind != "pattern" {
+		t.Fatalf("expected pattern promotion, got %+v (err %v)", item, err)
+	}
+
+	entries, err := GetRecentContextEntries(contextPath, 10)
+	if err != nil {
+		t.Fatalf("GetRecentContextEntries error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].Context.KeyInsights[0].Scope != "project" {
+		t.Errorf("expected promoted insight to be project-scoped")
+	}
+	if entries[1].Context.KeyInsights[0].Scope != "checkpoint" {
+		t.Errorf("non-matching insight should keep its scope")
+	}
+	if entries[1].Context.EstablishedPatterns[0].Scope != "project" {
+		t.Errorf("expected promoted pattern to be project-scoped")
+	}
+
+	data, _ := os.ReadFile(contextPath)
+	if !strings.HasPrefix(string(data), "# retention: 2 checkpoint-scoped entries pruned") {
+		t.Errorf("expected retention note to be kept, got:\n%s", string(data))
+	}
+
+	// Already project-scoped items are not matched again
+	item, err = PromoteToProjectScope(contextPath, "atomic writes")
+	if err != nil || item != nil {
+		t.Errorf("expected no match for already promoted item, got %+v (err %v)", item, err)
+	}
+}
+
+func TestRemoveContextEntry(t *testing.T) {
+	contextPath := filepath.Join(t.TempDir(), ".checkpoint-context.yaml")
+	for _, ts := range []string{"2025-01-01T00:00:00Z", "2025-01-02T00:00:00Z"} {
+		entry := CreateContextEntry(ts, CheckpointContext{ProblemStatement: "problem " + ts})
+		if err := AppendContextEntry(contextPath, entry); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+
+	removed, err := RemoveContextEntry(contextPath, "2025-01-02T00:00:00Z")
+	if err != nil || !removed {
+		t.Fatalf("RemoveContextEntry = %v, %v", removed, err)
+	}
+	entries, err := GetRecentContextEntries(contextPath, 10)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Timestamp != "2025-01-01T00:00:00Z" {
+		t.Errorf("unexpected entries after removal: %+v", entries)
+	}
+
+	if removed, err := RemoveContextEntry(contextPath, "2030-01-01T00:00:00Z"); err != nil || removed {
+		t.Errorf("expected no match, got %v, %v", removed, err)
+	}
+	if removed, err := RemoveContextEntry(filepath.Join(t.TempDir(), "missing.yaml"), "x"); err != nil || removed {
+		t.Errorf("expected missing file to be a no-op, got %v, %v", removed, err)
+	}
+}
+
+func TestRemoveContextEntryLast(t *testing.T) {
+	contextPath := filepath.Join(t.TempDir(), ".checkpoint-context.yaml")
+	if err := AppendContextEntry(contextPath, CreateContextEntry("2025-01-01T00:00:00Z", CheckpointContext{ProblemStatement: "only"})); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if removed, err := RemoveContextEntry(contextPath, "2025-01-01T00:00:00Z"); err != nil || !removed {
+		t.Fatalf("RemoveContextEntry = %v, %v", removed, err)
+	}
+	if _, err := os.Stat(contextPath); !os.IsNotExist(err) {
+		t.Errorf("expected empty context file removed, got %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package context
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PromotedItem describes a context item whose scope was raised to project
+type PromotedItem struct {
+	Kind      string // insight, decision, failed_approach, pattern
+	Text      string
+	Rationale string
+	Examples  string
+	Timestamp string // timestamp of the context entry holding the item
+}
+
+// PromoteToProjectScope finds a checkpoint-scoped item matching query, marks it
+// scope: project, and rewrites the context file. An exact (case-insensitive)
+// match wins over a substring match; among equal matches the newest entry wins.
+// Returns nil when nothing matches.
+func PromoteToProjectScope(contextPath, query string) (*PromotedItem, error) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil, fmt.Errorf("empty query")
+	}
+
+	data, err := os.ReadFile(contextPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read context file: %w", err)
+	}
+
+	docs, _ := splitContextDocuments(string(data))
+	entries := make([]*ContextEntry, len(docs))
+	for i, doc := range docs {
+		var e ContextEntry
+		if err := yaml.Unmarshal([]byte(doc), &e); err == nil && e.Timestamp != "" {
+			entries[i] = &e
+		}
+	}
+
+	for _, exact := range []bool{true, false} {
+		match := func(text string) bool {
+			text = strings.ToLower(strings.TrimSpace(text))
+			if exact {
+				return text == query
+			}
+			return strings.Contains(text, query)
+		}
+		for i := len(entries) - 1; i >= 0; i-- {
+			if entries[i] == nil {
+				continue
+			}
+			item := promoteInContext(&entries[i].Context, match)
+			if item == nil {
+				continue
+			}
+			item.Timestamp = entries[i].Timestamp
+			b, err := yaml.Marshal(entries[i])
+			if err != nil {
+				return nil, fmt.Errorf("marshal context entry: %w", err)
+			}
+			docs[i] = strings.TrimRight(string(b), "\n")
+			if err := writeContextDocuments(contextPath, string(data), docs); err != nil {
+				return nil, err
+			}
+			return item, nil
+		}
+	}
+	return nil, nil
+}
+
+// promoteInContext sets scope: project on the first checkpoint-scoped item accepted by match
+func promoteInContext(ctx *CheckpointContext, match func(string) bool) *PromotedItem {
+	for i := range ctx.KeyInsights {
+		it := &ctx.KeyInsights[i]
+		if it.Scope != "project" && match(it.Insight) {
+			it.Scope = "project"
+			return &PromotedItem{Kind: "insight", Text: it.Insight, Rationale: it.Impact}
+		}
+	}
+	for i := range ctx.DecisionsMade {
+		d := &ctx.DecisionsMade[i]
+		if d.Scope != "project" && match(d.Decision) {
+			d.Scope = "project"
+			return &PromotedItem{Kind: "decision", Text: d.Decision, Rationale: d.Rationale}
+		}
+	}
+	for i := range ctx.FailedApproaches {
+		f := &ctx.FailedApproaches[i]
+		if f.Scope != "project" && match(f.Approach) {
+			f.Scope = "project"
+			return &PromotedItem{Kind: "failed_approach", Text: f.Approach, Rationale: f.LessonsLearned}
+		}
+	}
+	for i := range ctx.EstablishedPatterns {
+		p := &ctx.EstablishedPatterns[i]
+		if p.Scope != "project" && match(p.Pattern) {
+			p.Scope = "project"
+			return &PromotedItem{Kind: "pattern", Text: p.Pattern, Rationale: p.Rationale, Examples: p.Examples}
+		}
+	}
+	return nil
+}
+
+// writeContextDocuments atomically rewrites the context file, keeping any
+// retention note from the original content
+func writeContextDocuments(contextPath, original string, docs []string) error {
+	var sb strings.Builder
+	for _, line := range strings.Split(original, "\n") {
+		if strings.HasPrefix(line, retentionNotePrefix) {
+			sb.WriteString(line + "\n")
+			break
+		}
+	}
+	for _, doc := range docs {
+		sb.WriteString("---\n")
+		sb.WriteString(doc)
+		sb.WriteString("\n")
+	}
+
+	tmpPath := contextPath + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("write context file: %w", err)
+	}
+	if err := os.Rename(tmpPath, contextPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("replace context file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package context
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RetentionPolicy bounds the size of the context file. Zero values mean
+// unlimited, which is the default and preserves append-only behavior.
+type RetentionPolicy struct {
+	MaxEntries int `yaml:"max_entries,omitempty" json:"max_entries,omitempty"`
+	MaxAgeDays int `yaml:"max_age_days,omitempty" json:"max_age_days,omitempty"`
+}
+
+// Unlimited reports whether the policy never prunes
+func (p RetentionPolicy) Unlimited() bool {
+	return p.MaxEntries <= 0 && p.MaxAgeDays <= 0
+}
+
+const retentionNotePrefix = "# retention:"
+
+// AppendContextEntryWithRetention appends an entry, then lazily enforces the policy
+func AppendContextEntryWithRetention(contextPath string, entry *ContextEntry, policy RetentionPolicy) error {
+	if err := AppendContextEntry(contextPath, entry); err != nil {
+		return err
+	}
+	if policy.Unlimited() {
+		return nil
+	}
+	return EnforceRetention(contextPath, policy, time.Now())
+}
+
+// EnforceRetention drops checkpoint-scoped items from entries that exceed the
+// policy, keeping project-scoped items. Entries left empty are removed and a
+// one-line note at the top of the file records how many were pruned.
+func EnforceRetention(contextPath string, policy RetentionPolicy, now time.Time) error {
+	if policy.Unlimited() {
+		return nil
+	}
+
+	data, err := os.ReadFile(contextPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("read context file: %w", err)
+	}
+
+	docs, prunedBefore := splitContextDocuments(string(data))
+
+	// Decide which entries fall outside the policy (docs are oldest first)
+	expired := make([]bool, len(docs))
+	entries := make([]*ContextEntry, len(docs))
+	for i, doc := range docs {
+		var e ContextEntry
+		if err := yaml.Unmarshal([]byte(doc), &e); err != nil || e.Timestamp == "" {
+			continue // keep anything we don't understand verbatim
+		}
+		entries[i] = &e
+		if policy.MaxEntries > 0 && i < len(docs)-policy.MaxEntries {
+			expired[i] = true
+		}
+		if policy.MaxAgeDays > 0 {
+			if ts, err := time.Parse(time.RFC3339, e.Timestamp); err == nil && now.Sub(ts) > time.Duration(policy.MaxAgeDays)*24*time.Hour {
+				expired[i] = true
+			}
+		}
+	}
+
+	pruned := 0
+	changed := false
+	var out []string
+	for i, doc := range docs {
+		if !expired[i] {
+			out = append(out, doc)
+			continue
+		}
+		kept, removed := projectScopedOnly(entries[i].Context)
+		if !removed {
+			out = append(out, doc)
+			continue
+		}
+		changed = true
+		if isEmptyContext(kept) {
+			pruned++
+			continue
+		}
+		entries[i].Context = kept
+		b, err := yaml.Marshal(entries[i])
+		if err != nil {
+			return fmt.Errorf("marshal context entry: %w", err)
+		}
+		out = append(out, strings.TrimRight(string(b), "\n"))
+	}
+
+	if !changed {
+		return nil
+	}
+
+	var sb strings.Builder
+	if total := prunedBefore + pruned; total > 0 {
+		sb.WriteString(fmt.Sprintf("%s %d checkpoint-scoped entries pruned (last %s)\n", retentionNotePrefix, total, now.Format("2006-01-02")))
+	}
+	for _, doc := range out {
+		sb.WriteString("---\n")
+		sb.WriteString(doc)
+		sb.WriteString("\n")
+	}
+
+	tmpPath := contextPath + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("write context file: %w", err)
+	}
+	if err := os.Rename(tmpPath, contextPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("replace context file: %w", err)
+	}
+	return nil
+}
+
+// splitContextDocuments splits on '---' lines and extracts the pruned count from a retention note
+func splitContextDocuments(content string) ([]string, int) {
+	var docs []string
+	var current []string
+	pruned := 0
+
+	flush := func() {
+		doc := strings.TrimSpace(strings.Join(current, "\n"))
+		if doc != "" {
+			docs = append(docs, doc)
+		}
+		current = nil
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, retentionNotePrefix) {
+			_, _ = fmt.Sscanf(strings.TrimPrefix(line, retentionNotePrefix), "%d", &pruned)
+			continue
+		}
+		if strings.TrimRight(line, " ") == "---" {
+			flush()
+			continue
+		}
+		current = append(current, line)
+	}
+	flush()
+	return docs, pruned
+}
+
+// projectScopedOnly returns the context with checkpoint-scoped items removed
+// and whether anything was removed. The problem statement, conversation and
+// external context have no scope and always belong to the checkpoint.
+func projectScopedOnly(ctx CheckpointContext) (CheckpointContext, bool) {
+	var kept CheckpointContext
+	removed := ctx.ProblemStatement != "" || len(ctx.ConversationContext) > 0 || len(ctx.ExternalContext) > 0
+
+	for _, i := range ctx.KeyInsights {
+		if i.Scope == "project" {
+			kept.KeyInsights = append(kept.KeyInsights, i)
+		} else {
+			removed = true
+		}
+	}
+	for _, d := range ctx.DecisionsMade {
+		if d.Scope == "project" {
+			kept.DecisionsMade = append(kept.DecisionsMade, d)
+		} else {
+			removed = true
+		}
+	}
+	for _, f := range ctx.FailedApproaches {
+		if f.Scope == "project" {
+			kept.FailedApproaches = append(kept.FailedApproaches, f)
+		} else {
+			removed = true
+		}
+	}
+	for _, p := range ctx.EstablishedPatterns {
+		if p.Scope == "project" {
+			kept.EstablishedPatterns = append(kept.EstablishedPatterns, p)
+		} else {
+			removed = true
+		}
+	}
+	return kept, removed
+}
+
+func isEmptyContext(ctx CheckpointContext) bool {
+	return ctx.ProblemStatement == "" &&
+		len(ctx.KeyInsights) == 0 &&
+		len(ctx.DecisionsMade) == 0 &&
+		len(ctx.FailedApproaches) == 0 &&
+		len(ctx.EstablishedPatterns) == 0 &&
+		len(ctx.ConversationContext) == 0 &&
+		len(ctx.ExternalContext) == 0
+}
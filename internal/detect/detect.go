@@ -11,19 +11,25 @@ import (
 
 // ProjectInfo holds auto-detected project information
 type ProjectInfo struct {
-	Name        string
-	Language    string
-	Languages   []string // Additional languages detected
-	BuildCmd    string
-	TestCmd     string
-	LintCmd     string
-	FormatCmd   string
-	DevCmd      string
-	CleanCmd    string
-	Description string
-	Frameworks  []string
-	HasGit      bool
-	GitRemote   string
+	Name        string   `json:"name"`
+	Language    string   `json:"language"`
+	Languages   []string `json:"languages,omitempty"` // Additional languages detected
+	BuildCmd    string   `json:"build_cmd,omitempty"`
+	TestCmd     string   `json:"test_cmd,omitempty"`
+	LintCmd     string   `json:"lint_cmd,omitempty"`
+	FormatCmd   string   `json:"format_cmd,omitempty"`
+	DevCmd      string   `json:"dev_cmd,omitempty"`
+	CleanCmd    string   `json:"clean_cmd,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Frameworks  []string `json:"frameworks,omitempty"`
+	HasGit      bool     `json:"has_git"`
+	GitRemote   string   `json:"git_remote,omitempty"`
+
+	// GoWorkspaceModules lists the module directories from go.work, when present
+	GoWorkspaceModules []string `json:"go_workspace_modules,omitempty"`
+
+	// Workspaces lists subdirectories (up to two levels deep) with their own build manifest
+	Workspaces []string `json:"workspaces,omitempty"`
 }
 
 // DetectProject analyzes a project directory and returns detected info
@@ -36,12 +42,15 @@ func DetectProject(projectPath string) *ProjectInfo {
 	// Check for git
 	if _, err := os.Stat(filepath.Join(projectPath, ".git")); err == nil {
 		info.HasGit = true
-		info.GitRemote = detectGitRemote(projectPath)
+		info.GitRemote = DetectGitRemote(projectPath)
 	}
 
 	// Detect language and package info
 	detectLanguage(projectPath, info)
 
+	// Detect sub-projects in monorepos
+	info.Workspaces = detectWorkspaces(projectPath)
+
 	// Detect commands from Makefile
 	detectMakefileCommands(projectPath, info)
 
@@ -54,7 +63,8 @@ func DetectProject(projectPath string) *ProjectInfo {
 	return info
 }
 
-func detectGitRemote(projectPath string) string {
+// DetectGitRemote returns the origin URL from .git/config, or "" if unset
+func DetectGitRemote(projectPath string) string {
 	configPath := filepath.Join(projectPath, ".git", "config")
 	data, err := os.ReadFile(configPath)
 	if err != nil {
@@ -70,9 +80,44 @@ func detectGitRemote(projectPath string) string {
 	return ""
 }
 
+// RemoteWebURL turns a git remote (https, ssh or scp-style) into the
+// repository's web URL, e.g. git@github.com:o/r.git -> https://github.com/o/r.
+// Returns "" for remotes without a host, such as local paths.
+func RemoteWebURL(remote string) string {
+	remote = strings.TrimSpace(remote)
+	remote = strings.TrimSuffix(strings.TrimSuffix(remote, "/"), ".git")
+	scheme, hostPath := "https", ""
+	switch {
+	case strings.HasPrefix(remote, "https://"), strings.HasPrefix(remote, "http://"):
+		scheme, hostPath, _ = strings.Cut(remote, "://")
+	case strings.HasPrefix(remote, "ssh://"):
+		hostPath = strings.TrimPrefix(remote, "ssh://")
+		if host, path, ok := strings.Cut(hostPath, "/"); ok {
+			// Drop an explicit ssh port, which the web host does not use
+			host, _, _ = strings.Cut(host, ":")
+			hostPath = host + "/" + path
+		}
+	case strings.Contains(remote, ":") && !strings.Contains(remote, "://"):
+		hostPath = strings.Replace(remote, ":", "/", 1)
+	default:
+		return ""
+	}
+	// Drop credentials or the ssh user
+	if at := strings.LastIndex(hostPath, "@"); at >= 0 && at < strings.Index(hostPath+"/", "/") {
+		hostPath = hostPath[at+1:]
+	}
+	host, path, ok := strings.Cut(hostPath, "/")
+	if !ok || host == "" || path == "" {
+		return ""
+	}
+	return scheme + "://" + host + "/" + path
+}
+
 func detectLanguage(projectPath string, info *ProjectInfo) {
-	// Go
-	if _, err := os.Stat(filepath.Join(projectPath, "go.mod")); err == nil {
+	// Go (a module, or a workspace of modules)
+	_, modErr := os.Stat(filepath.Join(projectPath, "go.mod"))
+	_, workErr := os.Stat(filepath.Join(projectPath, "go.work"))
+	if modErr == nil || workErr == nil {
 		info.Language = "go"
 		info.Languages = append(info.Languages, "go")
 		detectGoInfo(projectPath, info)
@@ -113,6 +158,15 @@ func detectLanguage(projectPath string, info *ProjectInfo) {
 		detectRustInfo(projectPath, info)
 	}
 
+	// Ruby (Bundler)
+	if hasRubyProject(projectPath) {
+		if info.Language == "" {
+			info.Language = "ruby"
+		}
+		info.Languages = append(info.Languages, "ruby")
+		detectRubyInfo(projectPath, info)
+	}
+
 	// Java/Kotlin (Maven)
 	if _, err := os.Stat(filepath.Join(projectPath, "pom.xml")); err == nil {
 		if info.Language == "" {
@@ -147,35 +201,50 @@ func hasPythonProject(projectPath string) bool {
 }
 
 func detectGoInfo(projectPath string, info *ProjectInfo) {
-	// Read go.mod for module name
-	data, err := os.ReadFile(filepath.Join(projectPath, "go.mod"))
-	if err != nil {
-		return
+	// Read go.mod for module name (a workspace root may not have one)
+	if data, err := os.ReadFile(filepath.Join(projectPath, "go.mod")); err == nil {
+		lines := strings.Split(string(data), "\n")
+		for _, line := range lines {
+			if strings.HasPrefix(line, "module ") {
+				moduleName := strings.TrimPrefix(line, "module ")
+				moduleName = strings.TrimSpace(moduleName)
+				// Use last part of module path as name if it looks better
+				parts := strings.Split(moduleName, "/")
+				if len(parts) > 0 {
+					lastPart := parts[len(parts)-1]
+					if lastPart != "" && lastPart != info.Name {
+						info.Name = lastPart
+					}
+				}
+				break
+			}
+		}
 	}
 
-	lines := strings.Split(string(data), "\n")
-	for _, line := range lines {
-		if strings.HasPrefix(line, "module ") {
-			moduleName := strings.TrimPrefix(line, "module ")
-			moduleName = strings.TrimSpace(moduleName)
-			// Use last part of module path as name if it looks better
-			parts := strings.Split(moduleName, "/")
-			if len(parts) > 0 {
-				lastPart := parts[len(parts)-1]
-				if lastPart != "" && lastPart != info.Name {
-					info.Name = lastPart
+	// In a workspace, ./... from the root only covers the root module (and
+	// fails without one), so commands name each member module explicitly
+	patterns := "./..."
+	if data, err := os.ReadFile(filepath.Join(projectPath, "go.work")); err == nil {
+		info.GoWorkspaceModules = parseGoWorkUse(string(data))
+		if len(info.GoWorkspaceModules) > 0 {
+			var parts []string
+			for _, dir := range info.GoWorkspaceModules {
+				if dir == "." {
+					parts = append(parts, "./...")
+				} else {
+					parts = append(parts, "./"+dir+"/...")
 				}
 			}
-			break
+			patterns = strings.Join(parts, " ")
 		}
 	}
 
 	// Default Go commands if not already set
 	if info.BuildCmd == "" {
-		info.BuildCmd = "go build ./..."
+		info.BuildCmd = "go build " + patterns
 	}
 	if info.TestCmd == "" {
-		info.TestCmd = "go test ./..."
+		info.TestCmd = "go test " + patterns
 	}
 
 	// Check for golangci-lint
@@ -186,7 +255,44 @@ func detectGoInfo(projectPath string, info *ProjectInfo) {
 	}
 
 	// Check for gofmt/goimports
-	info.FormatCmd = "go fmt ./..."
+	info.FormatCmd = "go fmt " + patterns
+}
+
+// parseGoWorkUse returns the module directories listed by use directives in
+// go.work, in both single-line and block form, cleaned and relative to the root
+func parseGoWorkUse(content string) []string {
+	var dirs []string
+	inBlock := false
+	for _, line := range strings.Split(content, "\n") {
+		if idx := strings.Index(line, "//"); idx >= 0 {
+			line = line[:idx]
+		}
+		line = strings.TrimSpace(line)
+
+		var dir string
+		switch {
+		case inBlock && line == ")":
+			inBlock = false
+			continue
+		case inBlock:
+			dir = line
+		case line == "use (" || line == "use(":
+			inBlock = true
+			continue
+		case strings.HasPrefix(line, "use "):
+			dir = strings.TrimSpace(strings.TrimPrefix(line, "use "))
+		}
+
+		dir = strings.Trim(dir, `"`)
+		if dir == "" {
+			continue
+		}
+		dir = filepath.ToSlash(filepath.Clean(dir))
+		if !contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
 }
 
 func detectNodeInfo(projectPath string, info *ProjectInfo) {
@@ -338,6 +444,89 @@ func detectRustInfo(projectPath string, info *ProjectInfo) {
 	info.FormatCmd = "cargo fmt"
 }
 
+func hasRubyProject(projectPath string) bool {
+	for _, f := range []string{"Gemfile", "Gemfile.lock"} {
+		if _, err := os.Stat(filepath.Join(projectPath, f)); err == nil {
+			return true
+		}
+	}
+	gemspecs, _ := filepath.Glob(filepath.Join(projectPath, "*.gemspec"))
+	return len(gemspecs) > 0
+}
+
+func detectRubyInfo(projectPath string, info *ProjectInfo) {
+	// Read the gemspec for the gem name
+	gemspecs, _ := filepath.Glob(filepath.Join(projectPath, "*.gemspec"))
+	if len(gemspecs) > 0 {
+		if data, err := os.ReadFile(gemspecs[0]); err == nil {
+			re := regexp.MustCompile(`\.name\s*=\s*["']([^"']+)["']`)
+			if matches := re.FindStringSubmatch(string(data)); len(matches) > 1 {
+				info.Name = matches[1]
+			}
+		}
+	}
+
+	// Default Ruby commands
+	if info.BuildCmd == "" {
+		info.BuildCmd = "bundle install"
+	}
+	if info.TestCmd == "" {
+		if hasRakeTestTask(projectPath) {
+			info.TestCmd = "rake test"
+		} else {
+			info.TestCmd = "bundle exec rspec"
+		}
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, ".rubocop.yml")); err == nil && info.LintCmd == "" {
+		info.LintCmd = "bundle exec rubocop"
+	}
+}
+
+// hasRakeTestTask reports whether the Rakefile defines a test task, either
+// directly or through Rake::TestTask (whose default name is test)
+func hasRakeTestTask(projectPath string) bool {
+	data, err := os.ReadFile(filepath.Join(projectPath, "Rakefile"))
+	if err != nil {
+		return false
+	}
+	re := regexp.MustCompile(`(?m)^\s*(?:task\s*\(?\s*(?::test\b|["']test["'])|Rake::TestTask\.new)`)
+	return re.Match(data)
+}
+
+// workspaceManifests mark a directory as a buildable sub-project
+var workspaceManifests = []string{"go.mod", "package.json", "Cargo.toml", "pyproject.toml"}
+
+// detectWorkspaces finds subdirectories up to two levels deep that contain a
+// build manifest, skipping hidden, vendor, and node_modules directories
+func detectWorkspaces(projectPath string) []string {
+	var workspaces []string
+	var scan func(rel string, depth int)
+	scan = func(rel string, depth int) {
+		entries, err := os.ReadDir(filepath.Join(projectPath, rel))
+		if err != nil {
+			return
+		}
+		for _, e := range entries {
+			name := e.Name()
+			if !e.IsDir() || strings.HasPrefix(name, ".") || name == "vendor" || name == "node_modules" {
+				continue
+			}
+			sub := filepath.Join(rel, name)
+			for _, manifest := range workspaceManifests {
+				if _, err := os.Stat(filepath.Join(projectPath, sub, manifest)); err == nil {
+					workspaces = append(workspaces, filepath.ToSlash(sub))
+					break
+				}
+			}
+			if depth < 2 {
+				scan(sub, depth+1)
+			}
+		}
+	}
+	scan("", 1)
+	return workspaces
+}
+
 func detectMakefileCommands(projectPath string, info *ProjectInfo) {
 	makefilePath := filepath.Join(projectPath, "Makefile")
 	if _, err := os.Stat(makefilePath); err != nil {
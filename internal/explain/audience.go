@@ -0,0 +1,68 @@
+package explain
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Audience values for tuning explain output
+const (
+	AudienceHuman = "human"
+	AudienceLLM   = "llm"
+)
+
+var (
+	// renderedKeyEmphasis matches the bold key the renderer puts at the start
+	// of list and map lines: "- **name**: ...", "  **key**:", "- **skill** - ..."
+	renderedKeyEmphasis = regexp.MustCompile(`^(\s*(?:- )?)\*\*([^*]+)\*\*(:| -)`)
+	// renderedFromCommit matches the "*(from abc123)*" line under a next step
+	renderedFromCommit = regexp.MustCompile(`^(\s*)\*\((from [^)]*)\)\*$`)
+)
+
+// CompactForLLM strips decorative formatting from rendered explain output:
+// blank lines, hint lines, the FLAGS footer, and the emphasis the renderer
+// adds to keys. Fenced code blocks and user text (skill bodies, globs,
+// regexes) pass through untouched; headers and list structure are kept.
+func CompactForLLM(output string) string {
+	var sb strings.Builder
+	skipSection := false
+	inFence := false
+
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			sb.WriteString(line + "\n")
+			continue
+		}
+		if inFence {
+			sb.WriteString(line + "\n")
+			continue
+		}
+
+		// Drop indented body of a skipped section until the next header
+		if skipSection {
+			if trimmed == "" || strings.HasPrefix(line, " ") {
+				continue
+			}
+			skipSection = false
+		}
+
+		switch {
+		case trimmed == "":
+			continue
+		case strings.HasPrefix(strings.ToLower(trimmed), "hint:"):
+			continue
+		case trimmed == "FLAGS:":
+			skipSection = true
+			continue
+		}
+
+		line = renderedKeyEmphasis.ReplaceAllString(line, "$1$2$3")
+		line = renderedFromCommit.ReplaceAllString(line, "$1($2)")
+		sb.WriteString(strings.TrimRight(line, " "))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
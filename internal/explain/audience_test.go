@@ -0,0 +1,55 @@
+package explain
+
+import "testing"
+
+func TestCompactForLLM(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "drops blank lines and hints",
+			in:   "# Project\n\nName: demo\n\nhint: run checkpoint init\n",
+			want: "# Project\nName: demo\n",
+		},
+		{
+			name: "drops FLAGS footer",
+			in:   "# Tools\nbuild: make\n\nFLAGS:\n  --full  show all\n  --json  as JSON\n# Next\n",
+			want: "# Tools\nbuild: make\n# Next\n",
+		},
+		{
+			name: "strips rendered key emphasis",
+			in:   "- **cmd/**: commands\n  **build**: make build\n- **git** - `checkpoint explain skill git`\n",
+			want: "- cmd/: commands\n  build: make build\n- git - `checkpoint explain skill git`\n",
+		},
+		{
+			name: "strips from-commit emphasis",
+			in:   "- Write tests\n  *(from abc12345)*\n",
+			want: "- Write tests\n  (from abc12345)\n",
+		},
+		{
+			name: "keeps globs in values",
+			in:   "- **tests**: cmd/**/*_test.go\n",
+			want: "- tests: cmd/**/*_test.go\n",
+		},
+		{
+			name: "keeps regex in user text",
+			in:   "- Match (a|b)* before **bold** words\n",
+			want: "- Match (a|b)* before **bold** words\n",
+		},
+		{
+			name: "passes fenced blocks through",
+			in:   "# Skill\n\n```go\nfunc main() {\n\n\t// **not** emphasis\n}\n```\n\nhint: done\n",
+			want: "# Skill\n```go\nfunc main() {\n\n\t// **not** emphasis\n}\n```\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CompactForLLM(tt.in); got != tt.want {
+				t.Errorf("CompactForLLM() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
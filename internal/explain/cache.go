@@ -0,0 +1,104 @@
+package explain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dmoose/checkpoint/pkg/config"
+)
+
+// SourceFingerprint hashes the path, size and mtime of every file explain
+// output is rendered from: config files, learnings, local and global skills,
+// the changelog and the context log. Any edit changes the fingerprint.
+func SourceFingerprint(projectPath string) string {
+	checkpointDir := filepath.Join(projectPath, config.CheckpointDir)
+	paths := []string{
+		filepath.Join(projectPath, config.ChangelogFileName),
+		filepath.Join(projectPath, config.ContextFileName),
+		filepath.Join(projectPath, config.ContextFileNameLegacy),
+		filepath.Join(checkpointDir, "learnings.yaml"),
+		filepath.Join(checkpointDir, "learnings.yml"),
+	}
+	for _, pair := range [][2]string{
+		{config.ExplainProjectYaml, config.ExplainProjectYmlLegacy},
+		{config.ExplainToolsYaml, config.ExplainToolsYmlLegacy},
+		{config.ExplainGuidelinesYaml, config.ExplainGuidelinesYmlLegacy},
+		{config.ExplainSkillsYaml, config.ExplainSkillsYmlLegacy},
+	} {
+		paths = append(paths, filepath.Join(checkpointDir, pair[0]), filepath.Join(checkpointDir, pair[1]))
+	}
+
+	skillDirs := []string{filepath.Join(checkpointDir, config.SkillsDir)}
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		skillDirs = append(skillDirs, filepath.Join(homeDir, config.GlobalConfigDir, config.GlobalSkillsDir))
+	}
+	for _, dir := range skillDirs {
+		_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err == nil && !d.IsDir() {
+				paths = append(paths, path)
+			}
+			return nil
+		})
+	}
+	sort.Strings(paths)
+
+	h := sha256.New()
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			continue
+		}
+		_, _ = fmt.Fprintf(h, "%s\x00%d\x00%d\n", p, info.Size(), info.ModTime().UnixNano())
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cachePath returns the cache file for one render variant (topic, flags, audience)
+func cachePath(projectPath, variant string) string {
+	sum := sha256.Sum256([]byte(variant))
+	return filepath.Join(projectPath, config.CheckpointDir, config.ExplainCacheDir, hex.EncodeToString(sum[:8]))
+}
+
+// ReadCachedRender returns the cached output for variant if it was rendered
+// from sources matching fingerprint
+func ReadCachedRender(projectPath, variant, fingerprint string) (string, bool) {
+	data, err := os.ReadFile(cachePath(projectPath, variant))
+	if err != nil {
+		return "", false
+	}
+	header, body, ok := strings.Cut(string(data), "\n")
+	if !ok || header != fingerprint {
+		return "", false
+	}
+	return body, true
+}
+
+// WriteCachedRender stores output for variant, replacing any stale render
+func WriteCachedRender(projectPath, variant, fingerprint, output string) error {
+	path := cachePath(projectPath, variant)
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("create cache dir: %w", err)
+	}
+	// The cache is local scratch data; keep it out of 'git add -A'
+	ignorePath := filepath.Join(dir, ".gitignore")
+	if _, err := os.Stat(ignorePath); err != nil {
+		_ = os.WriteFile(ignorePath, []byte("*\n"), 0644)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(fingerprint+"\n"+output), 0644); err != nil {
+		return fmt.Errorf("write cache: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("replace cache: %w", err)
+	}
+	return nil
+}
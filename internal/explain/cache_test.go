@@ -0,0 +1,112 @@
+package explain
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dmoose/checkpoint/pkg/config"
+)
+
+// writeSkillsProject creates a project with n local skills of size bytes each
+func writeSkillsProject(tb testing.TB, n, size int) string {
+	tb.Helper()
+	dir := tb.TempDir()
+	checkpointDir := filepath.Join(dir, config.CheckpointDir)
+
+	var names []string
+	body := strings.Repeat("Use the helper before touching the parser.\n", size/43+1)
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("skill-%03d", i)
+		names = append(names, "  - "+name)
+		skillDir := filepath.Join(checkpointDir, config.SkillsDir, name)
+		if err := os.MkdirAll(skillDir, 0755); err != nil {
+			tb.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(skillDir, "skill.md"), []byte("# "+name+"\n\n"+body), 0644); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	skills := "local:\n" + strings.Join(names, "\n") + "\n"
+	if err := os.WriteFile(filepath.Join(checkpointDir, config.ExplainSkillsYaml), []byte(skills), 0644); err != nil {
+		tb.Fatal(err)
+	}
+	project := "name: bench\npurpose: Benchmark explain caching\n"
+	if err := os.WriteFile(filepath.Join(checkpointDir, config.ExplainProjectYaml), []byte(project), 0644); err != nil {
+		tb.Fatal(err)
+	}
+	return dir
+}
+
+func TestCachedRenderInvalidation(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := writeSkillsProject(t, 2, 100)
+	variant := "topic= full=true"
+
+	fp := SourceFingerprint(dir)
+	if _, ok := ReadCachedRender(dir, variant, fp); ok {
+		t.Fatal("unexpected cache hit before write")
+	}
+	if err := WriteCachedRender(dir, variant, fp, "rendered\n"); err != nil {
+		t.Fatalf("WriteCachedRender: %v", err)
+	}
+	if got, ok := ReadCachedRender(dir, variant, SourceFingerprint(dir)); !ok || got != "rendered\n" {
+		t.Fatalf("ReadCachedRender = %q, %v; want hit", got, ok)
+	}
+	if _, ok := ReadCachedRender(dir, "topic=tools", fp); ok {
+		t.Error("cache hit for a different variant")
+	}
+
+	// Touching a skill invalidates the cache
+	skillPath := filepath.Join(dir, config.CheckpointDir, config.SkillsDir, "skill-001", "skill.md")
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(skillPath, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := ReadCachedRender(dir, variant, SourceFingerprint(dir)); ok {
+		t.Error("cache hit after a skill changed")
+	}
+
+	// Adding a changelog invalidates the cache
+	fp = SourceFingerprint(dir)
+	if err := os.WriteFile(filepath.Join(dir, config.ChangelogFileName), []byte("---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if SourceFingerprint(dir) == fp {
+		t.Error("fingerprint unchanged after changelog was created")
+	}
+}
+
+func BenchmarkExplainFull(b *testing.B) {
+	b.Setenv("HOME", b.TempDir())
+	dir := writeSkillsProject(b, 50, 64*1024)
+
+	b.Run("render", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ctx, err := LoadExplainContext(dir)
+			if err != nil {
+				b.Fatal(err)
+			}
+			_ = CompactForLLM(ctx.RenderFull())
+		}
+	})
+
+	ctx, err := LoadExplainContext(dir)
+	if err != nil {
+		b.Fatal(err)
+	}
+	variant := "topic= full=true"
+	if err := WriteCachedRender(dir, variant, SourceFingerprint(dir), CompactForLLM(ctx.RenderFull())); err != nil {
+		b.Fatal(err)
+	}
+	b.Run("cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, ok := ReadCachedRender(dir, variant, SourceFingerprint(dir)); !ok {
+				b.Fatal("cache miss")
+			}
+		}
+	})
+}
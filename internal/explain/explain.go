@@ -3,7 +3,9 @@ package explain
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/dmoose/checkpoint/pkg/config"
@@ -101,12 +103,104 @@ func loadLearnings(data []byte) []Learning {
 			break
 		}
 		if l.Learning != "" {
+			l.Tags = NormalizeTags(l.Tags)
 			learnings = append(learnings, l)
 		}
 	}
 	return learnings
 }
 
+// NormalizeTags trims tags, splits comma-joined ones, and drops empty and
+// repeated (case-insensitive) tags, keeping the first spelling
+func NormalizeTags(tags []string) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, t := range tags {
+		for _, part := range strings.Split(t, ",") {
+			part = strings.TrimSpace(part)
+			key := strings.ToLower(part)
+			if part == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// HasTag reports whether the learning carries tag (case-insensitive)
+func (l Learning) HasTag(tag string) bool {
+	for _, t := range l.Tags {
+		if strings.EqualFold(t, strings.TrimSpace(tag)) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterLearnings keeps only learnings tagged with tag
+func (e *ExplainOutput) FilterLearnings(tag string) {
+	if tag == "" {
+		return
+	}
+	var kept []Learning
+	for _, l := range e.Learnings {
+		if l.HasTag(tag) {
+			kept = append(kept, l)
+		}
+	}
+	e.Learnings = kept
+}
+
+// FilterProjectPaths limits the architecture's key paths and key files to
+// those matching pattern (a path prefix, or a glob when it contains * ? or [)
+// and, with untrackedOnly, key files not marked tracked. The loaded config
+// is left unmodified.
+func (e *ExplainOutput) FilterProjectPaths(pattern string, untrackedOnly bool) {
+	if e.Project == nil || (pattern == "" && !untrackedOnly) {
+		return
+	}
+	filtered := *e.Project
+	arch := filtered.Architecture
+
+	if pattern != "" {
+		keyPaths := map[string]string{}
+		for name, p := range arch.KeyPaths {
+			if matchProjectPath(pattern, p) {
+				keyPaths[name] = p
+			}
+		}
+		arch.KeyPaths = keyPaths
+	}
+	var keyFiles []KeyFileConfig
+	for _, kf := range arch.KeyFiles {
+		if (pattern == "" || matchProjectPath(pattern, kf.Path)) && (!untrackedOnly || !kf.Tracked) {
+			keyFiles = append(keyFiles, kf)
+		}
+	}
+	arch.KeyFiles = keyFiles
+
+	filtered.Architecture = arch
+	e.Project = &filtered
+}
+
+// matchProjectPath reports whether p starts with the prefix pattern or, for
+// glob patterns, matches it (directory entries like "cmd/" match "cmd/*")
+func matchProjectPath(pattern, p string) bool {
+	pattern = strings.TrimPrefix(pattern, "./")
+	p = strings.TrimPrefix(p, "./")
+	if !strings.ContainsAny(pattern, "*?[") {
+		return strings.HasPrefix(p, pattern)
+	}
+	for _, candidate := range []string{p, strings.TrimSuffix(p, "/")} {
+		if ok, _ := path.Match(pattern, candidate); ok {
+			return true
+		}
+	}
+	return false
+}
+
 // loadSkills loads skill.md files from local and global skills directories
 func loadSkills(projectPath string, skillsConfig *SkillsConfig) []Skill {
 	var skills []Skill
@@ -195,8 +289,8 @@ func (e *ExplainOutput) RenderSummary() string {
 	// Key paths
 	if e.Project != nil && len(e.Project.Architecture.KeyPaths) > 0 {
 		sb.WriteString("KEY PATHS:\n")
-		for name, path := range e.Project.Architecture.KeyPaths {
-			sb.WriteString(fmt.Sprintf("  %s: %s\n", name, path))
+		for _, name := range sortedKeys(e.Project.Architecture.KeyPaths) {
+			sb.WriteString(fmt.Sprintf("  %s: %s\n", name, e.Project.Architecture.KeyPaths[name]))
 		}
 		sb.WriteString("\n")
 	}
@@ -220,7 +314,7 @@ func (e *ExplainOutput) RenderSummary() string {
 				sb.WriteString(fmt.Sprintf("  ... and %d more (see: checkpoint explain guidelines)\n", len(e.Guidelines.Rules)-3))
 				break
 			}
-			sb.WriteString(fmt.Sprintf("  - %s\n", rule))
+			sb.WriteString(fmt.Sprintf("  - %s\n", rule.Label()))
 		}
 		sb.WriteString("\n")
 	}
@@ -284,8 +378,8 @@ func (e *ExplainOutput) RenderProject() string {
 
 	if len(e.Project.Architecture.KeyPaths) > 0 {
 		sb.WriteString("\n### Key Paths\n\n")
-		for name, path := range e.Project.Architecture.KeyPaths {
-			sb.WriteString(fmt.Sprintf("- **%s**: %s\n", name, path))
+		for _, name := range sortedKeys(e.Project.Architecture.KeyPaths) {
+			sb.WriteString(fmt.Sprintf("- **%s**: %s\n", name, e.Project.Architecture.KeyPaths[name]))
 		}
 	}
 
@@ -336,6 +430,57 @@ func (e *ExplainOutput) RenderProject() string {
 	return sb.String()
 }
 
+// RenderProjectMermaid returns a Mermaid flowchart of the project: key paths
+// as components and integrations as external systems, with the interaction
+// text as edge labels. A missing or empty project yields a single-node chart.
+func (e *ExplainOutput) RenderProjectMermaid() string {
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+
+	name := "project"
+	if e.Project != nil && strings.TrimSpace(e.Project.Name) != "" {
+		name = e.Project.Name
+	}
+	sb.WriteString(fmt.Sprintf("    project[\"%s\"]\n", mermaidLabel(name)))
+	if e.Project == nil {
+		return sb.String()
+	}
+
+	for i, key := range sortedKeys(e.Project.Architecture.KeyPaths) {
+		label := key
+		if path := e.Project.Architecture.KeyPaths[key]; path != "" {
+			label += "<br/>" + path
+		}
+		sb.WriteString(fmt.Sprintf("    path%d[\"%s\"]\n", i, mermaidLabel(label)))
+		sb.WriteString(fmt.Sprintf("    project --> path%d\n", i))
+	}
+
+	for i, integ := range e.Project.Integrations {
+		label := integ.Name
+		if label == "" {
+			label = fmt.Sprintf("integration %d", i+1)
+		}
+		if integ.Type != "" {
+			label += " (" + integ.Type + ")"
+		}
+		sb.WriteString(fmt.Sprintf("    ext%d[(\"%s\")]\n", i, mermaidLabel(label)))
+		if interaction := strings.TrimSpace(oneLine(integ.Interaction)); interaction != "" {
+			sb.WriteString(fmt.Sprintf("    project -->|\"%s\"| ext%d\n", mermaidLabel(interaction), i))
+		} else {
+			sb.WriteString(fmt.Sprintf("    project --> ext%d\n", i))
+		}
+	}
+
+	return sb.String()
+}
+
+// mermaidLabel escapes text for a quoted Mermaid label
+func mermaidLabel(s string) string {
+	s = oneLine(s)
+	s = strings.ReplaceAll(s, "\"", "#quot;")
+	return strings.ReplaceAll(s, "|", "#124;")
+}
+
 // RenderTools returns detailed tools information
 func (e *ExplainOutput) RenderTools() string {
 	if e.Tools == nil {
@@ -350,7 +495,8 @@ func (e *ExplainOutput) RenderTools() string {
 			return
 		}
 		sb.WriteString(fmt.Sprintf("## %s\n\n", title))
-		for name, cmd := range cmds {
+		for _, name := range sortedKeys(cmds) {
+			cmd := cmds[name]
 			sb.WriteString(fmt.Sprintf("### %s\n", name))
 			sb.WriteString(fmt.Sprintf("```\n%s\n```\n", cmd.Command))
 			if cmd.Output != "" {
@@ -388,42 +534,42 @@ func (e *ExplainOutput) RenderGuidelines() string {
 
 	if len(e.Guidelines.Naming) > 0 {
 		sb.WriteString("## Naming Conventions\n\n")
-		for name, rule := range e.Guidelines.Naming {
+		for _, name := range sortedKeys(e.Guidelines.Naming) {
 			sb.WriteString(fmt.Sprintf("### %s\n", name))
-			renderFlexibleValue(&sb, rule, "")
+			renderFlexibleValue(&sb, e.Guidelines.Naming[name], "")
 			sb.WriteString("\n")
 		}
 	}
 
 	if len(e.Guidelines.Structure) > 0 {
 		sb.WriteString("## Code Structure\n\n")
-		for name, desc := range e.Guidelines.Structure {
-			sb.WriteString(fmt.Sprintf("### %s\n\n%s\n\n", name, desc))
+		for _, name := range sortedKeys(e.Guidelines.Structure) {
+			sb.WriteString(fmt.Sprintf("### %s\n\n%s\n\n", name, e.Guidelines.Structure[name]))
 		}
 	}
 
 	if len(e.Guidelines.Errors) > 0 {
 		sb.WriteString("## Error Handling\n\n")
-		for name, val := range e.Guidelines.Errors {
+		for _, name := range sortedKeys(e.Guidelines.Errors) {
 			sb.WriteString(fmt.Sprintf("### %s\n", name))
-			renderFlexibleValue(&sb, val, "")
+			renderFlexibleValue(&sb, e.Guidelines.Errors[name], "")
 			sb.WriteString("\n")
 		}
 	}
 
 	if len(e.Guidelines.Testing) > 0 {
 		sb.WriteString("## Testing\n\n")
-		for name, val := range e.Guidelines.Testing {
+		for _, name := range sortedKeys(e.Guidelines.Testing) {
 			sb.WriteString(fmt.Sprintf("### %s\n", name))
-			renderFlexibleValue(&sb, val, "")
+			renderFlexibleValue(&sb, e.Guidelines.Testing[name], "")
 			sb.WriteString("\n")
 		}
 	}
 
 	if len(e.Guidelines.Commits) > 0 {
 		sb.WriteString("## Commits\n\n")
-		for name, desc := range e.Guidelines.Commits {
-			sb.WriteString(fmt.Sprintf("- **%s**: %s\n", name, desc))
+		for _, name := range sortedKeys(e.Guidelines.Commits) {
+			sb.WriteString(fmt.Sprintf("- **%s**: %s\n", name, e.Guidelines.Commits[name]))
 		}
 		sb.WriteString("\n")
 	}
@@ -431,7 +577,7 @@ func (e *ExplainOutput) RenderGuidelines() string {
 	if len(e.Guidelines.Rules) > 0 {
 		sb.WriteString("## Rules\n\n")
 		for _, rule := range e.Guidelines.Rules {
-			sb.WriteString(fmt.Sprintf("- %s\n", rule))
+			sb.WriteString(fmt.Sprintf("- %s\n", rule.Label()))
 		}
 		sb.WriteString("\n")
 	}
@@ -439,7 +585,7 @@ func (e *ExplainOutput) RenderGuidelines() string {
 	if len(e.Guidelines.Avoid) > 0 {
 		sb.WriteString("## Avoid\n\n")
 		for _, item := range e.Guidelines.Avoid {
-			sb.WriteString(fmt.Sprintf("- %s\n", item))
+			sb.WriteString(fmt.Sprintf("- %s\n", item.Label()))
 		}
 		sb.WriteString("\n")
 	}
@@ -447,7 +593,7 @@ func (e *ExplainOutput) RenderGuidelines() string {
 	if len(e.Guidelines.Principles) > 0 {
 		sb.WriteString("## Design Principles\n\n")
 		for _, p := range e.Guidelines.Principles {
-			sb.WriteString(fmt.Sprintf("- %s\n", p))
+			sb.WriteString(fmt.Sprintf("- %s\n", p.Label()))
 		}
 		sb.WriteString("\n")
 	}
@@ -455,6 +601,103 @@ func (e *ExplainOutput) RenderGuidelines() string {
 	return sb.String()
 }
 
+// GuidelinesChecklist flattens all guideline sections into DO/DON'T items.
+// Avoid entries become DON'T items; everything else is a DO.
+func (e *ExplainOutput) GuidelinesChecklist() []string {
+	if e.Guidelines == nil {
+		return nil
+	}
+
+	var items []string
+	addKeyed := func(section map[string]interface{}) {
+		for _, name := range sortedKeys(section) {
+			for _, v := range flattenFlexibleValue(section[name]) {
+				items = append(items, fmt.Sprintf("DO: %s: %s", name, v))
+			}
+		}
+	}
+	addStrings := func(section map[string]string) {
+		for _, name := range sortedKeys(section) {
+			items = append(items, fmt.Sprintf("DO: %s: %s", name, oneLine(section[name])))
+		}
+	}
+
+	addKeyed(e.Guidelines.Naming)
+	addStrings(e.Guidelines.Structure)
+	addKeyed(e.Guidelines.Errors)
+	addKeyed(e.Guidelines.Testing)
+	addStrings(e.Guidelines.Commits)
+	for _, rule := range e.Guidelines.Rules {
+		items = append(items, "DO: "+oneLine(rule.Label()))
+	}
+	for _, p := range e.Guidelines.Principles {
+		items = append(items, "DO: "+oneLine(p.Label()))
+	}
+	for _, item := range e.Guidelines.Avoid {
+		items = append(items, "DON'T: "+oneLine(item.Label()))
+	}
+	return items
+}
+
+// RenderGuidelinesAsRules returns guidelines as a flat checklist for code review
+func (e *ExplainOutput) RenderGuidelinesAsRules() string {
+	if e.Guidelines == nil {
+		return "No guidelines configuration found.\nhint: Create .checkpoint/guidelines.yml\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# Guidelines Checklist\n\n")
+	for _, item := range e.GuidelinesChecklist() {
+		sb.WriteString(fmt.Sprintf("- %s\n", item))
+	}
+	return sb.String()
+}
+
+// flattenFlexibleValue turns interface{} values into single-line items,
+// mirroring the shapes handled by renderFlexibleValue
+func flattenFlexibleValue(val interface{}) []string {
+	switch v := val.(type) {
+	case string:
+		return []string{oneLine(v)}
+	case []interface{}:
+		var items []string
+		for _, item := range v {
+			items = append(items, oneLine(fmt.Sprintf("%v", item)))
+		}
+		return items
+	case map[string]interface{}:
+		var items []string
+		for _, key := range sortedKeys(v) {
+			switch sv := v[key].(type) {
+			case []interface{}:
+				for _, item := range sv {
+					items = append(items, fmt.Sprintf("%s: %s", key, oneLine(fmt.Sprintf("%v", item))))
+				}
+			default:
+				items = append(items, fmt.Sprintf("%s: %s", key, oneLine(fmt.Sprintf("%v", sv))))
+			}
+		}
+		return items
+	default:
+		return []string{oneLine(fmt.Sprintf("%v", v))}
+	}
+}
+
+// oneLine collapses multi-line text so each checklist item stays on one line
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// sortedKeys returns map keys in order so rendered output is deterministic
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // renderFlexibleValue renders interface{} values in a readable way
 func renderFlexibleValue(sb *strings.Builder, val interface{}, indent string) {
 	switch v := val.(type) {
@@ -465,8 +708,8 @@ func renderFlexibleValue(sb *strings.Builder, val interface{}, indent string) {
 			fmt.Fprintf(sb, "%s- %v\n", indent, item)
 		}
 	case map[string]interface{}:
-		for key, subval := range v {
-			switch sv := subval.(type) {
+		for _, key := range sortedKeys(v) {
+			switch sv := v[key].(type) {
 			case string:
 				fmt.Fprintf(sb, "%s**%s**: %s\n", indent, key, sv)
 			case []interface{}:
@@ -556,7 +799,7 @@ func (e *ExplainOutput) RenderLearnings() string {
 		if len(ts) > 10 {
 			ts = ts[:10] // Just the date
 		}
-		sb.WriteString(fmt.Sprintf("- [%s] %s\n", ts, l.Learning))
+		sb.WriteString(fmt.Sprintf("- [%s] %s%s\n", ts, l.Learning, learningMeta(l)))
 	}
 
 	sb.WriteString("\nTo add: checkpoint learn \"your insight\"\n")
@@ -565,6 +808,25 @@ func (e *ExplainOutput) RenderLearnings() string {
 	return sb.String()
 }
 
+// learningMeta renders a learning's tags, scope and ref as a suffix, e.g.
+// " (tags: db, perf; scope: api; ref: 1a2b3c4)"
+func learningMeta(l Learning) string {
+	var parts []string
+	if len(l.Tags) > 0 {
+		parts = append(parts, "tags: "+strings.Join(l.Tags, ", "))
+	}
+	if l.Scope != "" {
+		parts = append(parts, "scope: "+l.Scope)
+	}
+	if l.Ref != "" {
+		parts = append(parts, "ref: "+l.Ref)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, "; ") + ")"
+}
+
 // RenderFull returns complete context dump
 func (e *ExplainOutput) RenderFull() string {
 	var sb strings.Builder
@@ -0,0 +1,291 @@
+package explain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestRenderDeterministicOrder(t *testing.T) {
+	keyPaths := map[string]string{}
+	build := map[string]ToolCommand{}
+	naming := map[string]interface{}{}
+	structure := map[string]string{}
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("key-%02d", 19-i)
+		keyPaths[name] = "path/" + name
+		build[name] = ToolCommand{Command: "make " + name}
+		naming[name] = map[string]interface{}{"b": "second", "a": "first", "c": []interface{}{"x"}}
+		structure[name] = "desc " + name
+	}
+	e := &ExplainOutput{
+		Project:    &ProjectConfig{Name: "demo", Architecture: ArchitectureConfig{KeyPaths: keyPaths}},
+		Tools:      &ToolsConfig{Build: build},
+		Guidelines: &GuidelinesConfig{Naming: naming, Structure: structure},
+	}
+
+	renders := map[string]func() string{
+		"project":    e.RenderProject,
+		"tools":      e.RenderTools,
+		"guidelines": e.RenderGuidelines,
+		"summary":    e.RenderSummary,
+		"full":       e.RenderFull,
+		"mermaid":    e.RenderProjectMermaid,
+		"rules":      e.RenderGuidelinesAsRules,
+	}
+	for name, render := range renders {
+		first := render()
+		for i := 0; i < 10; i++ {
+			if got := render(); got != first {
+				t.Fatalf("%s render not stable across runs", name)
+			}
+		}
+	}
+
+	tools := e.RenderTools()
+	if strings.Index(tools, "### key-00") > strings.Index(tools, "### key-19") {
+		t.Errorf("expected tool commands sorted by name")
+	}
+	guidelines := e.RenderGuidelines()
+	if strings.Index(guidelines, "**a**: first") > strings.Index(guidelines, "**b**: second") {
+		t.Errorf("expected nested guideline keys sorted")
+	}
+}
+
+func TestGuidelinesChecklist(t *testing.T) {
+	guidelinesYAML := `naming:
+  files: snake_case
+  types:
+    exported: PascalCase
+    suffixes: [Config, Options]
+  acronyms: [ID, URL]
+structure:
+  cmd: "One file per
+    command"
+commits:
+  subject: Imperative mood
+rules:
+  - Validate input at API boundaries
+  - text: Wrap errors with %w
+    applies_to: [go]
+principles:
+  - Prefer boring code
+avoid:
+  - Global state
+  - text: "panic in
+      library code"
+    applies_to: go
+`
+	var guidelines GuidelinesConfig
+	if err := yaml.Unmarshal([]byte(guidelinesYAML), &guidelines); err != nil {
+		t.Fatalf("unmarshal guidelines: %v", err)
+	}
+	e := &ExplainOutput{Guidelines: &guidelines}
+
+	want := []string{
+		"DO: acronyms: ID",
+		"DO: acronyms: URL",
+		"DO: files: snake_case",
+		"DO: types: exported: PascalCase",
+		"DO: types: suffixes: Config",
+		"DO: types: suffixes: Options",
+		"DO: cmd: One file per command",
+		"DO: subject: Imperative mood",
+		"DO: Validate input at API boundaries",
+		"DO: Wrap errors with %w (go)",
+		"DO: Prefer boring code",
+		"DON'T: Global state",
+		"DON'T: panic in library code (go)",
+	}
+	got := e.GuidelinesChecklist()
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("GuidelinesChecklist() =\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	rules := e.RenderGuidelinesAsRules()
+	if !strings.HasPrefix(rules, "# Guidelines Checklist\n\n- DO: acronyms: ID\n") || !strings.HasSuffix(rules, "- DON'T: panic in library code (go)\n") {
+		t.Errorf("unexpected rules output:\n%s", rules)
+	}
+
+	if got := (&ExplainOutput{}).GuidelinesChecklist(); got != nil {
+		t.Errorf("expected nil checklist without guidelines, got %v", got)
+	}
+}
+
+func TestRenderProjectMermaid(t *testing.T) {
+	empty := (&ExplainOutput{}).RenderProjectMermaid()
+	if empty != "flowchart LR\n    project[\"project\"]\n" {
+		t.Errorf("expected minimal diagram for missing project, got:\n%s", empty)
+	}
+
+	e := &ExplainOutput{Project: &ProjectConfig{
+		Name: `My "App"`,
+		Architecture: ArchitectureConfig{KeyPaths: map[string]string{
+			"commands": "cmd/",
+			"config":   "",
+		}},
+		Integrations: []IntegrationConfig{
+			{Name: "Git", Type: "required", Interaction: "Runs status | diff\nand commit"},
+			{Name: "Editor"},
+		},
+	}}
+	out := e.RenderProjectMermaid()
+	for _, want := range []string{
+		"    project[\"My #quot;App#quot;\"]\n",
+		"    path0[\"commands<br/>cmd/\"]\n    project --> path0\n",
+		"    path1[\"config\"]\n",
+		"    ext0[(\"Git (required)\")]\n",
+		"    project -->|\"Runs status #124; diff and commit\"| ext0\n",
+		"    project --> ext1\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("mermaid output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestGuidelinesAppliesTo(t *testing.T) {
+	src := `schema_version: "1"
+rules:
+  - Validate input at API boundaries
+  - text: Wrap errors with %w
+    applies_to: [go]
+  - text: Use type hints
+    applies_to: Python
+avoid:
+  - text: Bare except clauses
+    applies_to: [python]
+`
+	var g GuidelinesConfig
+	if err := yaml.Unmarshal([]byte(src), &g); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(g.Rules) != 3 || g.Rules[2].AppliesTo[0] != "Python" {
+		t.Fatalf("unexpected rules: %+v", g.Rules)
+	}
+
+	e := &ExplainOutput{Guidelines: &g}
+	e.FilterGuidelines("go")
+	out := e.RenderGuidelines()
+	for _, want := range []string{"- Validate input at API boundaries\n", "- Wrap errors with %w (go)\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("filtered output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "type hints") || strings.Contains(out, "## Avoid") {
+		t.Errorf("filtered output should drop python entries:\n%s", out)
+	}
+	if len(g.Rules) != 3 {
+		t.Error("filtering should not modify the loaded config")
+	}
+
+	// Untagged entries stay plain strings when written back
+	b, err := yaml.Marshal(&g)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(b), "- Validate input at API boundaries\n") || !strings.Contains(string(b), "applies_to:") {
+		t.Errorf("unexpected yaml:\n%s", b)
+	}
+	j, _ := json.Marshal(g.Rules[:2])
+	if string(j) != `["Validate input at API boundaries",{"text":"Wrap errors with %w","applies_to":["go"]}]` {
+		t.Errorf("unexpected json: %s", j)
+	}
+}
+
+func TestLearningsTags(t *testing.T) {
+	src := `---
+timestamp: "2025-01-01T00:00:00Z"
+learning: Run migrations before tests
+tags: [DB, "testing, db"]
+scope: store
+ref: "#12"
+---
+timestamp: "2025-01-02T00:00:00Z"
+learning: Prefer table-driven tests
+`
+	learnings := loadLearnings([]byte(src))
+	if len(learnings) != 2 {
+		t.Fatalf("expected 2 learnings, got %d", len(learnings))
+	}
+	if got := strings.Join(learnings[0].Tags, ","); got != "DB,testing" {
+		t.Errorf("unexpected tags: %q", got)
+	}
+
+	e := &ExplainOutput{Learnings: learnings}
+	e.FilterLearnings("db")
+	out := e.RenderLearnings()
+	if !strings.Contains(out, "Run migrations before tests (tags: DB, testing; scope: store; ref: #12)") {
+		t.Errorf("missing tagged learning:\n%s", out)
+	}
+	if strings.Contains(out, "table-driven") {
+		t.Errorf("untagged learning should be filtered out:\n%s", out)
+	}
+}
+
+func TestRenderJSON(t *testing.T) {
+	e := &ExplainOutput{
+		Project: &ProjectConfig{
+			Name:         "demo",
+			Architecture: ArchitectureConfig{KeyPaths: map[string]string{"cmd/": "CLI"}},
+			Languages:    LanguagesConfig{Primary: "go"},
+		},
+		Tools:     &ToolsConfig{Build: map[string]ToolCommand{"default": {Command: "go build ./..."}}},
+		SkillDefs: []Skill{{Name: "git", IsLocal: true}},
+		Learnings: []Learning{{Learning: "Run tests", Tags: []string{"ci"}}},
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(e.RenderJSON()), &decoded); err != nil {
+		t.Fatalf("RenderJSON is not valid JSON: %v", err)
+	}
+	for _, key := range []string{"project", "tools", "guidelines", "skills_config", "skills", "learnings"} {
+		if _, ok := decoded[key]; !ok {
+			t.Errorf("missing top-level key %q", key)
+		}
+	}
+	out := e.RenderJSON()
+	for _, want := range []string{`"key_paths"`, `"primary": "go"`, `"command": "go build ./..."`, `"is_local": true`, `"tags": [`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("RenderJSON missing %s:\n%s", want, out)
+		}
+	}
+
+	if _, ok := e.JSONView("skills").(SkillsJSON); !ok {
+		t.Errorf("JSONView(skills) = %T, want SkillsJSON", e.JSONView("skills"))
+	}
+}
+
+func TestFilterProjectPaths(t *testing.T) {
+	project := &ProjectConfig{Architecture: ArchitectureConfig{
+		KeyPaths: map[string]string{"commands": "cmd/", "git": "internal/git/", "explain": "internal/explain/"},
+		KeyFiles: []KeyFileConfig{
+			{Path: "cmd/root.go", Tracked: true},
+			{Path: "cmd/explain.go"},
+			{Path: "internal/git/git.go"},
+		},
+	}}
+
+	e := &ExplainOutput{Project: project}
+	e.FilterProjectPaths("internal/", false)
+	if len(e.Project.Architecture.KeyPaths) != 2 || len(e.Project.Architecture.KeyFiles) != 1 {
+		t.Errorf("prefix filter: %+v", e.Project.Architecture)
+	}
+
+	e = &ExplainOutput{Project: project}
+	e.FilterProjectPaths("cmd/*", true)
+	arch := e.Project.Architecture
+	if arch.KeyPaths["commands"] != "cmd/" || len(arch.KeyPaths) != 1 {
+		t.Errorf("glob key paths = %v", arch.KeyPaths)
+	}
+	if len(arch.KeyFiles) != 1 || arch.KeyFiles[0].Path != "cmd/explain.go" {
+		t.Errorf("glob untracked key files = %+v", arch.KeyFiles)
+	}
+
+	if len(project.Architecture.KeyPaths) != 3 || len(project.Architecture.KeyFiles) != 3 {
+		t.Error("filtering should not modify the loaded config")
+	}
+}
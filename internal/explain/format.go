@@ -0,0 +1,103 @@
+package explain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// HistoryFormatPresets are named shortcuts for 'explain history --format'
+var HistoryFormatPresets = map[string]string{
+	"oneline": "%h %s",
+	"full":    "%h %t [%T] %s (%c changes) %a",
+}
+
+// historyPlaceholders describes the verbs a history format may use
+var historyPlaceholders = map[byte]string{
+	'h': "short commit hash",
+	't': "timestamp",
+	's': "first change summary",
+	'T': "first change type",
+	'c': "change count",
+	'a': "commit author",
+	'%': "literal %",
+}
+
+// ResolveHistoryFormat expands a preset name and checks every placeholder,
+// reporting unknown ones together with the valid list
+func ResolveHistoryFormat(format string) (string, error) {
+	if preset, ok := HistoryFormatPresets[format]; ok {
+		return preset, nil
+	}
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		if i+1 == len(format) {
+			return "", fmt.Errorf("format ends with a bare %%; valid placeholders: %s", historyPlaceholderList())
+		}
+		if _, ok := historyPlaceholders[format[i+1]]; !ok {
+			return "", fmt.Errorf("unknown placeholder %%%c; valid placeholders: %s", format[i+1], historyPlaceholderList())
+		}
+		i++
+	}
+	return format, nil
+}
+
+// HistoryFormatUsesAuthor reports whether format needs commit authors (%a),
+// which are looked up in git and so only fetched when asked for
+func HistoryFormatUsesAuthor(format string) bool {
+	for i := 0; i+1 < len(format); i++ {
+		if format[i] == '%' {
+			if format[i+1] == 'a' {
+				return true
+			}
+			i++
+		}
+	}
+	return false
+}
+
+// FormatCheckpoint renders one checkpoint with a format already checked by
+// ResolveHistoryFormat
+func FormatCheckpoint(format string, cp CheckpointEntry, author string) string {
+	var sb strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' || i+1 == len(format) {
+			sb.WriteByte(format[i])
+			continue
+		}
+		i++
+		switch format[i] {
+		case 'h':
+			sb.WriteString(cp.CommitHash[:minInt(7, len(cp.CommitHash))])
+		case 't':
+			sb.WriteString(cp.Timestamp)
+		case 's':
+			if len(cp.Changes) > 0 {
+				sb.WriteString(cp.Changes[0].Summary)
+			}
+		case 'T':
+			if len(cp.Changes) > 0 {
+				sb.WriteString(cp.Changes[0].ChangeType)
+			}
+		case 'c':
+			sb.WriteString(strconv.Itoa(len(cp.Changes)))
+		case 'a':
+			sb.WriteString(author)
+		default:
+			sb.WriteByte(format[i])
+		}
+	}
+	return sb.String()
+}
+
+func historyPlaceholderList() string {
+	var verbs []string
+	for v, desc := range historyPlaceholders {
+		verbs = append(verbs, fmt.Sprintf("%%%c (%s)", v, desc))
+	}
+	sort.Strings(verbs)
+	return strings.Join(verbs, ", ")
+}
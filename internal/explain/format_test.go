@@ -0,0 +1,38 @@
+package explain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatCheckpoint(t *testing.T) {
+	cp := CheckpointEntry{
+		Timestamp:  "2025-01-02T00:00:00Z",
+		CommitHash: "abcdef1234567",
+		Changes: []ChangeEntry{
+			{Summary: "Add parser", ChangeType: "feature"},
+			{Summary: "Fix lexer", ChangeType: "fix"},
+		},
+	}
+
+	full, err := ResolveHistoryFormat("full")
+	if err != nil {
+		t.Fatalf("resolve preset: %v", err)
+	}
+	if got := FormatCheckpoint(full, cp, "Ada"); got != "abcdef1 2025-01-02T00:00:00Z [feature] Add parser (2 changes) Ada" {
+		t.Errorf("full = %q", got)
+	}
+	if got := FormatCheckpoint("%c%%|%s", cp, ""); got != "2%|Add parser" {
+		t.Errorf("custom = %q", got)
+	}
+	if !HistoryFormatUsesAuthor(full) || HistoryFormatUsesAuthor("%%a %s") {
+		t.Error("HistoryFormatUsesAuthor misdetects %a")
+	}
+
+	for _, bad := range []string{"%x", "trailing %"} {
+		_, err := ResolveHistoryFormat(bad)
+		if err == nil || !strings.Contains(err.Error(), "%h (short commit hash)") {
+			t.Errorf("ResolveHistoryFormat(%q) error = %v, want list of valid placeholders", bad, err)
+		}
+	}
+}
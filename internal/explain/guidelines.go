@@ -0,0 +1,151 @@
+package explain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GuidelineItem is one rule, avoid or principle entry. In YAML it is either
+// a plain string (applies everywhere) or a mapping with text and applies_to:
+//
+//	rules:
+//	  - Validate input at API boundaries
+//	  - text: Wrap errors with %w
+//	    applies_to: [go]
+type GuidelineItem struct {
+	Text      string
+	AppliesTo []string // languages the entry is limited to; empty means all
+}
+
+type guidelineItemFields struct {
+	Text      string   `yaml:"text" json:"text"`
+	AppliesTo []string `yaml:"applies_to,omitempty" json:"applies_to,omitempty"`
+}
+
+// UnmarshalYAML accepts a plain string or a text/applies_to mapping;
+// applies_to may be a single language or a list
+func (g *GuidelineItem) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		g.Text = node.Value
+		g.AppliesTo = nil
+		return nil
+	}
+	var raw struct {
+		Text      string    `yaml:"text"`
+		AppliesTo yaml.Node `yaml:"applies_to"`
+	}
+	if err := node.Decode(&raw); err != nil {
+		return err
+	}
+	g.Text = raw.Text
+	g.AppliesTo = nil
+	switch raw.AppliesTo.Kind {
+	case 0:
+	case yaml.ScalarNode:
+		if raw.AppliesTo.Value != "" {
+			g.AppliesTo = []string{raw.AppliesTo.Value}
+		}
+	default:
+		if err := raw.AppliesTo.Decode(&g.AppliesTo); err != nil {
+			return fmt.Errorf("applies_to: %w", err)
+		}
+	}
+	return nil
+}
+
+// MarshalYAML writes untagged entries as plain strings so existing files keep their shape
+func (g GuidelineItem) MarshalYAML() (interface{}, error) {
+	if len(g.AppliesTo) == 0 {
+		return g.Text, nil
+	}
+	return guidelineItemFields{Text: g.Text, AppliesTo: g.AppliesTo}, nil
+}
+
+// MarshalJSON mirrors MarshalYAML
+func (g GuidelineItem) MarshalJSON() ([]byte, error) {
+	if len(g.AppliesTo) == 0 {
+		return json.Marshal(g.Text)
+	}
+	return json.Marshal(guidelineItemFields{Text: g.Text, AppliesTo: g.AppliesTo})
+}
+
+// AppliesToLanguage reports whether the entry applies to language (case-insensitive).
+// Untagged entries apply to every language, and every entry applies when language is empty.
+func (g GuidelineItem) AppliesToLanguage(language string) bool {
+	if language == "" || len(g.AppliesTo) == 0 {
+		return true
+	}
+	for _, l := range g.AppliesTo {
+		if strings.EqualFold(strings.TrimSpace(l), strings.TrimSpace(language)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Label returns the text with any language tags, e.g. "Wrap errors (go)"
+func (g GuidelineItem) Label() string {
+	if len(g.AppliesTo) == 0 {
+		return g.Text
+	}
+	return fmt.Sprintf("%s (%s)", g.Text, strings.Join(g.AppliesTo, ", "))
+}
+
+// GuidelineList is a rules, avoid or principles section
+type GuidelineList []GuidelineItem
+
+// Contains reports whether an entry has exactly text
+func (l GuidelineList) Contains(text string) bool {
+	for _, g := range l {
+		if g.Text == text {
+			return true
+		}
+	}
+	return false
+}
+
+// Texts returns the entry texts without tags
+func (l GuidelineList) Texts() []string {
+	texts := make([]string, 0, len(l))
+	for _, g := range l {
+		texts = append(texts, g.Text)
+	}
+	return texts
+}
+
+// RemoveLast removes the last entry with exactly text
+func (l GuidelineList) RemoveLast(text string) (GuidelineList, bool) {
+	for i := len(l) - 1; i >= 0; i-- {
+		if l[i].Text == text {
+			return append(l[:i], l[i+1:]...), true
+		}
+	}
+	return l, false
+}
+
+// For returns the entries that apply to language
+func (l GuidelineList) For(language string) GuidelineList {
+	var out GuidelineList
+	for _, g := range l {
+		if g.AppliesToLanguage(language) {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+// FilterGuidelines limits rules, avoid and principles to entries tagged for
+// language plus untagged ones. Other sections have no tags and are kept.
+func (e *ExplainOutput) FilterGuidelines(language string) {
+	if e.Guidelines == nil || language == "" {
+		return
+	}
+	filtered := *e.Guidelines
+	filtered.Rules = filtered.Rules.For(language)
+	filtered.Avoid = filtered.Avoid.For(language)
+	filtered.Principles = filtered.Principles.For(language)
+	e.Guidelines = &filtered
+}
@@ -14,77 +14,88 @@ import (
 
 // CheckpointEntry represents a checkpoint from the changelog
 type CheckpointEntry struct {
-	SchemaVersion string                 `yaml:"schema_version"`
-	Timestamp     string                 `yaml:"timestamp"`
-	CommitHash    string                 `yaml:"commit_hash"`
-	Changes       []ChangeEntry          `yaml:"changes"`
-	NextSteps     []NextStepEntry        `yaml:"next_steps"`
-	Context       map[string]interface{} `yaml:"context"`
+	SchemaVersion string                 `yaml:"schema_version" json:"schema_version"`
+	Timestamp     string                 `yaml:"timestamp" json:"timestamp"`
+	CommitHash    string                 `yaml:"commit_hash" json:"commit_hash"`
+	Changes       []ChangeEntry          `yaml:"changes" json:"changes"`
+	NextSteps     []NextStepEntry        `yaml:"next_steps" json:"next_steps"`
+	Context       map[string]interface{} `yaml:"context" json:"context"`
 }
 
 // ChangeEntry represents a change in a checkpoint
 type ChangeEntry struct {
-	Summary    string `yaml:"summary"`
-	Details    string `yaml:"details"`
-	ChangeType string `yaml:"change_type"`
-	Scope      string `yaml:"scope"`
+	Summary    string   `yaml:"summary" json:"summary"`
+	Details    string   `yaml:"details" json:"details"`
+	ChangeType string   `yaml:"change_type" json:"change_type"`
+	Scope      string   `yaml:"scope" json:"scope"`
+	Refs       []string `yaml:"refs,omitempty" json:"refs,omitempty"`
 }
 
 // NextStepEntry represents a planned next step
 type NextStepEntry struct {
-	Summary  string `yaml:"summary"`
-	Details  string `yaml:"details"`
-	Priority string `yaml:"priority"`
-	Scope    string `yaml:"scope"`
+	Summary  string `yaml:"summary" json:"summary"`
+	Details  string `yaml:"details" json:"details"`
+	Priority string `yaml:"priority" json:"priority"`
+	Scope    string `yaml:"scope" json:"scope"`
 }
 
 // ContextEntry represents a context document
 type ContextEntry struct {
-	SchemaVersion       string        `yaml:"schema_version"`
-	Timestamp           string        `yaml:"timestamp"`
-	CommitHash          string        `yaml:"commit_hash"`
-	ProblemStatement    string        `yaml:"problem_statement"`
-	KeyInsights         []interface{} `yaml:"key_insights"`
-	DecisionsMade       []interface{} `yaml:"decisions_made"`
-	EstablishedPatterns []interface{} `yaml:"established_patterns"`
-	FailedApproaches    []interface{} `yaml:"failed_approaches"`
+	SchemaVersion       string        `yaml:"schema_version" json:"schema_version"`
+	Timestamp           string        `yaml:"timestamp" json:"timestamp"`
+	CommitHash          string        `yaml:"commit_hash" json:"commit_hash"`
+	ProblemStatement    string        `yaml:"problem_statement" json:"problem_statement"`
+	KeyInsights         []interface{} `yaml:"key_insights" json:"key_insights"`
+	DecisionsMade       []interface{} `yaml:"decisions_made" json:"decisions_made"`
+	EstablishedPatterns []interface{} `yaml:"established_patterns" json:"established_patterns"`
+	FailedApproaches    []interface{} `yaml:"failed_approaches" json:"failed_approaches"`
+	ConversationContext []interface{} `yaml:"conversation_context" json:"conversation_context"`
 }
 
 // HistoryData holds aggregated history data
 type HistoryData struct {
-	RecentCheckpoints []CheckpointEntry
-	AllNextSteps      []NextStepWithSource
-	RecentPatterns    []PatternWithSource
-	RecentDecisions   []DecisionWithSource
-	RecentFailed      []FailedWithSource
+	Limit             int                      `json:"limit"` // maximum checkpoints and context entries loaded
+	RecentCheckpoints []CheckpointEntry        `json:"recent_checkpoints"`
+	AllNextSteps      []NextStepWithSource     `json:"all_next_steps"`
+	RecentPatterns    []PatternWithSource      `json:"recent_patterns"`
+	RecentDecisions   []DecisionWithSource     `json:"recent_decisions"`
+	RecentFailed      []FailedWithSource       `json:"recent_failed"`
+	RecentExchanges   []ConversationWithSource `json:"recent_exchanges"`
 }
 
 // NextStepWithSource includes the source checkpoint info
 type NextStepWithSource struct {
 	NextStepEntry
-	FromTimestamp string
-	FromCommit    string
+	FromTimestamp string `json:"from_timestamp"`
+	FromCommit    string `json:"from_commit"`
 }
 
 // PatternWithSource includes source info
 type PatternWithSource struct {
-	Content       string
-	Rationale     string
-	FromTimestamp string
+	Content       string `json:"content"`
+	Rationale     string `json:"rationale"`
+	FromTimestamp string `json:"from_timestamp"`
 }
 
 // DecisionWithSource includes source info
 type DecisionWithSource struct {
-	Content       string
-	Rationale     string
-	FromTimestamp string
+	Content       string `json:"content"`
+	Rationale     string `json:"rationale"`
+	FromTimestamp string `json:"from_timestamp"`
 }
 
 // FailedWithSource includes source info
 type FailedWithSource struct {
-	Approach      string
-	WhyFailed     string
-	FromTimestamp string
+	Approach      string `json:"approach"`
+	WhyFailed     string `json:"why_failed"`
+	FromTimestamp string `json:"from_timestamp"`
+}
+
+// ConversationWithSource includes source info
+type ConversationWithSource struct {
+	Exchange      string `json:"exchange"`
+	Outcome       string `json:"outcome"`
+	FromTimestamp string `json:"from_timestamp"`
 }
 
 // LoadHistory loads recent checkpoint history
@@ -93,7 +104,7 @@ func LoadHistory(projectPath string, limit int) (*HistoryData, error) {
 		limit = 10
 	}
 
-	data := &HistoryData{}
+	data := &HistoryData{Limit: limit}
 
 	// Load changelog
 	changelogPath := filepath.Join(projectPath, config.ChangelogFileName)
@@ -140,6 +151,14 @@ func LoadHistory(projectPath string, limit int) (*HistoryData, error) {
 					data.RecentFailed = append(data.RecentFailed, failed)
 				}
 			}
+			// Extract conversation context
+			for _, c := range ctx.ConversationContext {
+				exchange := extractConversationContent(c)
+				if exchange.Exchange != "" {
+					exchange.FromTimestamp = ctx.Timestamp
+					data.RecentExchanges = append(data.RecentExchanges, exchange)
+				}
+			}
 		}
 	}
 
@@ -182,6 +201,7 @@ func loadContextEntries(path string, limit int) ([]ContextEntry, error) {
 		var entry ContextEntry
 		if err := yaml.Unmarshal([]byte(docs[i]), &entry); err == nil {
 			if entry.Timestamp != "" {
+				mergeNestedContext(docs[i], &entry)
 				entries = append(entries, entry)
 			}
 		}
@@ -190,6 +210,36 @@ func loadContextEntries(path string, limit int) ([]ContextEntry, error) {
 	return entries, nil
 }
 
+// mergeNestedContext fills entry fields from a nested "context:" section, which is
+// how commit writes context documents; top-level fields are kept when present
+func mergeNestedContext(doc string, entry *ContextEntry) {
+	var nested struct {
+		Context ContextEntry `yaml:"context"`
+	}
+	if err := yaml.Unmarshal([]byte(doc), &nested); err != nil {
+		return
+	}
+	c := nested.Context
+	if entry.ProblemStatement == "" {
+		entry.ProblemStatement = c.ProblemStatement
+	}
+	if len(entry.KeyInsights) == 0 {
+		entry.KeyInsights = c.KeyInsights
+	}
+	if len(entry.DecisionsMade) == 0 {
+		entry.DecisionsMade = c.DecisionsMade
+	}
+	if len(entry.EstablishedPatterns) == 0 {
+		entry.EstablishedPatterns = c.EstablishedPatterns
+	}
+	if len(entry.FailedApproaches) == 0 {
+		entry.FailedApproaches = c.FailedApproaches
+	}
+	if len(entry.ConversationContext) == 0 {
+		entry.ConversationContext = c.ConversationContext
+	}
+}
+
 func splitYAMLDocs(content string) []string {
 	var docs []string
 	parts := strings.Split(content, "\n---")
@@ -255,6 +305,23 @@ func extractFailedContent(item interface{}) FailedWithSource {
 	return FailedWithSource{}
 }
 
+func extractConversationContent(item interface{}) ConversationWithSource {
+	switch v := item.(type) {
+	case string:
+		return ConversationWithSource{Exchange: v}
+	case map[string]interface{}:
+		c := ConversationWithSource{}
+		if e, ok := v["exchange"].(string); ok {
+			c.Exchange = e
+		}
+		if o, ok := v["outcome"].(string); ok {
+			c.Outcome = o
+		}
+		return c
+	}
+	return ConversationWithSource{}
+}
+
 // RenderHistory returns formatted history output
 func RenderHistory(projectPath string, limit int) string {
 	history, err := LoadHistory(projectPath, limit)
@@ -282,7 +349,11 @@ func RenderHistory(projectPath string, limit int) string {
 				if change.ChangeType != "" {
 					typeStr = fmt.Sprintf(" (%s)", change.ChangeType)
 				}
-				sb.WriteString(fmt.Sprintf("- %s%s\n", change.Summary, typeStr))
+				refs := ""
+				if len(change.Refs) > 0 {
+					refs = fmt.Sprintf(" [refs: %s]", strings.Join(change.Refs, ", "))
+				}
+				sb.WriteString(fmt.Sprintf("- %s%s%s\n", change.Summary, typeStr, refs))
 			}
 			sb.WriteString("\n")
 		}
@@ -367,6 +438,25 @@ func RenderHistory(projectPath string, limit int) string {
 		sb.WriteString("\n")
 	}
 
+	// Conversation context
+	if len(history.RecentExchanges) > 0 {
+		sb.WriteString("## Conversation Context\n\n")
+		seen := make(map[string]bool)
+		count := 0
+		for _, c := range history.RecentExchanges {
+			if seen[c.Exchange] || count >= 5 {
+				continue
+			}
+			seen[c.Exchange] = true
+			count++
+			sb.WriteString(fmt.Sprintf("- %s\n", c.Exchange))
+			if c.Outcome != "" {
+				sb.WriteString(fmt.Sprintf("  *→ %s*\n", c.Outcome))
+			}
+		}
+		sb.WriteString("\n")
+	}
+
 	return sb.String()
 }
 
@@ -0,0 +1,91 @@
+package explain
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dmoose/checkpoint/pkg/config"
+)
+
+func TestLoadHistoryJSON(t *testing.T) {
+	dir := t.TempDir()
+	hash := "0123456789abcdef0123456789abcdef01234567"
+	changelog := `---
+schema_version: "1"
+document_type: meta
+---
+schema_version: "1"
+timestamp: "2025-01-02T00:00:00Z"
+commit_hash: ` + hash + `
+changes:
+  - summary: "Add parser"
+    change_type: feature
+next_steps:
+  - summary: "Handle errors"
+    priority: high
+`
+	if err := os.WriteFile(filepath.Join(dir, config.ChangelogFileName), []byte(changelog), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := LoadHistory(dir, 5)
+	if err != nil {
+		t.Fatalf("LoadHistory error: %v", err)
+	}
+	data, err := json.Marshal(history)
+	if err != nil {
+		t.Fatalf("marshal history: %v", err)
+	}
+	out := string(data)
+
+	for _, want := range []string{
+		`"limit":5`,
+		`"commit_hash":"` + hash + `"`,
+		`"from_commit":"` + hash + `"`,
+		`"summary":"Handle errors"`,
+		`"change_type":"feature"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("history JSON missing %s:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderHistoryNestedContext(t *testing.T) {
+	dir := t.TempDir()
+	// commit writes context documents with their content under "context:"
+	contextDoc := `---
+schema_version: "1"
+timestamp: "2025-01-02T00:00:00Z"
+commit_hash: abc1234
+context:
+  problem_statement: "Slow startup"
+  key_insights:
+    - insight: "Cache warmup dominates startup"
+  decisions_made:
+    - decision: "Warm the cache lazily"
+      rationale: "First request can pay the cost"
+  conversation_context:
+    - exchange: "Asked whether eager warmup is required"
+      outcome: "Lazy warmup is fine"
+`
+	if err := os.WriteFile(filepath.Join(dir, config.ContextFileName), []byte(contextDoc), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := RenderHistory(dir, 5)
+	for _, want := range []string{
+		"## Recent Decisions",
+		"- Warm the cache lazily",
+		"## Conversation Context",
+		"- Asked whether eager warmup is required",
+		"*→ Lazy warmup is fine*",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("history missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package explain
+
+import "encoding/json"
+
+// JSONOutput is the structured form of the full explain context
+type JSONOutput struct {
+	Project      *ProjectConfig    `json:"project"`
+	Tools        *ToolsConfig      `json:"tools"`
+	Guidelines   *GuidelinesConfig `json:"guidelines"`
+	SkillsConfig *SkillsConfig     `json:"skills_config"`
+	Skills       []Skill           `json:"skills"`
+	Learnings    []Learning        `json:"learnings"`
+}
+
+// SkillsJSON is the structured form of the skills topic
+type SkillsJSON struct {
+	Config *SkillsConfig `json:"config"`
+	Skills []Skill       `json:"skills"`
+}
+
+// JSONView returns the marshalable data behind a topic; an empty or
+// unrecognised topic returns the full JSONOutput
+func (e *ExplainOutput) JSONView(topic string) interface{} {
+	switch topic {
+	case "project":
+		return e.Project
+	case "tools":
+		return e.Tools
+	case "guidelines":
+		return e.Guidelines
+	case "skills":
+		return SkillsJSON{Config: e.Skills, Skills: e.SkillDefs}
+	case "learnings":
+		return e.Learnings
+	default:
+		return JSONOutput{
+			Project:      e.Project,
+			Tools:        e.Tools,
+			Guidelines:   e.Guidelines,
+			SkillsConfig: e.Skills,
+			Skills:       e.SkillDefs,
+			Learnings:    e.Learnings,
+		}
+	}
+}
+
+// RenderJSON renders the full explain context as indented JSON
+func (e *ExplainOutput) RenderJSON() string {
+	data, err := json.MarshalIndent(e.JSONView(""), "", "  ")
+	if err != nil {
+		return "{}\n"
+	}
+	return string(data) + "\n"
+}
@@ -0,0 +1,99 @@
+package explain
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/dmoose/checkpoint/pkg/config"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RedactedText replaces every redacted match
+const RedactedText = "[REDACTED]"
+
+// defaultRedactPatterns catch common credential formats and email addresses.
+// A capture group named "secret" limits the replacement to that group, so
+// "api_key: abc" keeps its key name.
+var defaultRedactPatterns = []string{
+	`AKIA[0-9A-Z]{16}`,                                 // AWS access key ID
+	`gh[pousr]_[A-Za-z0-9]{36,}`,                       // GitHub token
+	`github_pat_[A-Za-z0-9_]{22,}`,                     // GitHub fine-grained token
+	`sk-[A-Za-z0-9_-]{20,}`,                            // OpenAI/Anthropic-style key
+	`xox[abprs]-[A-Za-z0-9-]{10,}`,                     // Slack token
+	`-----BEGIN [A-Z ]*PRIVATE KEY-----`,               // PEM private key header
+	`(?i)bearer\s+(?P<secret>[A-Za-z0-9._~+/=-]{16,})`, // Authorization header
+	`(?i)(?:api[_-]?key|secret|token|passw(?:or)?d)["']?\s*[:=]\s*["']?(?P<secret>[^\s"',]{6,})`,
+	`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`, // email address
+}
+
+// RedactConfig is the optional .checkpoint/redact.yml
+type RedactConfig struct {
+	// Patterns are extra regular expressions to redact
+	Patterns []string `yaml:"patterns"`
+	// NoDefaults disables the built-in credential and email patterns
+	NoDefaults bool `yaml:"no_defaults,omitempty"`
+}
+
+// Redactor replaces sensitive matches in rendered text
+type Redactor struct {
+	patterns []*regexp.Regexp
+}
+
+// LoadRedactor builds a redactor from the built-in patterns plus any in
+// .checkpoint/redact.yml; an invalid pattern is an error
+func LoadRedactor(projectPath string) (*Redactor, error) {
+	var cfg RedactConfig
+	path := filepath.Join(projectPath, config.CheckpointDir, config.RedactYaml)
+	if data, err := os.ReadFile(path); err == nil {
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", config.RedactYaml, err)
+		}
+	}
+
+	var sources []string
+	if !cfg.NoDefaults {
+		sources = append(sources, defaultRedactPatterns...)
+	}
+	sources = append(sources, cfg.Patterns...)
+	return NewRedactor(sources)
+}
+
+// NewRedactor compiles patterns into a redactor
+func NewRedactor(patterns []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", p, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// Redact returns text with every match replaced and the number of redactions
+func (r *Redactor) Redact(text string) (string, int) {
+	count := 0
+	for _, re := range r.patterns {
+		secret := re.SubexpIndex("secret")
+		text = re.ReplaceAllStringFunc(text, func(match string) string {
+			start, end := 0, len(match)
+			if secret >= 0 {
+				// Re-match to locate the secret group within this match
+				if loc := re.FindStringSubmatchIndex(match); loc != nil && loc[2*secret] >= 0 {
+					start, end = loc[2*secret], loc[2*secret+1]
+				}
+			}
+			// Already redacted by an earlier pattern
+			if match[start:end] == RedactedText {
+				return match
+			}
+			count++
+			return match[:start] + RedactedText + match[end:]
+		})
+	}
+	return text, count
+}
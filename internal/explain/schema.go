@@ -2,106 +2,109 @@ package explain
 
 // ProjectConfig represents .checkpoint/project.yml
 type ProjectConfig struct {
-	SchemaVersion string              `yaml:"schema_version"`
-	Name          string              `yaml:"name"`
-	Type          string              `yaml:"type"`
-	Purpose       string              `yaml:"purpose"`
-	Repository    string              `yaml:"repository,omitempty"`
-	Architecture  ArchitectureConfig  `yaml:"architecture,omitempty"`
-	Languages     LanguagesConfig     `yaml:"languages,omitempty"`
-	Dependencies  DependenciesConfig  `yaml:"dependencies,omitempty"`
-	Integrations  []IntegrationConfig `yaml:"integrations,omitempty"`
+	SchemaVersion string              `yaml:"schema_version" json:"schema_version"`
+	Name          string              `yaml:"name" json:"name"`
+	Type          string              `yaml:"type" json:"type"`
+	Purpose       string              `yaml:"purpose" json:"purpose"`
+	Repository    string              `yaml:"repository,omitempty" json:"repository,omitempty"`
+	Architecture  ArchitectureConfig  `yaml:"architecture,omitempty" json:"architecture,omitempty"`
+	Languages     LanguagesConfig     `yaml:"languages,omitempty" json:"languages,omitempty"`
+	Dependencies  DependenciesConfig  `yaml:"dependencies,omitempty" json:"dependencies,omitempty"`
+	Integrations  []IntegrationConfig `yaml:"integrations,omitempty" json:"integrations,omitempty"`
 }
 
 type ArchitectureConfig struct {
-	Overview string            `yaml:"overview,omitempty"`
-	KeyPaths map[string]string `yaml:"key_paths,omitempty"`
-	DataFlow string            `yaml:"data_flow,omitempty"`
-	KeyFiles []KeyFileConfig   `yaml:"key_files,omitempty"`
+	Overview string            `yaml:"overview,omitempty" json:"overview,omitempty"`
+	KeyPaths map[string]string `yaml:"key_paths,omitempty" json:"key_paths,omitempty"`
+	DataFlow string            `yaml:"data_flow,omitempty" json:"data_flow,omitempty"`
+	KeyFiles []KeyFileConfig   `yaml:"key_files,omitempty" json:"key_files,omitempty"`
 }
 
 type KeyFileConfig struct {
-	Path    string `yaml:"path"`
-	Purpose string `yaml:"purpose"`
-	Tracked bool   `yaml:"tracked"`
+	Path    string `yaml:"path" json:"path"`
+	Purpose string `yaml:"purpose" json:"purpose"`
+	Tracked bool   `yaml:"tracked" json:"tracked"`
 }
 
 type LanguagesConfig struct {
-	Primary string `yaml:"primary"`
-	Version string `yaml:"version,omitempty"`
+	Primary string `yaml:"primary" json:"primary"`
+	Version string `yaml:"version,omitempty" json:"version,omitempty"`
 }
 
 type DependenciesConfig struct {
-	External []ExternalDepConfig `yaml:"external,omitempty"`
+	External []ExternalDepConfig `yaml:"external,omitempty" json:"external,omitempty"`
 }
 
 type ExternalDepConfig struct {
-	Name    string `yaml:"name"`
-	Purpose string `yaml:"purpose"`
+	Name    string `yaml:"name" json:"name"`
+	Purpose string `yaml:"purpose" json:"purpose"`
 }
 
 type IntegrationConfig struct {
-	Name        string `yaml:"name"`
-	Type        string `yaml:"type"`
-	Interaction string `yaml:"interaction,omitempty"`
+	Name        string `yaml:"name" json:"name"`
+	Type        string `yaml:"type" json:"type"`
+	Interaction string `yaml:"interaction,omitempty" json:"interaction,omitempty"`
 }
 
 // ToolsConfig represents .checkpoint/tools.yml
 type ToolsConfig struct {
-	SchemaVersion string                 `yaml:"schema_version"`
-	Build         map[string]ToolCommand `yaml:"build,omitempty"`
-	Test          map[string]ToolCommand `yaml:"test,omitempty"`
-	Lint          map[string]ToolCommand `yaml:"lint,omitempty"`
-	Check         map[string]ToolCommand `yaml:"check,omitempty"`
-	Run           map[string]ToolCommand `yaml:"run,omitempty"`
-	Checkpoint    map[string]ToolCommand `yaml:"checkpoint,omitempty"`
-	Maintenance   map[string]ToolCommand `yaml:"maintenance,omitempty"`
+	SchemaVersion string                 `yaml:"schema_version" json:"schema_version"`
+	Build         map[string]ToolCommand `yaml:"build,omitempty" json:"build,omitempty"`
+	Test          map[string]ToolCommand `yaml:"test,omitempty" json:"test,omitempty"`
+	Lint          map[string]ToolCommand `yaml:"lint,omitempty" json:"lint,omitempty"`
+	Check         map[string]ToolCommand `yaml:"check,omitempty" json:"check,omitempty"`
+	Run           map[string]ToolCommand `yaml:"run,omitempty" json:"run,omitempty"`
+	Checkpoint    map[string]ToolCommand `yaml:"checkpoint,omitempty" json:"checkpoint,omitempty"`
+	Maintenance   map[string]ToolCommand `yaml:"maintenance,omitempty" json:"maintenance,omitempty"`
 }
 
 type ToolCommand struct {
-	Command string `yaml:"command"`
-	Output  string `yaml:"output,omitempty"`
-	Notes   string `yaml:"notes,omitempty"`
-	Example string `yaml:"example,omitempty"`
+	Command string `yaml:"command" json:"command"`
+	Output  string `yaml:"output,omitempty" json:"output,omitempty"`
+	Notes   string `yaml:"notes,omitempty" json:"notes,omitempty"`
+	Example string `yaml:"example,omitempty" json:"example,omitempty"`
 }
 
 // GuidelinesConfig represents .checkpoint/guidelines.yml
 // Uses interface{} for flexible nested structures
 type GuidelinesConfig struct {
-	SchemaVersion string                 `yaml:"schema_version"`
-	Naming        map[string]interface{} `yaml:"naming,omitempty"`
-	Structure     map[string]string      `yaml:"structure,omitempty"`
-	Errors        map[string]interface{} `yaml:"errors,omitempty"`
-	Testing       map[string]interface{} `yaml:"testing,omitempty"`
-	Commits       map[string]string      `yaml:"commits,omitempty"`
-	Rules         []string               `yaml:"rules,omitempty"`
-	Avoid         []string               `yaml:"avoid,omitempty"`
-	Principles    []string               `yaml:"principles,omitempty"`
+	SchemaVersion string                 `yaml:"schema_version" json:"schema_version"`
+	Naming        map[string]interface{} `yaml:"naming,omitempty" json:"naming,omitempty"`
+	Structure     map[string]string      `yaml:"structure,omitempty" json:"structure,omitempty"`
+	Errors        map[string]interface{} `yaml:"errors,omitempty" json:"errors,omitempty"`
+	Testing       map[string]interface{} `yaml:"testing,omitempty" json:"testing,omitempty"`
+	Commits       map[string]string      `yaml:"commits,omitempty" json:"commits,omitempty"`
+	Rules         GuidelineList          `yaml:"rules,omitempty" json:"rules,omitempty"`
+	Avoid         GuidelineList          `yaml:"avoid,omitempty" json:"avoid,omitempty"`
+	Principles    GuidelineList          `yaml:"principles,omitempty" json:"principles,omitempty"`
 }
 
 // SkillsConfig represents .checkpoint/skills.yml
 type SkillsConfig struct {
-	SchemaVersion string                 `yaml:"schema_version"`
-	Global        []string               `yaml:"global,omitempty"`
-	Local         []string               `yaml:"local,omitempty"`
-	Config        map[string]interface{} `yaml:"config,omitempty"`
-	AutoDetect    AutoDetectConfig       `yaml:"auto_detect,omitempty"`
+	SchemaVersion string                 `yaml:"schema_version" json:"schema_version"`
+	Global        []string               `yaml:"global,omitempty" json:"global,omitempty"`
+	Local         []string               `yaml:"local,omitempty" json:"local,omitempty"`
+	Config        map[string]interface{} `yaml:"config,omitempty" json:"config,omitempty"`
+	AutoDetect    AutoDetectConfig       `yaml:"auto_detect,omitempty" json:"auto_detect,omitempty"`
 }
 
 type AutoDetectConfig struct {
-	IncludeInExplain []string `yaml:"include_in_explain,omitempty"`
+	IncludeInExplain []string `yaml:"include_in_explain,omitempty" json:"include_in_explain,omitempty"`
 }
 
 // Skill represents a skill definition from skill.md
 type Skill struct {
-	Name    string
-	Path    string
-	Content string
-	IsLocal bool
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Content string `json:"content"`
+	IsLocal bool   `json:"is_local"`
 }
 
 // Learning represents a captured insight from learnings.yml
 type Learning struct {
-	Timestamp string `yaml:"timestamp"`
-	Learning  string `yaml:"learning"`
+	Timestamp string   `yaml:"timestamp" json:"timestamp"`
+	Learning  string   `yaml:"learning" json:"learning"`
+	Tags      []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Scope     string   `yaml:"scope,omitempty" json:"scope,omitempty"`
+	Ref       string   `yaml:"ref,omitempty" json:"ref,omitempty"` // related commit
 }
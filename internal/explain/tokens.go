@@ -0,0 +1,49 @@
+package explain
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// charsPerToken is the rough characters-per-token ratio of common LLM tokenizers
+const charsPerToken = 4
+
+// SectionTokens is the estimated token count of one top-level section
+type SectionTokens struct {
+	Section string `json:"section"`
+	Tokens  int    `json:"tokens"`
+}
+
+// EstimateTokens approximates the token count of s as characters / 4
+func EstimateTokens(s string) int {
+	n := utf8.RuneCountInString(s)
+	return (n + charsPerToken - 1) / charsPerToken
+}
+
+// EstimateSectionTokens splits rendered output at top-level "# " headings
+// (outside code fences) and estimates each part. Text before the first
+// heading is reported as "(preamble)".
+func EstimateSectionTokens(output string) []SectionTokens {
+	var sections []SectionTokens
+	var sb strings.Builder
+	name := "(preamble)"
+	inFence := false
+	flush := func() {
+		if strings.TrimSpace(sb.String()) != "" {
+			sections = append(sections, SectionTokens{Section: name, Tokens: EstimateTokens(sb.String())})
+		}
+		sb.Reset()
+	}
+	for _, line := range strings.SplitAfter(output, "\n") {
+		if strings.HasPrefix(line, "```") {
+			inFence = !inFence
+		}
+		if !inFence && strings.HasPrefix(line, "# ") {
+			flush()
+			name = strings.TrimSpace(strings.TrimPrefix(line, "# "))
+		}
+		sb.WriteString(line)
+	}
+	flush()
+	return sections
+}
@@ -0,0 +1,29 @@
+package explain
+
+import "testing"
+
+func TestEstimateSectionTokens(t *testing.T) {
+	if got := EstimateTokens("abcdefgh"); got != 2 {
+		t.Errorf("EstimateTokens(8 chars) = %d, want 2", got)
+	}
+	if got := EstimateTokens("abcde"); got != 2 {
+		t.Errorf("EstimateTokens(5 chars) = %d, want 2 (rounded up)", got)
+	}
+
+	output := "intro\n# Project\n## Purpose\nText\n```sh\n# not a heading\n```\n# Tools\nmake\n"
+	sections := EstimateSectionTokens(output)
+	want := []string{"(preamble)", "Project", "Tools"}
+	if len(sections) != len(want) {
+		t.Fatalf("sections = %+v, want %v", sections, want)
+	}
+	total := 0
+	for i, s := range sections {
+		if s.Section != want[i] {
+			t.Errorf("section %d = %q, want %q", i, s.Section, want[i])
+		}
+		total += s.Tokens
+	}
+	if total < EstimateTokens(output) {
+		t.Errorf("section total %d below whole-output estimate %d", total, EstimateTokens(output))
+	}
+}
@@ -0,0 +1,128 @@
+package explain
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dmoose/checkpoint/pkg/config"
+)
+
+// SkillUsage is one skill's access record in .checkpoint/.skill-usage.json
+type SkillUsage struct {
+	Count    int    `json:"count"`
+	LastUsed string `json:"last_used"`
+}
+
+// SkillUsageEntry pairs a configured skill with its usage for reporting
+type SkillUsageEntry struct {
+	Name     string `json:"name"`
+	IsLocal  bool   `json:"local"`
+	Count    int    `json:"count"`
+	LastUsed string `json:"last_used,omitempty"`
+}
+
+func skillUsagePath(projectPath string) string {
+	return filepath.Join(projectPath, config.CheckpointDir, config.SkillUsageFileName)
+}
+
+// LoadSkillUsage reads the usage counters; a missing file means no usage yet
+func LoadSkillUsage(projectPath string) (map[string]SkillUsage, error) {
+	usage := map[string]SkillUsage{}
+	data, err := os.ReadFile(skillUsagePath(projectPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return usage, nil
+		}
+		return nil, fmt.Errorf("read skill usage: %w", err)
+	}
+	if err := json.Unmarshal(data, &usage); err != nil {
+		return nil, fmt.Errorf("parse skill usage: %w", err)
+	}
+	return usage, nil
+}
+
+// RecordSkillUsage increments the view count for a skill; projects without
+// a .checkpoint directory are not tracked
+func RecordSkillUsage(projectPath, name string, now time.Time) error {
+	if _, err := os.Stat(filepath.Join(projectPath, config.CheckpointDir)); err != nil {
+		return nil
+	}
+	usage, err := LoadSkillUsage(projectPath)
+	if err != nil {
+		return err
+	}
+	u := usage[name]
+	u.Count++
+	u.LastUsed = now.Format(time.RFC3339)
+	usage[name] = u
+
+	data, err := json.MarshalIndent(usage, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal skill usage: %w", err)
+	}
+	path := skillUsagePath(projectPath)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("write skill usage: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("replace skill usage: %w", err)
+	}
+	return nil
+}
+
+// SkillUsageReport lists configured skills by access count (most used
+// first, then by name), including skills that were never viewed
+func (e *ExplainOutput) SkillUsageReport(usage map[string]SkillUsage) []SkillUsageEntry {
+	entries := make([]SkillUsageEntry, 0, len(e.SkillDefs))
+	for _, s := range e.SkillDefs {
+		u := usage[s.Name]
+		entries = append(entries, SkillUsageEntry{Name: s.Name, IsLocal: s.IsLocal, Count: u.Count, LastUsed: u.LastUsed})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Count != entries[j].Count {
+			return entries[i].Count > entries[j].Count
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// RenderSkillUsage renders the usage report, flagging never-viewed skills
+func (e *ExplainOutput) RenderSkillUsage(usage map[string]SkillUsage) string {
+	var sb strings.Builder
+	sb.WriteString("# Skill Usage\n\n")
+
+	entries := e.SkillUsageReport(usage)
+	if len(entries) == 0 {
+		sb.WriteString("No skills configured.\n")
+		return sb.String()
+	}
+
+	var unused []string
+	for _, u := range entries {
+		if u.Count == 0 {
+			unused = append(unused, u.Name)
+			continue
+		}
+		last := u.LastUsed
+		if len(last) > 10 {
+			last = last[:10] // Just the date
+		}
+		sb.WriteString(fmt.Sprintf("- **%s** - %d view(s), last %s\n", u.Name, u.Count, last))
+	}
+	if len(unused) > 0 {
+		sb.WriteString("\n## Never Viewed (candidates for removal)\n\n")
+		for _, name := range unused {
+			sb.WriteString(fmt.Sprintf("- %s\n", name))
+		}
+	}
+	sb.WriteString("\nViews are counted by 'checkpoint explain skill <name>' and 'checkpoint skill show <name>'.\n")
+	return sb.String()
+}
@@ -0,0 +1,60 @@
+package explain
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dmoose/checkpoint/pkg/config"
+)
+
+func TestSkillUsage(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// Without .checkpoint nothing is recorded
+	if err := RecordSkillUsage(tmpDir, "go", time.Now()); err != nil {
+		t.Fatalf("RecordSkillUsage: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, config.CheckpointDir)); !os.IsNotExist(err) {
+		t.Fatalf("usage tracking should not create .checkpoint")
+	}
+
+	if err := os.MkdirAll(filepath.Join(tmpDir, config.CheckpointDir), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	day1 := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	day2 := time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC)
+	for _, rec := range []struct {
+		name string
+		at   time.Time
+	}{{"go", day1}, {"docker", day1}, {"go", day2}} {
+		if err := RecordSkillUsage(tmpDir, rec.name, rec.at); err != nil {
+			t.Fatalf("RecordSkillUsage: %v", err)
+		}
+	}
+
+	usage, err := LoadSkillUsage(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadSkillUsage: %v", err)
+	}
+	if usage["go"].Count != 2 || usage["go"].LastUsed != day2.Format(time.RFC3339) {
+		t.Errorf("unexpected go usage: %+v", usage["go"])
+	}
+
+	ctx := &ExplainOutput{SkillDefs: []Skill{{Name: "unused"}, {Name: "docker"}, {Name: "go", IsLocal: true}}}
+	report := ctx.SkillUsageReport(usage)
+	var order []string
+	for _, e := range report {
+		order = append(order, e.Name)
+	}
+	if strings.Join(order, ",") != "go,docker,unused" {
+		t.Errorf("expected skills ordered by count, got %v", order)
+	}
+
+	out := ctx.RenderSkillUsage(usage)
+	if !strings.Contains(out, "**go** - 2 view(s), last 2025-01-02") || !strings.Contains(out, "candidates for removal)\n\n- unused\n") {
+		t.Errorf("unexpected usage render:\n%s", out)
+	}
+}
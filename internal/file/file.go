@@ -1,10 +1,14 @@
 package file
 
 import (
+	"errors"
 	"fmt"
 	"os"
 )
 
+// ErrLocked is returned by CreateLock when the lock file already exists
+var ErrLocked = errors.New("lock is held")
+
 func ReadFile(path string) (string, error) {
 	b, err := os.ReadFile(path)
 	if err != nil {
@@ -25,6 +29,23 @@ func Exists(path string) bool {
 	return err == nil
 }
 
+// CreateLock atomically creates the lock file at path with content, failing
+// with ErrLocked when it already exists
+func CreateLock(path, content string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return ErrLocked
+		}
+		return fmt.Errorf("create lock: %w", err)
+	}
+	if _, err := f.WriteString(content); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write lock: %w", err)
+	}
+	return f.Close()
+}
+
 // FindWithFallback checks for a file at the primary path, then falls back to legacy path.
 // Returns the path that exists, or the primary path if neither exists.
 func FindWithFallback(primary, legacy string) string {
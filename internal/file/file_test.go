@@ -1,6 +1,7 @@
 package file
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -34,3 +35,22 @@ func TestReadWriteExists(t *testing.T) {
 		t.Fatalf("unexpected file mode: %v", fi.Mode())
 	}
 }
+
+func TestCreateLock(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "test.lock")
+	if err := CreateLock(p, "pid=1\n"); err != nil {
+		t.Fatalf("CreateLock: %v", err)
+	}
+	if err := CreateLock(p, "pid=2\n"); !errors.Is(err, ErrLocked) {
+		t.Fatalf("expected ErrLocked, got %v", err)
+	}
+	if got, _ := ReadFile(p); got != "pid=1\n" {
+		t.Errorf("held lock was overwritten: %q", got)
+	}
+	if err := os.Remove(p); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if err := CreateLock(p, "pid=3\n"); err != nil {
+		t.Errorf("expected the released lock to be acquirable: %v", err)
+	}
+}
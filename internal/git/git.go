@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os/exec"
+	"path/filepath"
 	"strings"
 )
 
@@ -156,6 +157,93 @@ func StageAll(path string) error {
 	return nil
 }
 
+// GetCommitAuthor returns the author name recorded for a commit
+func GetCommitAuthor(path, hash string) (string, error) {
+	out, err := runGit(path, []string{"log", "-1", "--format=%an", hash})
+	if err != nil {
+		return "", fmt.Errorf("git log %s: %w", hash, err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// IsAncestor reports whether commit is reachable from descendant. A commit
+// that no longer exists (e.g. dropped by a rebase and pruned) is not.
+func IsAncestor(path, commit, descendant string) (bool, error) {
+	out, err := runGit(path, []string{"merge-base", "--is-ancestor", commit, descendant})
+	if err == nil {
+		return true, nil
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return false, nil
+	}
+	if _, catErr := runGit(path, []string{"cat-file", "-e", commit + "^{commit}"}); catErr != nil {
+		return false, nil
+	}
+	return false, fmt.Errorf("git merge-base --is-ancestor %s %s: %s", commit, descendant, strings.TrimSpace(out))
+}
+
+// HeadCommit returns the hash and subject line of HEAD
+func HeadCommit(path string) (string, string, error) {
+	out, err := runGit(path, []string{"log", "-1", "--format=%H%n%s"})
+	if err != nil {
+		return "", "", fmt.Errorf("git log -1: %w", err)
+	}
+	hash, subject, _ := strings.Cut(strings.TrimSpace(out), "\n")
+	return hash, subject, nil
+}
+
+// HooksDir returns the absolute hooks directory, honouring core.hooksPath
+// and linked worktrees
+func HooksDir(path string) (string, error) {
+	return GitPath(path, "hooks")
+}
+
+// GitPath returns the absolute path of name inside the git directory, where
+// files are never staged
+func GitPath(path, name string) (string, error) {
+	out, err := runGit(path, []string{"rev-parse", "--git-path", name})
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse --git-path %s: %w: %s", name, err, strings.TrimSpace(out))
+	}
+	p := strings.TrimSpace(out)
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(path, p)
+	}
+	return p, nil
+}
+
+// ResetSoft moves HEAD back to rev, keeping the index and working tree
+func ResetSoft(path, rev string) error {
+	if out, err := runGit(path, []string{"reset", "--soft", rev}); err != nil {
+		return fmt.Errorf("git reset --soft %s: %w: %s", rev, err, strings.TrimSpace(out))
+	}
+	return nil
+}
+
+// Stash is one entry of 'git stash list'
+type Stash struct {
+	Ref     string // e.g. stash@{0}
+	Message string // e.g. "On main: wip parser"
+}
+
+// StashList returns the repository's stashes, newest first; none is not an error
+func StashList(path string) ([]Stash, error) {
+	out, err := runGit(path, []string{"stash", "list", "--format=%gd%x00%gs"})
+	if err != nil {
+		return nil, fmt.Errorf("git stash list: %w: %s", err, strings.TrimSpace(out))
+	}
+	var stashes []Stash
+	for _, line := range strings.Split(out, "\n") {
+		ref, msg, ok := strings.Cut(line, "\x00")
+		if !ok {
+			continue
+		}
+		stashes = append(stashes, Stash{Ref: ref, Message: msg})
+	}
+	return stashes, nil
+}
+
 // Commit creates a git commit with the given message
 func Commit(path, message string) (string, error) {
 	cmd := exec.Command("git", "commit", "-m", message)
@@ -178,3 +266,45 @@ func Commit(path, message string) (string, error) {
 	}
 	return strings.TrimSpace(hashOut.String()), nil
 }
+
+// CommitAmend folds the staged changes into HEAD, replacing its message,
+// and returns the new commit hash
+func CommitAmend(path, message string) (string, error) {
+	if out, err := runGit(path, []string{"commit", "--amend", "-m", message}); err != nil {
+		return "", fmt.Errorf("git commit --amend: %w: %s", err, strings.TrimSpace(out))
+	}
+	out, err := runGit(path, []string{"rev-parse", "HEAD"})
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse HEAD: %w", err)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// ValidateTagName checks that name is a well-formed git tag name
+func ValidateTagName(path, name string) error {
+	if out, err := runGit(path, []string{"check-ref-format", "refs/tags/" + name}); err != nil {
+		return fmt.Errorf("invalid tag name %q: %s", name, strings.TrimSpace(out))
+	}
+	return nil
+}
+
+// TagExists reports whether a tag with the given name already exists
+func TagExists(path, name string) (bool, error) {
+	out, err := runGit(path, []string{"tag", "--list", name})
+	if err != nil {
+		return false, fmt.Errorf("git tag --list: %w", err)
+	}
+	return strings.TrimSpace(out) != "", nil
+}
+
+// CreateTag tags the given commit. A non-empty message creates an annotated tag.
+func CreateTag(path, name, hash, message string) error {
+	args := []string{"tag", name, hash}
+	if message != "" {
+		args = []string{"tag", "-a", name, "-m", message, hash}
+	}
+	if out, err := runGit(path, args); err != nil {
+		return fmt.Errorf("git tag %s: %w: %s", name, err, strings.TrimSpace(out))
+	}
+	return nil
+}
@@ -197,6 +197,41 @@ func TestCommit(t *testing.T) {
 	}
 }
 
+func TestCommitAmend(t *testing.T) {
+	tmpDir, cleanup := setupGitRepo(t)
+	defer cleanup()
+
+	testFile := filepath.Join(tmpDir, "test.txt")
+	if err := os.WriteFile(testFile, []byte("one\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	runGitCmd(t, tmpDir, "add", "test.txt")
+	first, err := Commit(tmpDir, "first")
+	if err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+
+	if err := os.WriteFile(testFile, []byte("two\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	runGitCmd(t, tmpDir, "add", "test.txt")
+	amended, err := CommitAmend(tmpDir, "first, amended")
+	if err != nil {
+		t.Fatalf("CommitAmend: %v", err)
+	}
+	if amended == first || len(amended) != 40 {
+		t.Errorf("expected a new 40-character hash, got %s", amended)
+	}
+
+	// The amended commit replaces HEAD rather than adding to history
+	if count := strings.TrimSpace(runGitCmd(t, tmpDir, "rev-list", "--count", "HEAD")); count != "1" {
+		t.Errorf("expected a single commit, got %s", count)
+	}
+	if subject := strings.TrimSpace(runGitCmd(t, tmpDir, "log", "-1", "--pretty=format:%s")); subject != "first, amended" {
+		t.Errorf("unexpected subject %q", subject)
+	}
+}
+
 func TestGetStatus(t *testing.T) {
 	tmpDir, cleanup := setupGitRepo(t)
 	defer cleanup()
@@ -283,3 +318,140 @@ func runGitCmd(t *testing.T, dir string, args ...string) string {
 	}
 	return string(output)
 }
+
+func TestCreateTag(t *testing.T) {
+	tmpDir, cleanup := setupGitRepo(t)
+	defer cleanup()
+
+	if err := os.WriteFile(filepath.Join(tmpDir, "test.txt"), []byte("test\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	runGitCmd(t, tmpDir, "add", "test.txt")
+	hash, err := Commit(tmpDir, "Initial commit")
+	if err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+
+	if err := ValidateTagName(tmpDir, "v1.2.0"); err != nil {
+		t.Errorf("expected v1.2.0 to be valid: %v", err)
+	}
+	if err := ValidateTagName(tmpDir, "bad..name"); err == nil {
+		t.Errorf("expected bad..name to be rejected")
+	}
+
+	if exists, err := TagExists(tmpDir, "v1.2.0"); err != nil || exists {
+		t.Fatalf("expected tag to not exist yet (exists=%v, err=%v)", exists, err)
+	}
+	if err := CreateTag(tmpDir, "v1.2.0", hash, ""); err != nil {
+		t.Fatalf("CreateTag lightweight: %v", err)
+	}
+	if err := CreateTag(tmpDir, "v1.2.1", hash, "Release 1.2.1"); err != nil {
+		t.Fatalf("CreateTag annotated: %v", err)
+	}
+	if exists, err := TagExists(tmpDir, "v1.2.0"); err != nil || !exists {
+		t.Errorf("expected tag to exist (exists=%v, err=%v)", exists, err)
+	}
+
+	if got := strings.TrimSpace(runGitCmd(t, tmpDir, "cat-file", "-t", "v1.2.1")); got != "tag" {
+		t.Errorf("expected annotated tag object, got %q", got)
+	}
+	if got := strings.TrimSpace(runGitCmd(t, tmpDir, "rev-list", "-n", "1", "v1.2.0")); got != hash {
+		t.Errorf("expected tag to point at %s, got %s", hash, got)
+	}
+}
+
+func TestIsAncestor(t *testing.T) {
+	tmpDir, cleanup := setupGitRepo(t)
+	defer cleanup()
+
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(name+"\n"), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		runGitCmd(t, tmpDir, "add", name)
+		runGitCmd(t, tmpDir, "commit", "-m", "add "+name)
+	}
+	first := strings.TrimSpace(runGitCmd(t, tmpDir, "rev-parse", "HEAD~1"))
+	second := strings.TrimSpace(runGitCmd(t, tmpDir, "rev-parse", "HEAD"))
+
+	if ok, err := IsAncestor(tmpDir, first, "HEAD"); err != nil || !ok {
+		t.Errorf("expected first commit reachable, got %v, %v", ok, err)
+	}
+
+	// Rewrite history: the old tip still exists but is no longer reachable
+	runGitCmd(t, tmpDir, "reset", "--hard", "HEAD~1")
+	if ok, err := IsAncestor(tmpDir, second, "HEAD"); err != nil || ok {
+		t.Errorf("expected rewritten commit unreachable, got %v, %v", ok, err)
+	}
+
+	if ok, err := IsAncestor(tmpDir, "0123456789abcdef0123456789abcdef01234567", "HEAD"); err != nil || ok {
+		t.Errorf("expected unknown commit unreachable, got %v, %v", ok, err)
+	}
+}
+
+func TestHeadCommitAndResetSoft(t *testing.T) {
+	tmpDir, cleanup := setupGitRepo(t)
+	defer cleanup()
+
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(name+"\n"), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		runGitCmd(t, tmpDir, "add", name)
+		runGitCmd(t, tmpDir, "commit", "-m", "add "+name)
+	}
+
+	hash, subject, err := HeadCommit(tmpDir)
+	if err != nil {
+		t.Fatalf("HeadCommit: %v", err)
+	}
+	if subject != "add b.txt" || hash != strings.TrimSpace(runGitCmd(t, tmpDir, "rev-parse", "HEAD")) {
+		t.Errorf("unexpected HEAD %s %q", hash, subject)
+	}
+
+	if err := ResetSoft(tmpDir, "HEAD~1"); err != nil {
+		t.Fatalf("ResetSoft: %v", err)
+	}
+	if _, subject, _ := HeadCommit(tmpDir); subject != "add a.txt" {
+		t.Errorf("expected HEAD moved back, got %q", subject)
+	}
+	// The undone commit's changes stay staged
+	if status := runGitCmd(t, tmpDir, "status", "--porcelain"); !strings.Contains(status, "A  b.txt") {
+		t.Errorf("expected b.txt staged, got %q", status)
+	}
+}
+
+func TestStashList(t *testing.T) {
+	tmpDir, cleanup := setupGitRepo(t)
+	defer cleanup()
+
+	path := filepath.Join(tmpDir, "a.txt")
+	if err := os.WriteFile(path, []byte("a\n"), 0644); err != nil {
+		t.Fatalf("failed to write a.txt: %v", err)
+	}
+	runGitCmd(t, tmpDir, "add", "a.txt")
+	runGitCmd(t, tmpDir, "commit", "-m", "add a.txt")
+
+	stashes, err := StashList(tmpDir)
+	if err != nil {
+		t.Fatalf("StashList: %v", err)
+	}
+	if len(stashes) != 0 {
+		t.Errorf("expected no stashes, got %+v", stashes)
+	}
+
+	for _, msg := range []string{"first wip", "second wip"} {
+		if err := os.WriteFile(path, []byte(msg+"\n"), 0644); err != nil {
+			t.Fatalf("failed to write a.txt: %v", err)
+		}
+		runGitCmd(t, tmpDir, "stash", "push", "-m", msg)
+	}
+
+	stashes, err = StashList(tmpDir)
+	if err != nil {
+		t.Fatalf("StashList: %v", err)
+	}
+	if len(stashes) != 2 || stashes[0].Ref != "stash@{0}" || !strings.HasSuffix(stashes[0].Message, "second wip") {
+		t.Errorf("unexpected stashes: %+v", stashes)
+	}
+}
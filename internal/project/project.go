@@ -3,6 +3,7 @@ package project
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/dmoose/checkpoint/internal/language"
@@ -527,3 +528,37 @@ func RenderProjectDocumentForLLM(projectPath string) (string, error) {
 
 	return contentStr, nil
 }
+
+// UpdateProjectDocument applies update to the main project document and
+// rewrites it in place, leaving any appended recommendation documents intact
+func UpdateProjectDocument(projectPath string, update func(doc *ProjectDocument)) error {
+	content, err := os.ReadFile(projectPath)
+	if err != nil {
+		return fmt.Errorf("read project file: %w", err)
+	}
+
+	doc, err := ReadProjectDocument(projectPath)
+	if err != nil {
+		return err
+	}
+	update(doc)
+	doc.LastUpdated = time.Now().Format(time.RFC3339)
+
+	yamlData, err := yaml.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("marshal project document: %w", err)
+	}
+
+	// Keep everything from the second document separator onward
+	rest := ""
+	body := strings.TrimPrefix(string(content), "---\n")
+	if idx := strings.Index(body, "\n---\n"); idx >= 0 {
+		rest = body[idx+1:]
+	}
+
+	newContent := "---\n" + string(yamlData) + rest
+	if err := os.WriteFile(projectPath, []byte(newContent), 0644); err != nil {
+		return fmt.Errorf("write project file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package prompts
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffLine is one line of an edit script: ' ' kept, '-' removed, '+' added
+type diffLine struct {
+	kind byte
+	text string
+	a, b int // 0-based position in old and new text before this line
+}
+
+// UnifiedDiff renders a unified diff of two texts with the given lines of
+// context, or "" when they are equal
+func UnifiedDiff(oldName, newName, oldText, newText string, context int) string {
+	a, b := splitLines(oldText), splitLines(newText)
+	script := diffLines(a, b)
+
+	var changes []int
+	for i, l := range script {
+		if l.kind != ' ' {
+			changes = append(changes, i)
+		}
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("--- %s\n+++ %s\n", oldName, newName))
+	for i := 0; i < len(changes); {
+		// Extend the hunk while the next change is within two contexts
+		j := i
+		for j+1 < len(changes) && changes[j+1]-changes[j] <= 2*context {
+			j++
+		}
+		start := max(changes[i]-context, 0)
+		end := min(changes[j]+context+1, len(script))
+		writeHunk(&sb, script[start:end])
+		i = j + 1
+	}
+	return sb.String()
+}
+
+func writeHunk(sb *strings.Builder, hunk []diffLine) {
+	oldCount, newCount := 0, 0
+	for _, l := range hunk {
+		if l.kind != '+' {
+			oldCount++
+		}
+		if l.kind != '-' {
+			newCount++
+		}
+	}
+	// An empty range is numbered from the line before it
+	oldStart, newStart := hunk[0].a+1, hunk[0].b+1
+	if oldCount == 0 {
+		oldStart--
+	}
+	if newCount == 0 {
+		newStart--
+	}
+	sb.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount))
+	for _, l := range hunk {
+		sb.WriteByte(l.kind)
+		sb.WriteString(l.text)
+		sb.WriteByte('\n')
+	}
+}
+
+// diffLines builds an edit script from the longest common subsequence
+func diffLines(a, b []string) []diffLine {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var script []diffLine
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			script = append(script, diffLine{' ', a[i], i, j})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			script = append(script, diffLine{'-', a[i], i, j})
+			i++
+		default:
+			script = append(script, diffLine{'+', b[j], i, j})
+			j++
+		}
+	}
+	return script
+}
+
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
@@ -0,0 +1,32 @@
+package prompts
+
+import "testing"
+
+func TestUnifiedDiff(t *testing.T) {
+	if diff := UnifiedDiff("a", "b", "same\ntext\n", "same\ntext\n", 3); diff != "" {
+		t.Errorf("expected no diff for equal text, got:\n%s", diff)
+	}
+
+	old := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	new := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n"
+	want := `--- a
++++ b
+@@ -2,3 +2,3 @@
+ 2
+-3
++three
+ 4
+@@ -12,1 +12,2 @@
+ 12
++13
+`
+	if diff := UnifiedDiff("a", "b", old, new, 1); diff != want {
+		t.Errorf("UnifiedDiff() =\n%s\nwant:\n%s", diff, want)
+	}
+
+	// Insertion into an empty file numbers the old range from line 0
+	want = "--- a\n+++ b\n@@ -0,0 +1,1 @@\n+x\n"
+	if diff := UnifiedDiff("a", "b", "", "x\n", 3); diff != want {
+		t.Errorf("UnifiedDiff() =\n%s\nwant:\n%s", diff, want)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -123,14 +124,94 @@ func LoadPromptTemplate(promptsDir string, filename string) (string, error) {
 	return string(data), nil
 }
 
+// AutomaticVariables are provided by the prompt command for every prompt
+var AutomaticVariables = []string{"project_name", "project_path"}
+
+var (
+	variablePattern   = regexp.MustCompile(`\{\{([a-z_][a-z0-9_]*)\}\}`)
+	placeholderSyntax = regexp.MustCompile(`\{\{([^{}]*)\}\}`)
+)
+
+// ValidationIssue describes a problem found in one prompt definition
+type ValidationIssue struct {
+	PromptID string `json:"prompt_id"`
+	Message  string `json:"message"`
+}
+
+// ValidatePrompts checks the prompt library for duplicate or missing ids, missing
+// or empty template files, and {{variables}} that are used but not declared
+// (or declared but never used)
+func ValidatePrompts(config *PromptsConfig, promptsDir string) []ValidationIssue {
+	var issues []ValidationIssue
+	seen := make(map[string]bool)
+
+	for i, p := range config.Prompts {
+		id := p.ID
+		if id == "" {
+			id = fmt.Sprintf("prompts[%d]", i)
+			issues = append(issues, ValidationIssue{id, "missing id"})
+		} else if seen[id] {
+			issues = append(issues, ValidationIssue{id, "duplicate id"})
+		}
+		seen[p.ID] = true
+
+		if p.File == "" {
+			issues = append(issues, ValidationIssue{id, "missing file"})
+			continue
+		}
+		body, err := LoadPromptTemplate(promptsDir, p.File)
+		if err != nil {
+			issues = append(issues, ValidationIssue{id, fmt.Sprintf("template file %s not readable", p.File)})
+			continue
+		}
+		if strings.TrimSpace(body) == "" {
+			issues = append(issues, ValidationIssue{id, fmt.Sprintf("template file %s is empty", p.File)})
+			continue
+		}
+
+		declared := make(map[string]bool)
+		for _, v := range p.Variables {
+			declared[v] = true
+		}
+		available := make(map[string]bool)
+		for _, v := range AutomaticVariables {
+			available[v] = true
+		}
+		for v := range config.Variables {
+			available[v] = true
+		}
+
+		used := make(map[string]bool)
+		for _, m := range placeholderSyntax.FindAllStringSubmatch(body, -1) {
+			name := m[1]
+			if !variablePattern.MatchString("{{" + name + "}}") {
+				issues = append(issues, ValidationIssue{id, fmt.Sprintf("invalid variable syntax {{%s}} (use lowercase letters, digits, underscores)", name)})
+				continue
+			}
+			if used[name] {
+				continue
+			}
+			used[name] = true
+			if !declared[name] && !available[name] {
+				issues = append(issues, ValidationIssue{id, fmt.Sprintf("variable {{%s}} is used but not declared", name)})
+			}
+		}
+		for _, v := range p.Variables {
+			if !used[v] {
+				issues = append(issues, ValidationIssue{id, fmt.Sprintf("variable %s is declared but never used", v)})
+			}
+		}
+	}
+
+	return issues
+}
+
 // SubstituteVariables performs simple variable substitution in the template
 // Variables are in the format {{variable_name}} and are replaced with values from the vars map
 // Unknown variables are replaced with empty strings
 func SubstituteVariables(template string, vars map[string]string) string {
 	// Match {{variable_name}} where variable_name is [a-z_][a-z0-9_]*
-	re := regexp.MustCompile(`\{\{([a-z_][a-z0-9_]*)\}\}`)
-
-	result := re.ReplaceAllStringFunc(template, func(match string) string {
+	result := variablePattern.ReplaceAllStringFunc(template, func(match string) string {
 		// Extract variable name (remove {{ and }})
 		varName := match[2 : len(match)-2]
 
@@ -254,3 +254,56 @@ func TestLoadPromptTemplateMissing(t *testing.T) {
 		t.Error("LoadPromptTemplate() expected error for missing file, got nil")
 	}
 }
+
+func TestValidatePrompts(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	files := map[string]string{
+		"good.md":  "Hello {{project_name}}, fix {{bug}} in {{primary_language}}",
+		"typo.md":  "Implement {{feature_nmae}} with {{Feature}}",
+		"empty.md": "  \n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	config := &PromptsConfig{
+		SchemaVersion: "1",
+		Variables:     map[string]string{"primary_language": "Go"},
+		Prompts: []PromptDefinition{
+			{ID: "good", File: "good.md", Variables: []string{"bug"}},
+			{ID: "typo", File: "typo.md", Variables: []string{"feature_name"}},
+			{ID: "good", File: "good.md", Variables: []string{"bug"}},
+			{ID: "empty", File: "empty.md"},
+			{ID: "missing", File: "missing.md"},
+		},
+	}
+
+	issues := ValidatePrompts(config, tmpDir)
+
+	want := []ValidationIssue{
+		{"typo", "invalid variable syntax {{Feature}} (use lowercase letters, digits, underscores)"},
+		{"typo", "variable {{feature_nmae}} is used but not declared"},
+		{"typo", "variable feature_name is declared but never used"},
+		{"good", "duplicate id"},
+		{"empty", "template file empty.md is empty"},
+		{"missing", "template file missing.md not readable"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("ValidatePrompts() returned %d issues, want %d: %+v", len(issues), len(want), issues)
+	}
+	for _, w := range want {
+		found := false
+		for _, got := range issues {
+			if got == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("missing issue %+v in %+v", w, issues)
+		}
+	}
+}
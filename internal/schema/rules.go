@@ -0,0 +1,122 @@
+package schema
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/dmoose/checkpoint/pkg/config"
+)
+
+// defaultChangeTypes are the change_type values allowed without a schema.yml
+var defaultChangeTypes = []string{"feature", "fix", "refactor", "docs", "perf", "other"}
+
+// Rules are the project-tunable validation limits
+type Rules struct {
+	MaxSummaryLength int
+	ChangeTypes      []string // allowed change_type values, in prompt order
+	DefaultType      string   // prompt default; empty uses the first change type
+}
+
+// RulesConfig is the optional .checkpoint/schema.yml
+type RulesConfig struct {
+	// MaxSummaryLength overrides the default summary limit (80)
+	MaxSummaryLength int `yaml:"max_summary_length,omitempty"`
+	// ChangeTypes replaces the default change types
+	ChangeTypes []string `yaml:"change_types,omitempty"`
+	// ExtraChangeTypes are allowed in addition to ChangeTypes (or the defaults)
+	ExtraChangeTypes []string `yaml:"extra_change_types,omitempty"`
+}
+
+// DefaultRules are the built-in limits used when there is no schema.yml
+func DefaultRules() Rules {
+	return Rules{
+		MaxSummaryLength: MaxSummaryLength,
+		ChangeTypes:      append([]string(nil), defaultChangeTypes...),
+	}
+}
+
+// LoadRules reads .checkpoint/schema.yml, falling back to DefaultRules when
+// it is absent
+func LoadRules(projectPath string) (Rules, error) {
+	data, err := os.ReadFile(filepath.Join(projectPath, config.CheckpointDir, config.SchemaYaml))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return DefaultRules(), nil
+		}
+		return Rules{}, fmt.Errorf("read %s: %w", config.SchemaYaml, err)
+	}
+	var cfg RulesConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return Rules{}, fmt.Errorf("parse %s: %w", config.SchemaYaml, err)
+	}
+	return cfg.Rules()
+}
+
+// Rules applies the config on top of DefaultRules
+func (c RulesConfig) Rules() (Rules, error) {
+	rules := DefaultRules()
+	if c.MaxSummaryLength < 0 {
+		return Rules{}, fmt.Errorf("%s: max_summary_length must be positive (got %d)", config.SchemaYaml, c.MaxSummaryLength)
+	}
+	if c.MaxSummaryLength > 0 {
+		rules.MaxSummaryLength = c.MaxSummaryLength
+	}
+	if len(c.ChangeTypes) > 0 {
+		rules.ChangeTypes = nil
+	}
+	seen := make(map[string]bool)
+	for _, t := range rules.ChangeTypes {
+		seen[t] = true
+	}
+	for _, t := range append(append([]string(nil), c.ChangeTypes...), c.ExtraChangeTypes...) {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t == "" || strings.ContainsAny(t, " ,|") {
+			return Rules{}, fmt.Errorf("%s: invalid change type %q", config.SchemaYaml, t)
+		}
+		if !seen[t] {
+			seen[t] = true
+			rules.ChangeTypes = append(rules.ChangeTypes, t)
+		}
+	}
+	return rules, nil
+}
+
+// AllowsChangeType reports whether t is one of the allowed change types
+func (r Rules) AllowsChangeType(t string) bool {
+	for _, allowed := range r.ChangeTypes {
+		if t == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultChangeType is the change type an empty prompt answer selects
+func (r Rules) DefaultChangeType() string {
+	if r.DefaultType != "" && r.AllowsChangeType(r.DefaultType) {
+		return r.DefaultType
+	}
+	if len(r.ChangeTypes) == 0 {
+		return ""
+	}
+	return r.ChangeTypes[0]
+}
+
+// ChangeTypeList formats the allowed change types like ValidChangeTypes
+func (r Rules) ChangeTypeList() string {
+	return strings.Join(r.ChangeTypes, ", ")
+}
+
+// ApplyToTemplate rewrites the default change types and summary limit in a
+// generated input template so the instructions match these rules
+func (r Rules) ApplyToTemplate(content string) string {
+	return strings.NewReplacer(
+		ValidChangeTypes, r.ChangeTypeList(),
+		strings.Join(defaultChangeTypes, "|"), strings.Join(r.ChangeTypes, "|"),
+		fmt.Sprintf("(<%d chars)", MaxSummaryLength), fmt.Sprintf("(<%d chars)", r.MaxSummaryLength),
+	).Replace(content)
+}
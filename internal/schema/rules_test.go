@@ -0,0 +1,114 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dmoose/checkpoint/pkg/config"
+)
+
+func TestLoadRules(t *testing.T) {
+	dir := t.TempDir()
+	rules, err := LoadRules(dir)
+	if err != nil {
+		t.Fatalf("LoadRules: %v", err)
+	}
+	if rules.MaxSummaryLength != MaxSummaryLength || rules.ChangeTypeList() != ValidChangeTypes {
+		t.Errorf("expected defaults without schema.yml, got %+v", rules)
+	}
+
+	write := func(content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Join(dir, config.CheckpointDir), 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, config.CheckpointDir, config.SchemaYaml), []byte(content), 0644); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+
+	write("max_summary_length: 100\nextra_change_types: [build, CI, fix]\n")
+	rules, err = LoadRules(dir)
+	if err != nil {
+		t.Fatalf("LoadRules: %v", err)
+	}
+	if rules.MaxSummaryLength != 100 || rules.ChangeTypeList() != ValidChangeTypes+", build, ci" {
+		t.Errorf("expected extended defaults, got %+v", rules)
+	}
+
+	write("change_types: [feat, fix]\nextra_change_types: [chore]\n")
+	rules, err = LoadRules(dir)
+	if err != nil {
+		t.Fatalf("LoadRules: %v", err)
+	}
+	if rules.MaxSummaryLength != MaxSummaryLength || rules.ChangeTypeList() != "feat, fix, chore" {
+		t.Errorf("expected replaced types, got %+v", rules)
+	}
+
+	for _, bad := range []string{"max_summary_length: -1\n", "change_types: [\"\"]\n", "change_types: [\"a|b\"]\n", "change_types: {\n"} {
+		write(bad)
+		if _, err := LoadRules(dir); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestValidateEntryWithRules(t *testing.T) {
+	rules, err := RulesConfig{MaxSummaryLength: 10, ChangeTypes: []string{"feat", "chore"}}.Rules()
+	if err != nil {
+		t.Fatalf("Rules: %v", err)
+	}
+	entry := &CheckpointEntry{SchemaVersion: "1", Timestamp: "2025-01-01T00:00:00Z", Changes: []Change{{Summary: "Bump deps", ChangeType: "chore"}}}
+	if err := ValidateEntryWithRules(entry, rules); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateEntry(entry); err == nil {
+		t.Error("chore should be rejected by the default rules")
+	}
+
+	entry.Changes[0].ChangeType = "feature"
+	if err := ValidateEntryWithRules(entry, rules); err == nil || !strings.Contains(err.Error(), "valid: feat, chore") {
+		t.Errorf("expected invalid change_type listing the project types, got %v", err)
+	}
+
+	entry.Changes[0] = Change{Summary: "Bump all deps", ChangeType: "chore"}
+	if err := ValidateEntryWithRules(entry, rules); err == nil || !strings.Contains(err.Error(), "13 > 10") {
+		t.Errorf("expected summary too long, got %v", err)
+	}
+}
+
+func TestRulesApplyToTemplate(t *testing.T) {
+	rules, err := RulesConfig{MaxSummaryLength: 72, ExtraChangeTypes: []string{"ci"}}.Rules()
+	if err != nil {
+		t.Fatalf("Rules: %v", err)
+	}
+	out := rules.ApplyToTemplate(GenerateInputTemplate("M main.go", ".checkpoint-diff", nil))
+	for _, want := range []string{"Allowed change_type values: " + ValidChangeTypes + ", ci.", "(<72 chars)", "feature|fix|refactor|docs|perf|other|ci"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("template missing %q", want)
+		}
+	}
+
+	// The defaults leave the template unchanged
+	tmpl := GenerateInputTemplate("M main.go", ".checkpoint-diff", nil)
+	if DefaultRules().ApplyToTemplate(tmpl) != tmpl {
+		t.Error("default rules should not change the template")
+	}
+}
+
+func TestRulesDefaultChangeType(t *testing.T) {
+	rules := DefaultRules()
+	if got := rules.DefaultChangeType(); got != "feature" {
+		t.Errorf("expected first type by default, got %q", got)
+	}
+	rules.DefaultType = "fix"
+	if got := rules.DefaultChangeType(); got != "fix" {
+		t.Errorf("expected configured default, got %q", got)
+	}
+	rules.DefaultType = "chore"
+	if got := rules.DefaultChangeType(); got != "feature" {
+		t.Errorf("expected a disallowed default to be ignored, got %q", got)
+	}
+}
@@ -1,7 +1,9 @@
 package schema
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -13,28 +15,42 @@ import (
 )
 
 type FileChange struct {
-	Path      string `yaml:"path"`
-	Additions int    `yaml:"additions"`
-	Deletions int    `yaml:"deletions"`
+	Path      string `yaml:"path" json:"path"`
+	Additions int    `yaml:"additions" json:"additions"`
+	Deletions int    `yaml:"deletions" json:"deletions"`
 }
 
 type Change struct {
-	Summary    string `yaml:"summary"`
-	Details    string `yaml:"details,omitempty"`
-	ChangeType string `yaml:"change_type"`
-	Scope      string `yaml:"scope,omitempty"`
+	Summary    string   `yaml:"summary" json:"summary"`
+	Details    string   `yaml:"details,omitempty" json:"details,omitempty"`
+	ChangeType string   `yaml:"change_type" json:"change_type"`
+	Scope      string   `yaml:"scope,omitempty" json:"scope,omitempty"`
+	Breaking   bool     `yaml:"breaking,omitempty" json:"breaking,omitempty"`
+	Refs       []string `yaml:"refs,omitempty" json:"refs,omitempty"` // issue/ticket IDs, e.g. JIRA-123 or #456
 }
 
 type CheckpointEntry struct {
-	SchemaVersion string                    `yaml:"schema_version"`
-	Timestamp     string                    `yaml:"timestamp"`
-	CommitHash    string                    `yaml:"commit_hash,omitempty"`
-	GitStatus     string                    `yaml:"git_status,omitempty"`
-	DiffFile      string                    `yaml:"diff_file,omitempty"`
-	FilesChanged  []FileChange              `yaml:"files_changed,omitempty"`
-	Context       context.CheckpointContext `yaml:"context,omitempty"`
-	Changes       []Change                  `yaml:"changes"`
-	NextSteps     []NextStep                `yaml:"next_steps,omitempty"`
+	SchemaVersion string                    `yaml:"schema_version" json:"schema_version"`
+	Timestamp     string                    `yaml:"timestamp" json:"timestamp"`
+	CommitHash    string                    `yaml:"commit_hash,omitempty" json:"commit_hash,omitempty"`
+	Tag           string                    `yaml:"tag,omitempty" json:"tag,omitempty"` // git tag created by commit --tag
+	GitStatus     string                    `yaml:"git_status,omitempty" json:"git_status,omitempty"`
+	DiffFile      string                    `yaml:"diff_file,omitempty" json:"diff_file,omitempty"`
+	FilesChanged  []FileChange              `yaml:"files_changed,omitempty" json:"files_changed,omitempty"`
+	Context       context.CheckpointContext `yaml:"context,omitempty" json:"context,omitempty"`
+	Changes       []Change                  `yaml:"changes" json:"changes"`
+	NextSteps     []NextStep                `yaml:"next_steps,omitempty" json:"next_steps,omitempty"`
+
+	// GitFiles is GitStatus parsed into per-file states; derived, never persisted
+	GitFiles []GitFileStatus `yaml:"-" json:"-"`
+}
+
+// GitFileStatus is one entry of `git status --porcelain=v1` output
+type GitFileStatus struct {
+	Path     string // Current path (destination for renames/copies)
+	OrigPath string // Source path for renames/copies
+	State    string // modified|added|deleted|renamed|copied|untracked|ignored|unmerged|typechange
+	Staged   bool   // Change is in the index
 }
 
 const (
@@ -46,6 +62,8 @@ const (
 # 3. Human will review and edit before running 'checkpoint commit'
 #
 # Each change has: summary (required), details (optional), change_type (required), scope (optional).
+# Set breaking: true on a change that breaks compatibility for users or callers.
+# Add refs: ["JIRA-123", "#456"] to tie a change to issues or tickets (quote IDs starting with #).
 # Allowed change_type values: feature, fix, refactor, docs, perf, other.
 # Keep summaries concise (<80 chars), present tense; use consistent scope names.
 # Derive distinct changes from git_status/diff context - group related file changes into logical units.
@@ -84,10 +102,10 @@ const (
 )
 
 type NextStep struct {
-	Summary  string `yaml:"summary"`
-	Details  string `yaml:"details,omitempty"`
-	Priority string `yaml:"priority,omitempty"` // low|med|high
-	Scope    string `yaml:"scope,omitempty"`
+	Summary  string `yaml:"summary" json:"summary"`
+	Details  string `yaml:"details,omitempty" json:"details,omitempty"`
+	Priority string `yaml:"priority,omitempty" json:"priority,omitempty"` // low|med|high
+	Scope    string `yaml:"scope,omitempty" json:"scope,omitempty"`
 }
 
 func GenerateInputTemplate(gitStatus, diffFileName string, prevNextSteps []NextStep) string {
@@ -123,7 +141,7 @@ timestamp: "%s"
 commit_hash: ""
 
 # Git status output (informational):
-git_status: |
+git_status: |2
 %s
 
 # Reference to diff context (path to git diff output):
@@ -140,6 +158,7 @@ changes:
     details: "[OPTIONAL: longer description]"
     change_type: "[FILL IN: feature|fix|refactor|docs|perf|other]"
     scope: "[FILL IN: affected component]"
+#    refs: ["JIRA-123", "#456"]  # optional issue/ticket IDs
 #  - summary: "[FILL IN: another change]"
 #    change_type: "[FILL IN]"
 #    scope: "[FILL IN]"
@@ -151,6 +170,67 @@ next_steps:
 `, LLMPrompt, SchemaVersion, ts, indent(gitStatus), diffFileName, filesSection, contextTemplate, prev)
 }
 
+// inputTemplateJSON is the JSON form of the input template. JSON has no
+// comments, so the LLM instructions travel in an ignored _instructions field.
+type inputTemplateJSON struct {
+	Instructions []string `json:"_instructions"`
+	CheckpointEntry
+}
+
+// GenerateInputTemplateJSON renders the input template as JSON for
+// .checkpoint-input.json. ParseInputFile accepts it the same as YAML.
+func GenerateInputTemplateJSON(gitStatus, diffFileName string, prevNextSteps []NextStep, filesChanged []FileChange) (string, error) {
+	tmpl := inputTemplateJSON{
+		Instructions: []string{
+			"Fill the changes array with all changes in this checkpoint, then run 'checkpoint lint'.",
+			"Each change has: summary (required), details (optional), change_type (required), scope (optional).",
+			"Set breaking: true on a change that breaks compatibility for users or callers.",
+			"Optionally list issue/ticket IDs a change addresses in refs, e.g. [\"JIRA-123\", \"#456\"].",
+			"Allowed change_type values: " + ValidChangeTypes + ".",
+			fmt.Sprintf("Keep summaries concise (<%d chars), present tense; use consistent scope names.", MaxSummaryLength),
+			"Fill context with the reasoning behind this checkpoint; remove optional items you do not use.",
+			"Optionally add next_steps (summary, details, priority low|med|high, scope).",
+			"If previous next_steps are present, remove completed items and keep unfinished ones.",
+			"Do not alter schema_version/timestamp; leave commit_hash empty.",
+		},
+		CheckpointEntry: CheckpointEntry{
+			SchemaVersion: SchemaVersion,
+			Timestamp:     time.Now().Format(time.RFC3339),
+			GitStatus:     gitStatus,
+			DiffFile:      diffFileName,
+			FilesChanged:  filesChanged,
+			Context: context.CheckpointContext{
+				ProblemStatement: "[REQUIRED: What problem is this checkpoint solving?]",
+				KeyInsights: []context.Insight{{
+					Insight: "[REQUIRED: What did you learn during implementation?]",
+					Scope:   "[OPTIONAL: checkpoint|project - default is checkpoint]",
+				}},
+				DecisionsMade: []context.Decision{{
+					Decision:  "[REQUIRED: Significant architectural/implementation choice]",
+					Rationale: "[REQUIRED: Why this approach over alternatives?]",
+					Scope:     "[OPTIONAL: checkpoint|project - default is checkpoint]",
+				}},
+			},
+			Changes: []Change{{
+				Summary:    "[FILL IN: what changed]",
+				Details:    "[OPTIONAL: longer description]",
+				ChangeType: "[FILL IN: feature|fix|refactor|docs|perf|other]",
+				Scope:      "[FILL IN: affected component]",
+			}},
+			NextSteps: prevNextSteps,
+		},
+	}
+
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(tmpl); err != nil {
+		return "", fmt.Errorf("marshal json: %w", err)
+	}
+	return b.String(), nil
+}
+
 // ExtractNextStepsFromStatus parses a status YAML and returns next_steps if present
 func ExtractNextStepsFromStatus(statusYAML string) []NextStep {
 	var aux struct {
@@ -182,16 +262,105 @@ func renderNextStepsYAML(steps []NextStep) string {
 	return b.String()
 }
 
+// ParseGitStatus parses porcelain v1 status text into per-file states. It
+// tolerates lines whose leading space was lost (e.g. after YAML round-trips).
+func ParseGitStatus(status string) []GitFileStatus {
+	var files []GitFileStatus
+	for _, line := range strings.Split(status, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		var x, y byte
+		var rest string
+		switch {
+		case len(line) > 3 && line[2] == ' ':
+			x, y, rest = line[0], line[1], line[3:]
+		case len(line) > 2 && line[1] == ' ':
+			x, y, rest = ' ', line[0], line[2:]
+		default:
+			continue
+		}
+
+		fs := GitFileStatus{Path: unquoteGitPath(rest)}
+		if idx := strings.Index(rest, " -> "); idx >= 0 {
+			fs.OrigPath = unquoteGitPath(rest[:idx])
+			fs.Path = unquoteGitPath(rest[idx+4:])
+		}
+		fs.State, fs.Staged = gitFileState(x, y)
+		files = append(files, fs)
+	}
+	return files
+}
+
+// gitFileState maps porcelain XY codes to a state name and whether the index holds the change
+func gitFileState(x, y byte) (string, bool) {
+	switch {
+	case x == '?' && y == '?':
+		return "untracked", false
+	case x == '!' && y == '!':
+		return "ignored", false
+	case x == 'U' || y == 'U' || (x == 'A' && y == 'A') || (x == 'D' && y == 'D'):
+		return "unmerged", false
+	}
+
+	code := y
+	if x != ' ' {
+		code = x
+	}
+	states := map[byte]string{
+		'M': "modified",
+		'A': "added",
+		'D': "deleted",
+		'R': "renamed",
+		'C': "copied",
+		'T': "typechange",
+	}
+	state, ok := states[code]
+	if !ok {
+		state = "modified"
+	}
+	return state, x != ' '
+}
+
+func unquoteGitPath(p string) string {
+	p = strings.TrimSpace(p)
+	if strings.HasPrefix(p, "\"") {
+		if uq, err := strconv.Unquote(p); err == nil {
+			return uq
+		}
+	}
+	return p
+}
+
+// ParseInputFile parses checkpoint input as JSON (when the content starts
+// with '{') or YAML.
 func ParseInputFile(content string) (*CheckpointEntry, error) {
-	trimmed := stripPrompt(content)
 	var e CheckpointEntry
+	if strings.HasPrefix(strings.TrimSpace(content), "{") {
+		if err := json.Unmarshal([]byte(content), &e); err != nil {
+			return nil, fmt.Errorf("parse json: %w", err)
+		}
+		e.GitFiles = ParseGitStatus(e.GitStatus)
+		return &e, nil
+	}
+	trimmed := stripPrompt(content)
 	if err := yaml.Unmarshal([]byte(trimmed), &e); err != nil {
 		return nil, fmt.Errorf("parse yaml: %w", err)
 	}
+	e.GitFiles = ParseGitStatus(e.GitStatus)
 	return &e, nil
 }
 
+// ValidateEntry checks e against the default rules
 func ValidateEntry(e *CheckpointEntry) error {
+	return ValidateEntryWithRules(e, DefaultRules())
+}
+
+// ValidateEntryWithRules checks required fields, change types and summary
+// lengths against a project's rules (see LoadRules)
+func ValidateEntryWithRules(e *CheckpointEntry, rules Rules) error {
 	var missing []string
 	if e.SchemaVersion == "" {
 		missing = append(missing, "schema_version")
@@ -206,7 +375,6 @@ func ValidateEntry(e *CheckpointEntry) error {
 		return fmt.Errorf("missing required fields: %s", strings.Join(missing, ", "))
 	}
 
-	valid := map[string]struct{}{"feature": {}, "fix": {}, "refactor": {}, "docs": {}, "perf": {}, "other": {}}
 	for i, c := range e.Changes {
 		summary := strings.TrimSpace(c.Summary)
 		if summary == "" {
@@ -215,14 +383,14 @@ func ValidateEntry(e *CheckpointEntry) error {
 		if isPlaceholder(summary) {
 			return fmt.Errorf("change[%d]: summary contains placeholder text", i)
 		}
-		if _, ok := valid[c.ChangeType]; !ok {
-			return fmt.Errorf("change[%d]: invalid change_type '%s' (valid: %s)", i, c.ChangeType, ValidChangeTypes)
+		if !rules.AllowsChangeType(c.ChangeType) {
+			return fmt.Errorf("change[%d]: invalid change_type '%s' (valid: %s)", i, c.ChangeType, rules.ChangeTypeList())
 		}
 		if isPlaceholder(c.ChangeType) {
 			return fmt.Errorf("change[%d]: change_type contains placeholder text", i)
 		}
-		if len([]rune(summary)) > MaxSummaryLength {
-			return fmt.Errorf("change[%d]: summary too long (%d > %d chars)", i, len([]rune(summary)), MaxSummaryLength)
+		if len([]rune(summary)) > rules.MaxSummaryLength {
+			return fmt.Errorf("change[%d]: summary too long (%d > %d chars)", i, len([]rune(summary)), rules.MaxSummaryLength)
 		}
 	}
 
@@ -243,9 +411,54 @@ func ValidateEntry(e *CheckpointEntry) error {
 		}
 	}
 
+	// The template marks a pattern's scope REQUIRED once the pattern is filled in
+	for i, p := range e.Context.EstablishedPatterns {
+		if s := strings.TrimSpace(p.Pattern); s == "" || isContextPlaceholder(s) {
+			continue
+		}
+		scope := strings.TrimSpace(p.Scope)
+		if scope == "" {
+			return fmt.Errorf("context.established_patterns[%d]: scope required (checkpoint|project)", i)
+		}
+		if isContextPlaceholder(scope) {
+			return fmt.Errorf("context.established_patterns[%d]: scope contains placeholder text", i)
+		}
+	}
+
 	return nil
 }
 
+// ValidateContext enforces the minimum reasoning a checkpoint must capture
+// when require_context is enabled: a real problem_statement and at least one
+// decision or insight. Placeholder items from the template do not count.
+func ValidateContext(e *CheckpointEntry) error {
+	ps := strings.TrimSpace(e.Context.ProblemStatement)
+	if ps == "" {
+		return fmt.Errorf("context.problem_statement: required")
+	}
+	if isContextPlaceholder(ps) {
+		return fmt.Errorf("context.problem_statement: contains placeholder text")
+	}
+
+	for _, d := range e.Context.DecisionsMade {
+		if s := strings.TrimSpace(d.Decision); s != "" && !isContextPlaceholder(s) {
+			return nil
+		}
+	}
+	for _, in := range e.Context.KeyInsights {
+		if s := strings.TrimSpace(in.Insight); s != "" && !isContextPlaceholder(s) {
+			return nil
+		}
+	}
+	return fmt.Errorf("context.decisions_made / context.key_insights: at least one decision or insight required")
+}
+
+// isContextPlaceholder also catches the [REQUIRED: ...] markers used in the
+// context section of the template
+func isContextPlaceholder(s string) bool {
+	return isPlaceholder(s) || strings.HasPrefix(strings.TrimSpace(strings.ToLower(s)), "[required")
+}
+
 // isPlaceholder detects placeholder text in input fields
 func isPlaceholder(s string) bool {
 	s = strings.TrimSpace(strings.ToLower(s))
@@ -316,6 +529,11 @@ func LintEntry(e *CheckpointEntry) []string {
 		if strings.Count(c.Summary, " and ") > 1 {
 			issues = append(issues, fmt.Sprintf("change[%d]: summary contains multiple 'and' - consider splitting into separate changes", i))
 		}
+
+		// Perf changes should say by how much
+		if changeType == "perf" && !measurementPattern.MatchString(c.Details) {
+			issues = append(issues, fmt.Sprintf("change[%d]: perf change should quantify the improvement in details", i))
+		}
 	}
 
 	// Check next_steps for placeholders
@@ -329,15 +547,105 @@ func LintEntry(e *CheckpointEntry) []string {
 		}
 	}
 
+	// Catch a half-filled context section
+	if ps := strings.TrimSpace(e.Context.ProblemStatement); ps == "" {
+		issues = append(issues, "context: problem_statement is empty")
+	} else if isContextPlaceholder(ps) {
+		issues = append(issues, "context: problem_statement contains placeholder text")
+	}
+	for i, d := range e.Context.DecisionsMade {
+		if s := strings.TrimSpace(d.Decision); s == "" || isContextPlaceholder(s) {
+			continue
+		}
+		if r := strings.TrimSpace(d.Rationale); r == "" || isContextPlaceholder(r) {
+			issues = append(issues, fmt.Sprintf("context.decisions_made[%d]: rationale missing for this decision", i))
+		}
+	}
+
 	return issues
 }
 
+// measurementPattern matches a number with a unit, such as "40ms", "2.5x",
+// "30%" or "12 MB"
+var measurementPattern = regexp.MustCompile(`(?i)\d+(?:\.\d+)?\s*(?:%|×|(?:ns|µs|us|ms|s|sec|secs|seconds|x|kb|mb|gb|ops/s|rps|qps)\b)`)
+
+// Lint issue severities. Errors block commit; warnings are suggestions.
+const (
+	LintSeverityError   = "error"
+	LintSeverityWarning = "warning"
+)
+
+// LintIssue is a structured form of a validation or lint message
+type LintIssue struct {
+	ChangeIndex int    `json:"change_index"` // -1 when the issue is not tied to a change
+	Field       string `json:"field"`
+	Severity    string `json:"severity"`
+	Message     string `json:"message"`
+}
+
+// ParseLintIssue converts a message from ValidateEntry or LintEntry, such as
+// "change[2]: summary contains placeholder text", into a LintIssue. Issues on
+// next_steps and the context section keep their path in the field (e.g.
+// "next_steps[0].summary", "context.established_patterns[1].scope").
+func ParseLintIssue(msg, severity string) LintIssue {
+	issue := LintIssue{ChangeIndex: -1, Severity: severity, Message: msg}
+
+	prefix, rest, ok := strings.Cut(msg, ": ")
+	if !ok {
+		return issue
+	}
+
+	// The field is named in the first two words ("summary required", "invalid change_type ...")
+	field := ""
+	words := strings.Fields(rest)
+	for i := 0; i < len(words) && i < 2 && field == ""; i++ {
+		switch words[i] {
+		case "summary", "details", "change_type", "scope", "priority", "problem_statement", "rationale":
+			field = words[i]
+		}
+	}
+
+	if prefix == "context" || strings.HasPrefix(prefix, "context.") {
+		issue.Field = prefix
+		if field != "" {
+			issue.Field += "." + field
+		}
+		issue.Message = rest
+		return issue
+	}
+
+	section, idx, ok := strings.Cut(prefix, "[")
+	if !ok || !strings.HasSuffix(idx, "]") {
+		return issue
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(idx, "]"))
+	if err != nil {
+		return issue
+	}
+
+	switch section {
+	case "change":
+		issue.ChangeIndex = n
+		issue.Field = field
+	case "next_steps":
+		issue.Field = prefix
+		if field != "" {
+			issue.Field += "." + field
+		}
+	default:
+		return issue
+	}
+	issue.Message = rest
+	return issue
+}
+
 // RenderChangelogDocument renders only the persisted fields (omits git_status/diff_file)
 func RenderChangelogDocument(e *CheckpointEntry) (string, error) {
 	out := struct {
 		SchemaVersion string       `yaml:"schema_version"`
 		Timestamp     string       `yaml:"timestamp"`
 		CommitHash    string       `yaml:"commit_hash"`
+		Tag           string       `yaml:"tag,omitempty"`
 		FilesChanged  []FileChange `yaml:"files_changed,omitempty"`
 		Changes       []Change     `yaml:"changes"`
 		NextSteps     []NextStep   `yaml:"next_steps"`
@@ -345,6 +653,7 @@ func RenderChangelogDocument(e *CheckpointEntry) (string, error) {
 		SchemaVersion: e.SchemaVersion,
 		Timestamp:     e.Timestamp,
 		CommitHash:    e.CommitHash,
+		Tag:           e.Tag,
 		FilesChanged:  e.FilesChanged,
 		Changes:       e.Changes,
 		NextSteps:     e.NextSteps,
@@ -356,18 +665,21 @@ func RenderChangelogDocument(e *CheckpointEntry) (string, error) {
 	return "---\n" + string(b), nil
 }
 
+// indent renders s as the body of a YAML literal block with an explicit
+// two-space indentation indicator (|2). Content is kept verbatim, so porcelain
+// status columns and filenames containing spaces or tabs survive parsing.
 func indent(s string) string {
 	if s == "" {
 		return s
 	}
 	lines := strings.Split(s, "\n")
 	for i, ln := range lines {
+		ln = strings.TrimRight(ln, "\r")
 		if ln == "" {
+			lines[i] = ln
 			continue
 		}
-		// Normalize leading whitespace to avoid YAML block issues
-		trimmed := strings.TrimLeft(ln, " \t")
-		lines[i] = "  " + trimmed
+		lines[i] = "  " + ln
 	}
 	return strings.Join(lines, "\n")
 }
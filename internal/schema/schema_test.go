@@ -3,6 +3,8 @@ package schema
 import (
 	"strings"
 	"testing"
+
+	"github.com/dmoose/checkpoint/internal/context"
 )
 
 func TestGenerateInputTemplateContainsFields(t *testing.T) {
@@ -47,6 +49,44 @@ Changes:
 	}
 }
 
+func TestParseInputFileJSON(t *testing.T) {
+	tmpl, err := GenerateInputTemplateJSON("M main.go", ".checkpoint-diff", nil, nil)
+	if err != nil {
+		t.Fatalf("GenerateInputTemplateJSON error: %v", err)
+	}
+	entry, err := ParseInputFile(tmpl)
+	if err != nil {
+		t.Fatalf("ParseInputFile(template) error: %v", err)
+	}
+	if entry.SchemaVersion != SchemaVersion || entry.DiffFile != ".checkpoint-diff" {
+		t.Fatalf("unexpected template entry: %+v", entry)
+	}
+	if err := ValidateEntry(entry); err == nil {
+		t.Fatalf("expected unfilled template to fail validation")
+	}
+
+	content := `{
+  "schema_version": "1",
+  "timestamp": "2025-10-22T16:00:00Z",
+  "changes": [{"summary": "Add auth", "change_type": "feature", "scope": "auth"}],
+  "context": {"problem_statement": "Users need login"}
+}`
+	entry, err = ParseInputFile(content)
+	if err != nil {
+		t.Fatalf("ParseInputFile error: %v", err)
+	}
+	if err := ValidateEntry(entry); err != nil {
+		t.Fatalf("ValidateEntry unexpected error: %v", err)
+	}
+	if entry.Context.ProblemStatement != "Users need login" {
+		t.Fatalf("context not parsed: %+v", entry.Context)
+	}
+
+	if _, err := ParseInputFile(`{"schema_version": "1",`); err == nil || !strings.Contains(err.Error(), "parse json") {
+		t.Fatalf("expected parse json error, got %v", err)
+	}
+}
+
 func TestValidateEntryErrors(t *testing.T) {
 	// Missing required fields
 	bad := &CheckpointEntry{}
@@ -60,6 +100,44 @@ func TestValidateEntryErrors(t *testing.T) {
 	}
 }
 
+func TestValidateContext(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     context.CheckpointContext
+		wantErr string
+	}{
+		{"empty problem statement", context.CheckpointContext{}, "context.problem_statement: required"},
+		{"template problem statement", context.CheckpointContext{ProblemStatement: "[REQUIRED: What problem is this checkpoint solving?]"}, "context.problem_statement: contains placeholder"},
+		{"no decisions or insights", context.CheckpointContext{ProblemStatement: "Slow startup"}, "context.decisions_made"},
+		{"only placeholder insight", context.CheckpointContext{
+			ProblemStatement: "Slow startup",
+			KeyInsights:      []context.Insight{{Insight: "[REQUIRED: What did you learn?]"}},
+		}, "context.key_insights"},
+		{"decision present", context.CheckpointContext{
+			ProblemStatement: "Slow startup",
+			DecisionsMade:    []context.Decision{{Decision: "Load config lazily"}},
+		}, ""},
+		{"insight present", context.CheckpointContext{
+			ProblemStatement: "Slow startup",
+			KeyInsights:      []context.Insight{{Insight: "Config parsing dominated startup"}},
+		}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateContext(&CheckpointEntry{Context: tt.ctx})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
 func TestLintEntry(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -72,6 +150,7 @@ func TestLintEntry(t *testing.T) {
 			entry: &CheckpointEntry{
 				SchemaVersion: "1",
 				Timestamp:     "2025-01-01T00:00:00Z",
+				Context:       context.CheckpointContext{ProblemStatement: "Users cannot log in"},
 				Changes: []Change{
 					{Summary: "Add user authentication endpoint", ChangeType: "feature", Scope: "api"},
 				},
@@ -83,6 +162,7 @@ func TestLintEntry(t *testing.T) {
 			entry: &CheckpointEntry{
 				SchemaVersion: "1",
 				Timestamp:     "2025-01-01T00:00:00Z",
+				Context:       context.CheckpointContext{ProblemStatement: "Users cannot log in"},
 				Changes: []Change{
 					{Summary: "[FILL IN: what changed]", ChangeType: "feature"},
 				},
@@ -95,6 +175,7 @@ func TestLintEntry(t *testing.T) {
 			entry: &CheckpointEntry{
 				SchemaVersion: "1",
 				Timestamp:     "2025-01-01T00:00:00Z",
+				Context:       context.CheckpointContext{ProblemStatement: "Users cannot log in"},
 				Changes: []Change{
 					{Summary: "improve code", ChangeType: "refactor"},
 				},
@@ -107,6 +188,7 @@ func TestLintEntry(t *testing.T) {
 			entry: &CheckpointEntry{
 				SchemaVersion: "1",
 				Timestamp:     "2025-01-01T00:00:00Z",
+				Context:       context.CheckpointContext{ProblemStatement: "Users cannot log in"},
 				Changes: []Change{
 					{Summary: "Add auth and update config and fix tests", ChangeType: "feature"},
 				},
@@ -119,6 +201,7 @@ func TestLintEntry(t *testing.T) {
 			entry: &CheckpointEntry{
 				SchemaVersion: "1",
 				Timestamp:     "2025-01-01T00:00:00Z",
+				Context:       context.CheckpointContext{ProblemStatement: "Users cannot log in"},
 				Changes: []Change{
 					{Summary: "Add feature", ChangeType: "feature"},
 				},
@@ -129,6 +212,89 @@ func TestLintEntry(t *testing.T) {
 			wantIssues: 1,
 			wantMatch:  "next_steps",
 		},
+		{
+			name: "empty problem statement",
+			entry: &CheckpointEntry{
+				SchemaVersion: "1",
+				Timestamp:     "2025-01-01T00:00:00Z",
+				Changes: []Change{
+					{Summary: "Add user authentication endpoint", ChangeType: "feature"},
+				},
+			},
+			wantIssues: 1,
+			wantMatch:  "problem_statement is empty",
+		},
+		{
+			name: "placeholder problem statement",
+			entry: &CheckpointEntry{
+				SchemaVersion: "1",
+				Timestamp:     "2025-01-01T00:00:00Z",
+				Context:       context.CheckpointContext{ProblemStatement: "[REQUIRED: What problem is this checkpoint solving?]"},
+				Changes: []Change{
+					{Summary: "Add user authentication endpoint", ChangeType: "feature"},
+				},
+			},
+			wantIssues: 1,
+			wantMatch:  "problem_statement contains placeholder",
+		},
+		{
+			name: "decision without rationale",
+			entry: &CheckpointEntry{
+				SchemaVersion: "1",
+				Timestamp:     "2025-01-01T00:00:00Z",
+				Context: context.CheckpointContext{
+					ProblemStatement: "Users cannot log in",
+					DecisionsMade: []context.Decision{
+						{Decision: "[REQUIRED: Significant architectural/implementation choice]"},
+						{Decision: "Use JWT", Rationale: "[REQUIRED: Why this approach over alternatives?]"},
+						{Decision: "Store sessions in Redis", Rationale: "Shared across instances"},
+					},
+				},
+				Changes: []Change{
+					{Summary: "Add user authentication endpoint", ChangeType: "feature"},
+				},
+			},
+			wantIssues: 1,
+			wantMatch:  "decisions_made[1]",
+		},
+		{
+			name: "perf change without details",
+			entry: &CheckpointEntry{
+				SchemaVersion: "1",
+				Timestamp:     "2025-01-01T00:00:00Z",
+				Context:       context.CheckpointContext{ProblemStatement: "Search is slow"},
+				Changes: []Change{
+					{Summary: "Cache compiled search patterns", ChangeType: "perf"},
+				},
+			},
+			wantIssues: 1,
+			wantMatch:  "quantify",
+		},
+		{
+			name: "perf change without measurement",
+			entry: &CheckpointEntry{
+				SchemaVersion: "1",
+				Timestamp:     "2025-01-01T00:00:00Z",
+				Context:       context.CheckpointContext{ProblemStatement: "Search is slow"},
+				Changes: []Change{
+					{Summary: "Cache compiled search patterns", Details: "Much faster now", ChangeType: "perf"},
+				},
+			},
+			wantIssues: 1,
+			wantMatch:  "quantify",
+		},
+		{
+			name: "perf change with measurement",
+			entry: &CheckpointEntry{
+				SchemaVersion: "1",
+				Timestamp:     "2025-01-01T00:00:00Z",
+				Context:       context.CheckpointContext{ProblemStatement: "Search is slow"},
+				Changes: []Change{
+					{Summary: "Cache compiled search patterns", Details: "Search over 500 entries drops from 120ms to 15 ms", ChangeType: "perf"},
+				},
+			},
+			wantIssues: 0,
+		},
 	}
 
 	for _, tt := range tests {
@@ -159,7 +325,7 @@ func TestRenderChangelogDocument(t *testing.T) {
 		Timestamp:     "2025-01-01T00:00:00Z",
 		CommitHash:    "abc123",
 		Changes: []Change{
-			{Summary: "Add feature", ChangeType: "feature", Scope: "api"},
+			{Summary: "Add feature", ChangeType: "feature", Scope: "api", Refs: []string{"JIRA-123", "#456"}},
 		},
 		NextSteps: []NextStep{
 			{Summary: "Write tests", Priority: "high"},
@@ -183,6 +349,8 @@ func TestRenderChangelogDocument(t *testing.T) {
 		"commit_hash: abc123",
 		"changes:",
 		"summary: Add feature",
+		"- JIRA-123",
+		"- '#456'",
 		"next_steps:",
 	}
 	for _, check := range checks {
@@ -326,3 +494,127 @@ func TestParseNumStat(t *testing.T) {
 		})
 	}
 }
+
+func TestParseGitStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		want   GitFileStatus
+	}{
+		{"untracked", "?? newfile.go", GitFileStatus{Path: "newfile.go", State: "untracked"}},
+		{"unstaged modify", " M main.go", GitFileStatus{Path: "main.go", State: "modified"}},
+		{"staged modify", "M  main.go", GitFileStatus{Path: "main.go", State: "modified", Staged: true}},
+		{"staged add", "A  cmd/new.go", GitFileStatus{Path: "cmd/new.go", State: "added", Staged: true}},
+		{"unstaged delete", " D old.go", GitFileStatus{Path: "old.go", State: "deleted"}},
+		{"rename", "R  old.go -> new.go", GitFileStatus{Path: "new.go", OrigPath: "old.go", State: "renamed", Staged: true}},
+		{"quoted rename", `R  "a b.go" -> "c d.go"`, GitFileStatus{Path: "c d.go", OrigPath: "a b.go", State: "renamed", Staged: true}},
+		{"unmerged", "UU conflict.go", GitFileStatus{Path: "conflict.go", State: "unmerged"}},
+		{"lost leading space", "M main.go", GitFileStatus{Path: "main.go", State: "modified"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseGitStatus(tt.status)
+			if len(got) != 1 {
+				t.Fatalf("expected 1 entry, got %d: %+v", len(got), got)
+			}
+			if got[0] != tt.want {
+				t.Errorf("got %+v, want %+v", got[0], tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInputFilePopulatesGitFiles(t *testing.T) {
+	content := GenerateInputTemplate(" M main.go\n?? newfile.go\nR  a.go -> b.go\n", ".checkpoint-diff", nil)
+	entry, err := ParseInputFile(content)
+	if err != nil {
+		t.Fatalf("ParseInputFile error: %v", err)
+	}
+	if len(entry.GitFiles) != 3 {
+		t.Fatalf("expected 3 git files, got %d: %+v", len(entry.GitFiles), entry.GitFiles)
+	}
+	if entry.GitFiles[0].Path != "main.go" || entry.GitFiles[2].State != "renamed" {
+		t.Errorf("unexpected git files: %+v", entry.GitFiles)
+	}
+}
+
+func TestParseInputFileGitStatusWhitespace(t *testing.T) {
+	status := " M main.go\n?? dir with spaces/file name.go\n?? tab\tname.go\n\tleading-tab.go\n    deep indent.go\n"
+	content := GenerateInputTemplate(status, ".checkpoint-diff", nil)
+	entry, err := ParseInputFile(content)
+	if err != nil {
+		t.Fatalf("ParseInputFile error: %v", err)
+	}
+	if entry.GitStatus != status {
+		t.Errorf("git_status not preserved:\ngot  %q\nwant %q", entry.GitStatus, status)
+	}
+	if len(entry.GitFiles) < 3 {
+		t.Fatalf("expected at least 3 git files, got %d: %+v", len(entry.GitFiles), entry.GitFiles)
+	}
+	if entry.GitFiles[1].Path != "dir with spaces/file name.go" || entry.GitFiles[2].Path != "tab\tname.go" {
+		t.Errorf("unexpected git files: %+v", entry.GitFiles)
+	}
+}
+
+func TestParseLintIssue(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want LintIssue
+	}{
+		{"change[2]: summary contains placeholder text", LintIssue{ChangeIndex: 2, Field: "summary", Severity: LintSeverityWarning, Message: "summary contains placeholder text"}},
+		{"change[0]: invalid change_type 'x' (valid: feature)", LintIssue{ChangeIndex: 0, Field: "change_type", Severity: LintSeverityWarning, Message: "invalid change_type 'x' (valid: feature)"}},
+		{"next_steps[1]: priority must be low|med|high (got: x)", LintIssue{ChangeIndex: -1, Field: "next_steps[1].priority", Severity: LintSeverityWarning, Message: "priority must be low|med|high (got: x)"}},
+		{"context: problem_statement is empty", LintIssue{ChangeIndex: -1, Field: "context.problem_statement", Severity: LintSeverityWarning, Message: "problem_statement is empty"}},
+		{"context.established_patterns[1]: scope required (checkpoint|project)", LintIssue{ChangeIndex: -1, Field: "context.established_patterns[1].scope", Severity: LintSeverityWarning, Message: "scope required (checkpoint|project)"}},
+		{"missing required fields: timestamp", LintIssue{ChangeIndex: -1, Severity: LintSeverityWarning, Message: "missing required fields: timestamp"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.msg, func(t *testing.T) {
+			if got := ParseLintIssue(tt.msg, LintSeverityWarning); got != tt.want {
+				t.Errorf("ParseLintIssue(%q) = %+v, want %+v", tt.msg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateEntryPatternScope(t *testing.T) {
+	entry := func(patterns ...context.Pattern) *CheckpointEntry {
+		return &CheckpointEntry{
+			SchemaVersion: "1",
+			Timestamp:     "2025-01-01T00:00:00Z",
+			Changes:       []Change{{Summary: "Add parser", ChangeType: "feature"}},
+			Context:       context.CheckpointContext{EstablishedPatterns: patterns},
+		}
+	}
+
+	// The untouched template item and scoped patterns pass
+	ok := entry(
+		context.Pattern{Pattern: "[OPTIONAL: New convention established]", Scope: "[REQUIRED if present: checkpoint|project]"},
+		context.Pattern{Pattern: "Table-driven tests", Scope: "project"},
+	)
+	if err := ValidateEntry(ok); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := ValidateEntry(entry(context.Pattern{Pattern: "Table-driven tests"})); err == nil || !strings.Contains(err.Error(), "established_patterns[0]: scope required") {
+		t.Errorf("expected missing scope error, got %v", err)
+	}
+	if err := ValidateEntry(entry(context.Pattern{Pattern: "Table-driven tests", Scope: "[REQUIRED if present: checkpoint|project]"})); err == nil || !strings.Contains(err.Error(), "placeholder") {
+		t.Errorf("expected placeholder scope error, got %v", err)
+	}
+}
+
+func TestMeasurementPattern(t *testing.T) {
+	for _, text := range []string{"40ms faster", "2.5x throughput", "allocations down 30%", "uses 12 MB less", "3× fewer queries", "p99 1.2s"} {
+		if !measurementPattern.MatchString(text) {
+			t.Errorf("expected a measurement in %q", text)
+		}
+	}
+	for _, text := range []string{"", "much faster", "fixes issue 42", "v2 parser", "uses xml"} {
+		if measurementPattern.MatchString(text) {
+			t.Errorf("unexpected measurement in %q", text)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+// Package userconfig reads and writes the per-user defaults in
+// ~/.config/checkpoint/config.json.
+package userconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dmoose/checkpoint/pkg/config"
+)
+
+// Config is the typed view of config.json
+type Config struct {
+	Roots               []string `json:"roots,omitempty"`                // project roots to search for checkpoint projects
+	DefaultChangeType   string   `json:"default_change_type,omitempty"`  // default answer for the change type prompt
+	ConventionalCommits bool     `json:"conventional_commits,omitempty"` // commit uses --conventional by default
+}
+
+// Key describes a settable config.json key
+type Key struct {
+	Name        string
+	Kind        string // string, bool or list
+	Description string
+}
+
+// Keys are the known config.json keys, in display order
+var Keys = []Key{
+	{Name: "roots", Kind: "list", Description: "Project roots, comma-separated on set"},
+	{Name: "default_change_type", Kind: "string", Description: "Default change type for 'checkpoint check' prompts"},
+	{Name: "conventional_commits", Kind: "bool", Description: "Use Conventional Commits messages by default"},
+}
+
+// LookupKey returns the known key named name
+func LookupKey(name string) (Key, error) {
+	for _, k := range Keys {
+		if k.Name == name {
+			return k, nil
+		}
+	}
+	return Key{}, fmt.Errorf("unknown key '%s' (valid: %s)", name, strings.Join(KeyNames(), ", "))
+}
+
+// Path returns the location of config.json
+func Path() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, config.GlobalConfigDir, config.UserConfigFile), nil
+}
+
+// Load reads config.json; a missing file is an empty config
+func Load() (Config, error) {
+	var cfg Config
+	raw, err := readRaw()
+	if err != nil {
+		return cfg, err
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("parse %s: %w", config.UserConfigFile, err)
+	}
+	return cfg, nil
+}
+
+// Get returns the value of a known key, or nil when it is unset
+func Get(name string) (any, error) {
+	if _, err := LookupKey(name); err != nil {
+		return nil, err
+	}
+	raw, err := readRaw()
+	if err != nil {
+		return nil, err
+	}
+	return raw[name], nil
+}
+
+// All returns every value in config.json, including keys this version
+// does not know
+func All() (map[string]any, error) {
+	return readRaw()
+}
+
+// Set parses value for a known key and writes it to config.json, keeping
+// any other keys in the file
+func Set(name, value string) (any, error) {
+	key, err := LookupKey(name)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parseValue(key, value)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := readRaw()
+	if err != nil {
+		return nil, err
+	}
+	raw[name] = parsed
+	return parsed, writeRaw(raw)
+}
+
+// parseValue converts a command-line value to the key's JSON type
+func parseValue(key Key, value string) (any, error) {
+	switch key.Kind {
+	case "bool":
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%s must be true or false (got %q)", key.Name, value)
+		}
+		return b, nil
+	case "list":
+		items := []string{}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	default:
+		return strings.TrimSpace(value), nil
+	}
+}
+
+func readRaw() (map[string]any, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	raw := map[string]any{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return raw, nil
+		}
+		return nil, fmt.Errorf("read %s: %w", config.UserConfigFile, err)
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return raw, nil
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", config.UserConfigFile, err)
+	}
+	return raw, nil
+}
+
+func writeRaw(raw map[string]any) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create config directory: %w", err)
+	}
+	data, err := json.MarshalIndent(raw, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("write %s: %w", config.UserConfigFile, err)
+	}
+	return nil
+}
+
+// KeyNames returns the known key names, sorted
+func KeyNames() []string {
+	names := make([]string, len(Keys))
+	for i, k := range Keys {
+		names[i] = k.Name
+	}
+	sort.Strings(names)
+	return names
+}
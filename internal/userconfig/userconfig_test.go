@@ -0,0 +1,78 @@
+package userconfig
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dmoose/checkpoint/pkg/config"
+)
+
+func TestSetGetLoad(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load without a file: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, Config{}) {
+		t.Errorf("expected empty config, got %+v", cfg)
+	}
+
+	// Unknown keys already in the file survive a set
+	path := filepath.Join(home, config.GlobalConfigDir, config.UserConfigFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(`{"mcp": {"port": 7000}}`), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	if _, err := Set("roots", " ~/src, ,~/work "); err != nil {
+		t.Fatalf("Set roots: %v", err)
+	}
+	if _, err := Set("conventional_commits", "true"); err != nil {
+		t.Fatalf("Set conventional_commits: %v", err)
+	}
+	if _, err := Set("default_change_type", "fix"); err != nil {
+		t.Fatalf("Set default_change_type: %v", err)
+	}
+
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := Config{Roots: []string{"~/src", "~/work"}, DefaultChangeType: "fix", ConventionalCommits: true}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("Load = %+v, want %+v", cfg, want)
+	}
+
+	all, err := All()
+	if err != nil {
+		t.Fatalf("All: %v", err)
+	}
+	if _, ok := all["mcp"]; !ok {
+		t.Errorf("expected unknown key to be kept, got %v", all)
+	}
+
+	if v, err := Get("default_change_type"); err != nil || v != "fix" {
+		t.Errorf("Get = %v, %v", v, err)
+	}
+}
+
+func TestSetRejectsBadInput(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if _, err := Set("colour", "red"); err == nil || !strings.Contains(err.Error(), "valid: conventional_commits, default_change_type, roots") {
+		t.Errorf("expected unknown key error listing valid keys, got %v", err)
+	}
+	if _, err := Get("colour"); err == nil {
+		t.Error("expected Get to reject unknown keys")
+	}
+	if _, err := Set("conventional_commits", "maybe"); err == nil {
+		t.Error("expected non-bool value to be rejected")
+	}
+}
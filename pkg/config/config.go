@@ -6,9 +6,11 @@ const (
 	ContextFileName      = ".checkpoint-context.yaml"
 	ProjectFileName      = ".checkpoint-project.yaml"
 	InputFileName        = ".checkpoint-input"
+	InputJSONFileName    = ".checkpoint-input.json"
 	DiffFileName         = ".checkpoint-diff"
 	StatusFileName       = ".checkpoint-status.yaml"
 	LockFileName         = ".checkpoint-lock"
+	CommitLockFileName   = "checkpoint-commit.lock" // inside the git directory, held while commit writes
 	CheckpointMdFileName = "CHECKPOINT.md"
 
 	// Legacy file names (for backward compatibility)
@@ -22,6 +24,14 @@ const (
 	ExplainGuidelinesYaml   = "guidelines.yaml"
 	ExplainSkillsYaml       = "skills.yaml"
 	SkillsDir               = "skills"
+	LearningsFileName       = "learnings.yml"
+	LearnHistoryFileName    = ".learn-history"
+	InputBackupsDir         = ".input-backups"
+	ExplainCacheDir         = ".explain-cache"
+	SessionSnapshotsDir     = "session-snapshots"
+	RedactYaml              = "redact.yml"
+	SchemaYaml              = "schema.yml"
+	SkillUsageFileName      = ".skill-usage.json"
 
 	// Legacy names (for backward compatibility)
 	ExplainProjectYmlLegacy    = "project.yml"
@@ -33,4 +43,5 @@ const (
 	GlobalConfigDir    = ".config/checkpoint"
 	GlobalSkillsDir    = "skills"
 	GlobalTemplatesDir = "templates"
+	UserConfigFile     = "config.json"
 )